@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/spf13/cobra"
+)
+
+func newCalibrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calibrate",
+		Short: "Fit complexity scoring weights to actual hours",
+		Long: `Fit complexity scoring weights to the actualHours recorded on completed
+tasks and subtasks using least squares, and preview the thresholds the new
+weights would produce. At least 5 completed tasks with actual hours are needed.
+
+With --save the weights are written to .taskmaster/config.json, project-wide
+or, with --for-tag, as an override for the active tag.`,
+		Args:         noArgs,
+		SilenceUsage: true,
+		RunE:         runCalibrate,
+	}
+	cmd.Flags().Bool("save", false, "Save the calibrated weights to the project config")
+	cmd.Flags().Bool("for-tag", false, "Save the weights as an override for the active tag")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func runCalibrate(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format == OutputIDs {
+		return usageError("calibrate supports --output table or json")
+	}
+	svc, err := loadService(cmd)
+	if err != nil {
+		return err
+	}
+
+	result, err := svc.CalibrateComplexity()
+	if err != nil {
+		return err
+	}
+	if format == OutputJSON {
+		if err := writeJSON(cmd.OutOrStdout(), result); err != nil {
+			return err
+		}
+	} else if err := writeCalibration(cmd.OutOrStdout(), result); err != nil {
+		return err
+	}
+
+	if save, _ := cmd.Flags().GetBool("save"); save {
+		forTag, _ := cmd.Flags().GetBool("for-tag")
+		if err := svc.SaveScoringWeights(result.Weights, forTag); err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "Saved calibrated weights to .taskmaster/config.json")
+	}
+	return nil
+}
+
+func writeCalibration(w io.Writer, result *taskmaster.CalibrationResult) error {
+	fitted := make(map[string]bool, len(result.Fitted))
+	for _, name := range result.Fitted {
+		fitted[name] = true
+	}
+	current := result.CurrentWeights.WeightMap()
+	calibrated := result.Weights.WeightMap()
+
+	fmt.Fprintf(w, "Fitted to %d completed tasks (tag %s)\n", result.SampleSize, orDash(result.Tag))
+	fmt.Fprintf(w, "R² %.2f (current scores %.2f), RMSE %.1fh\n\n", result.RSquared, result.BaselineRSquared, result.RMSE)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "WEIGHT\tCURRENT\tCALIBRATED\t")
+	for _, name := range config.ScoringWeightNames {
+		note := ""
+		if !fitted[name] {
+			note = "kept"
+		}
+		fmt.Fprintf(tw, "%s\t%.2f\t%.2f\t%s\n", name, current[name], calibrated[name], note)
+	}
+	fmt.Fprintln(tw, "\t\t\t")
+	fmt.Fprintln(tw, "THRESHOLD\tCURRENT\tCALIBRATED\t")
+	fmt.Fprintf(tw, "low\t%d\t%d\t\n", result.CurrentThresholds.Low, result.CalibratedThresholds.Low)
+	fmt.Fprintf(tw, "medium\t%d\t%d\t\n", result.CurrentThresholds.Medium, result.CalibratedThresholds.Medium)
+	fmt.Fprintf(tw, "high\t%d\t%d\t\n", result.CurrentThresholds.High, result.CalibratedThresholds.High)
+	return tw.Flush()
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes returned by the headless subcommands.
+const (
+	ExitOK         = 0 // Command succeeded
+	ExitFailure    = 1 // Unexpected failure (I/O, missing project, ...)
+	ExitUsage      = 2 // Invalid arguments or flag values
+	ExitNotFound   = 3 // Requested task does not exist or nothing is ready
+	ExitValidation = 4 // validate found warnings
+)
+
+// ExitError carries the process exit code for a failed command. A nil Err
+// means the command already reported the problem on its output and only the
+// exit code should be propagated.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
+
+func usageError(format string, args ...interface{}) error {
+	return &ExitError{Code: ExitUsage, Err: fmt.Errorf(format, args...)}
+}
+
+func notFoundError(format string, args ...interface{}) error {
+	return &ExitError{Code: ExitNotFound, Err: fmt.Errorf(format, args...)}
+}
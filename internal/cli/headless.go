@@ -0,0 +1,290 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/spf13/cobra"
+)
+
+// addHeadlessCommands registers the non-interactive subcommands used by
+// scripts and CI jobs. They share the taskmaster.Service used by the TUI.
+func addHeadlessCommands(root *cobra.Command) {
+	root.AddCommand(
+		newListCommand(),
+		newShowCommand(),
+		newNextCommand(),
+		newSetStatusCommand(),
+		newValidateCommand(),
+		newCalibrateCommand(),
+		newTimesheetCommand(),
+		newServeCommand(),
+	)
+}
+
+func newListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List tasks",
+		Long: `List tasks in the active tag. Filters can be repeated or given as
+comma-separated values; a task must match every filter that is set.
+
+--query takes the same query language as the TUI search bar, e.g.
+  status:pending priority:>=high tag:api dep:12 complexity:>7 -status:done "free text"`,
+		Args:         noArgs,
+		SilenceUsage: true,
+		RunE:         runList,
+	}
+	cmd.Flags().StringSlice("status", nil, "Only include tasks with these statuses")
+	cmd.Flags().StringSlice("priority", nil, "Only include tasks with these priorities")
+	cmd.Flags().StringP("query", "q", "", "Only include tasks matching this query")
+	cmd.Flags().Bool("with-subtasks", false, "Include subtasks")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func newShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "show <id>",
+		Short:        "Show a task and its subtasks",
+		Args:         exactArgs(1),
+		SilenceUsage: true,
+		RunE:         runShow,
+	}
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func newNextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "next",
+		Short: "Show the next task to work on",
+		Long: `Show the highest ranked pending task whose dependencies are complete.
+Exits with status 3 when no task is ready.`,
+		Args:         noArgs,
+		SilenceUsage: true,
+		RunE:         runNext,
+	}
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func newSetStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-status <id> <status>",
+		Short: "Set the status of a task",
+		Long: `Set the status of a task by writing tasks.json directly.
+Valid statuses: pending, in-progress, blocked, deferred, done, cancelled.`,
+		Args:         exactArgs(2),
+		SilenceUsage: true,
+		RunE:         runSetStatus,
+	}
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func newValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check tasks for dependency and consistency problems",
+		Long: `Check tasks for missing dependencies, circular dependencies and invalid
+fields. Exits with status 4 when any warnings are found.`,
+		Args:         noArgs,
+		SilenceUsage: true,
+		RunE:         runValidate,
+	}
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	statuses, _ := cmd.Flags().GetStringSlice("status")
+	priorities, _ := cmd.Flags().GetStringSlice("priority")
+	queryText, _ := cmd.Flags().GetString("query")
+	withSubtasks, _ := cmd.Flags().GetBool("with-subtasks")
+
+	for _, status := range statuses {
+		if probe := (taskmaster.Task{Status: status}); !probe.IsValidStatus() {
+			return usageError("invalid status %q", status)
+		}
+	}
+	for _, priority := range priorities {
+		if probe := (taskmaster.Task{Priority: priority}); priority == "" || !probe.IsValidPriority() {
+			return usageError("invalid priority %q", priority)
+		}
+	}
+	query, err := taskmaster.ParseQuery(queryText)
+	if err != nil {
+		return usageError("invalid query: %v", err)
+	}
+
+	svc, err := loadService(cmd)
+	if err != nil {
+		return err
+	}
+	tasks, _ := svc.GetTasks()
+
+	var matches []*taskmaster.Task
+	var walk func(tasks []taskmaster.Task)
+	walk = func(tasks []taskmaster.Task) {
+		for i := range tasks {
+			task := &tasks[i]
+			if matchesAny(task.Status, statuses) && matchesAny(task.Priority, priorities) && query.Match(task) {
+				matches = append(matches, task)
+			}
+			if withSubtasks {
+				walk(task.Subtasks)
+			}
+		}
+	}
+	walk(tasks)
+
+	return writeTasks(cmd.OutOrStdout(), format, matches)
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	svc, err := loadService(cmd)
+	if err != nil {
+		return err
+	}
+	task, ok := svc.GetTaskByID(args[0])
+	if !ok {
+		return notFoundError("task not found: %s", args[0])
+	}
+	return writeTaskDetail(cmd.OutOrStdout(), format, task)
+}
+
+func runNext(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	svc, err := loadService(cmd)
+	if err != nil {
+		return err
+	}
+	task, err := svc.GetNextTask()
+	if err != nil {
+		return &ExitError{Code: ExitNotFound, Err: err}
+	}
+	return writeTasks(cmd.OutOrStdout(), format, []*taskmaster.Task{task})
+}
+
+func runSetStatus(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	taskID, status := args[0], args[1]
+	if probe := (taskmaster.Task{Status: status}); !probe.IsValidStatus() {
+		return usageError("invalid status %q", status)
+	}
+
+	svc, err := loadService(cmd)
+	if err != nil {
+		return err
+	}
+	if _, ok := svc.GetTaskByID(taskID); !ok {
+		return notFoundError("task not found: %s", taskID)
+	}
+	if err := svc.SetTaskStatus(taskID, status); err != nil {
+		return fmt.Errorf("failed to set status of task %s: %w", taskID, err)
+	}
+
+	task, ok := svc.GetTaskByID(taskID)
+	if !ok {
+		return notFoundError("task not found: %s", taskID)
+	}
+	return writeTasks(cmd.OutOrStdout(), format, []*taskmaster.Task{task})
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	svc, err := loadService(cmd)
+	if err != nil {
+		return err
+	}
+
+	warnings := svc.GetValidationWarnings()
+	if err := writeWarnings(cmd.OutOrStdout(), format, warnings); err != nil {
+		return err
+	}
+	if len(warnings) > 0 {
+		return &ExitError{Code: ExitValidation}
+	}
+	return nil
+}
+
+// loadService loads the configuration and tasks for the current directory,
+// honouring the persistent --tag flag.
+func loadService(cmd *cobra.Command) (*taskmaster.Service, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if tag, _ := cmd.Flags().GetString("tag"); tag != "" {
+		cfg.ActiveTag = tag
+	}
+	if cfg.TaskMasterPath == "" {
+		return nil, errors.New("no .taskmaster directory found; run 'task-master init' first")
+	}
+
+	svc, err := taskmaster.NewService(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize task service: %w", err)
+	}
+	if !svc.IsAvailable() {
+		return nil, errors.New("no .taskmaster directory found; run 'task-master init' first")
+	}
+	return svc, nil
+}
+
+func matchesAny(value string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, candidate := range allowed {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func noArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return usageError("%s takes no arguments, got %d", cmd.CommandPath(), len(args))
+	}
+	return nil
+}
+
+// rootArgs rejects arguments to the root command, which are mistyped
+// subcommands, with the usage exit code.
+func rootArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return usageError("unknown command %q for %q", args[0], cmd.CommandPath())
+	}
+	return nil
+}
+
+func exactArgs(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != n {
+			return usageError("usage: %s", cmd.UseLine())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/memory"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/timetrack"
+)
+
+// setupProject writes tasks.json into a temporary project and makes it the
+// working directory so config.Load discovers it.
+func setupProject(t *testing.T, tasks []taskmaster.Task) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	tasksDir := filepath.Join(tmpDir, ".taskmaster", "tasks")
+	if err := os.MkdirAll(tasksDir, 0755); err != nil {
+		t.Fatalf("failed to create tasks dir: %v", err)
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{"tasks": tasks}, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal tasks: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tasksDir, "tasks.json"), data, 0644); err != nil {
+		t.Fatalf("failed to write tasks: %v", err)
+	}
+	t.Chdir(tmpDir)
+	return tmpDir
+}
+
+func headlessFixture() []taskmaster.Task {
+	return []taskmaster.Task{
+		{ID: "1", Title: "Setup", Status: taskmaster.StatusDone, Priority: taskmaster.PriorityHigh},
+		{ID: "2", Title: "Build", Status: taskmaster.StatusPending, Priority: taskmaster.PriorityMedium, Dependencies: []string{"1"},
+			Subtasks: []taskmaster.Task{
+				{ID: "2.1", Title: "First", Status: taskmaster.StatusPending},
+			},
+		},
+		{ID: "3", Title: "Ship", Status: taskmaster.StatusPending, Priority: taskmaster.PriorityLow, Dependencies: []string{"2"}},
+	}
+}
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewRootCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestListFiltersAndFormats(t *testing.T) {
+	setupProject(t, headlessFixture())
+
+	out, err := runCommand(t, "list", "--status", "pending", "--output", "ids")
+	if err != nil {
+		t.Fatalf("list returned error: %v", err)
+	}
+	if got := strings.Fields(out); strings.Join(got, " ") != "2 3" {
+		t.Fatalf("expected pending ids [2 3], got %v", got)
+	}
+
+	out, err = runCommand(t, "list", "--status", "pending", "--priority", "low", "--with-subtasks", "-o", "json")
+	if err != nil {
+		t.Fatalf("list returned error: %v", err)
+	}
+	var records []taskRecord
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatalf("list output is not JSON: %v\n%s", err, out)
+	}
+	if len(records) != 1 || records[0].ID != "3" || records[0].Dependencies[0] != "2" {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+
+	if _, err := runCommand(t, "list", "--status", "bogus"); ExitCode(err) != ExitUsage {
+		t.Fatalf("expected usage exit code for invalid status, got %d (%v)", ExitCode(err), err)
+	}
+	out, err = runCommand(t, "list", "--with-subtasks", "-q", "status:pending -dep:2", "-o", "ids")
+	if err != nil {
+		t.Fatalf("list returned error: %v", err)
+	}
+	if got := strings.Fields(out); strings.Join(got, " ") != "2 2.1" {
+		t.Fatalf("expected query ids [2 2.1], got %v", got)
+	}
+	if _, err := runCommand(t, "list", "--query", "priority:>urgent"); ExitCode(err) != ExitUsage {
+		t.Fatalf("expected usage exit code for invalid query, got %d (%v)", ExitCode(err), err)
+	}
+	if _, err := runCommand(t, "list", "-o", "yaml"); ExitCode(err) != ExitUsage {
+		t.Fatalf("expected usage exit code for invalid output, got %d (%v)", ExitCode(err), err)
+	}
+}
+
+func TestShowAndNext(t *testing.T) {
+	setupProject(t, headlessFixture())
+
+	out, err := runCommand(t, "show", "2")
+	if err != nil {
+		t.Fatalf("show returned error: %v", err)
+	}
+	if !strings.Contains(out, "Build") || !strings.Contains(out, "Subtasks:") || !strings.Contains(out, "2.1") {
+		t.Fatalf("unexpected show output:\n%s", out)
+	}
+
+	if _, err := runCommand(t, "show", "42"); ExitCode(err) != ExitNotFound {
+		t.Fatalf("expected not-found exit code, got %d (%v)", ExitCode(err), err)
+	}
+
+	out, err = runCommand(t, "next", "-o", "ids")
+	if err != nil {
+		t.Fatalf("next returned error: %v", err)
+	}
+	if strings.TrimSpace(out) != "2" {
+		t.Fatalf("expected task 2 to be next, got %q", out)
+	}
+}
+
+func TestSetStatusWritesTasksFile(t *testing.T) {
+	dir := setupProject(t, headlessFixture())
+
+	if _, err := runCommand(t, "set-status", "3", "in-progress", "-o", "ids"); err != nil {
+		t.Fatalf("set-status returned error: %v", err)
+	}
+	tasks, err := taskmaster.LoadTasksFromFile(dir, "")
+	if err != nil {
+		t.Fatalf("failed to reload tasks: %v", err)
+	}
+	if tasks[2].Status != taskmaster.StatusInProgress {
+		t.Fatalf("expected persisted status in-progress, got %q", tasks[2].Status)
+	}
+
+	if _, err := runCommand(t, "set-status", "3", "bogus"); ExitCode(err) != ExitUsage {
+		t.Fatalf("expected usage exit code for invalid status, got %d", ExitCode(err))
+	}
+	if _, err := runCommand(t, "set-status", "9", "done"); ExitCode(err) != ExitNotFound {
+		t.Fatalf("expected not-found exit code, got %d", ExitCode(err))
+	}
+}
+
+func TestValidateExitCode(t *testing.T) {
+	setupProject(t, headlessFixture())
+	if _, err := runCommand(t, "validate"); err != nil {
+		t.Fatalf("expected clean validation, got %v", err)
+	}
+
+	tasks := headlessFixture()
+	tasks[2].Dependencies = []string{"99"}
+	setupProject(t, tasks)
+
+	out, err := runCommand(t, "validate", "-o", "json")
+	if ExitCode(err) != ExitValidation {
+		t.Fatalf("expected validation exit code, got %d (%v)", ExitCode(err), err)
+	}
+	var warnings []warningRecord
+	if err := json.Unmarshal([]byte(out), &warnings); err != nil || len(warnings) == 0 {
+		t.Fatalf("expected JSON warnings, got %q (%v)", out, err)
+	}
+	if warnings[0].TaskID != "3" {
+		t.Fatalf("expected warning for task 3, got %+v", warnings)
+	}
+}
+
+func TestUsageErrorsExitCode(t *testing.T) {
+	setupProject(t, headlessFixture())
+	for _, args := range [][]string{
+		{"list", "--bogus"},
+		{"show"},
+		{"list", "--with-subtasks=maybe"},
+		{"lsit"},
+	} {
+		if _, err := runCommand(t, args...); ExitCode(err) != ExitUsage {
+			t.Errorf("%v: expected usage exit code, got %d (%v)", args, ExitCode(err), err)
+		}
+	}
+}
+
+func TestCalibrateCommand(t *testing.T) {
+	setupProject(t, headlessFixture())
+	if _, err := runCommand(t, "calibrate"); err == nil || ExitCode(err) != ExitFailure {
+		t.Fatalf("expected calibrate to fail without enough samples, got %v", err)
+	}
+
+	var tasks []taskmaster.Task
+	for i, hours := range []float64{2, 4, 6, 5, 9} {
+		id := string(rune('a' + i))
+		task := taskmaster.Task{ID: id, Title: "Done " + id, Status: taskmaster.StatusDone, ActualHours: hours}
+		for j := 0; j < int(hours)/2; j++ {
+			task.Subtasks = append(task.Subtasks, taskmaster.Task{ID: id + "." + string(rune('1'+j)), Title: "Step", Status: taskmaster.StatusDone})
+		}
+		tasks = append(tasks, task)
+	}
+	root := setupProject(t, tasks)
+
+	out, err := runCommand(t, "calibrate", "-o", "json")
+	if err != nil {
+		t.Fatalf("calibrate returned error: %v\n%s", err, out)
+	}
+	var result taskmaster.CalibrationResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil || result.SampleSize != 5 {
+		t.Fatalf("expected JSON result with 5 samples, got %s (%v)", out, err)
+	}
+
+	if out, err := runCommand(t, "calibrate", "--save"); err != nil || !strings.Contains(out, "CALIBRATED") {
+		t.Fatalf("calibrate --save failed: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(filepath.Join(root, ".taskmaster", "config.json"))
+	if err != nil || !strings.Contains(string(data), "subtaskCount") {
+		t.Fatalf("expected weights saved to config.json, got %s (%v)", data, err)
+	}
+}
+
+func TestTimesheetCommand(t *testing.T) {
+	root := setupProject(t, headlessFixture())
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	state := fmt.Sprintf(`{"entries":[{"taskId":"2.1","start":%q,"end":%q},{"taskId":"3","start":%q,"end":%q}]}`,
+		day.Format(time.RFC3339), day.Add(90*time.Minute).Format(time.RFC3339),
+		day.AddDate(0, 0, 1).Format(time.RFC3339), day.AddDate(0, 0, 1).Add(time.Hour).Format(time.RFC3339))
+	store, err := memory.OpenStore(root)
+	if err != nil {
+		t.Fatalf("failed to open memory store: %v", err)
+	}
+	store.Store(context.Background(), timetrack.StateKey, []byte(state))
+	store.Close()
+
+	out, err := runCommand(t, "timesheet", "--from", "2026-03-02", "--to", "2026-03-03")
+	if err != nil {
+		t.Fatalf("timesheet returned error: %v\n%s", err, out)
+	}
+	for _, want := range []string{"2026-03-02  2.1", "First", "1.50", "2026-03-03  3", "total"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in table output:\n%s", want, out)
+		}
+	}
+
+	out, err = runCommand(t, "timesheet", "--from", "2026-03-03", "--to", "2026-03-03", "--csv")
+	if err != nil || out != "date,task_id,title,hours\n2026-03-03,3,Ship,1.00\n" {
+		t.Fatalf("unexpected CSV output (%v):\n%s", err, out)
+	}
+
+	if _, err := runCommand(t, "timesheet", "--from", "tomorrow"); ExitCode(err) != ExitUsage {
+		t.Fatalf("expected usage error for invalid date, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/spf13/cobra"
+)
+
+// Output formats accepted by --output.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputIDs   = "ids"
+)
+
+// taskRecord is the flat representation of a task in list-style output.
+type taskRecord struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Status       string   `json:"status"`
+	Priority     string   `json:"priority,omitempty"`
+	Dependencies []string `json:"dependencies"`
+	ParentID     string   `json:"parentId,omitempty"`
+	Complexity   int      `json:"complexity,omitempty"`
+	Subtasks     int      `json:"subtaskCount"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+// warningRecord is the JSON representation of a validation warning.
+type warningRecord struct {
+	TaskID  string `json:"taskId"`
+	Message string `json:"message"`
+}
+
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", OutputTable, "Output format: table, json or ids")
+}
+
+func outputFormat(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("output")
+	switch format {
+	case OutputTable, OutputJSON, OutputIDs:
+		return format, nil
+	default:
+		return "", usageError("invalid output format %q (want table, json or ids)", format)
+	}
+}
+
+func newTaskRecord(task *taskmaster.Task) taskRecord {
+	deps := task.Dependencies
+	if deps == nil {
+		deps = []string{}
+	}
+	return taskRecord{
+		ID:           task.ID,
+		Title:        task.Title,
+		Status:       task.Status,
+		Priority:     task.Priority,
+		Dependencies: deps,
+		ParentID:     task.ParentID,
+		Complexity:   task.Complexity,
+		Subtasks:     len(task.Subtasks),
+		Tags:         task.Tags,
+	}
+}
+
+// writeTasks writes one row per task.
+func writeTasks(w io.Writer, format string, tasks []*taskmaster.Task) error {
+	switch format {
+	case OutputJSON:
+		records := make([]taskRecord, 0, len(tasks))
+		for _, task := range tasks {
+			records = append(records, newTaskRecord(task))
+		}
+		return writeJSON(w, records)
+	case OutputIDs:
+		for _, task := range tasks {
+			if _, err := fmt.Fprintln(w, task.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATUS\tPRIORITY\tDEPENDENCIES\tTITLE")
+	for _, task := range tasks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			task.ID, task.Status, orDash(task.Priority), orDash(strings.Join(task.Dependencies, ",")), task.Title)
+	}
+	return tw.Flush()
+}
+
+// writeTaskDetail writes a single task including its subtasks.
+func writeTaskDetail(w io.Writer, format string, task *taskmaster.Task) error {
+	switch format {
+	case OutputJSON:
+		return writeJSON(w, task)
+	case OutputIDs:
+		_, err := fmt.Fprintln(w, task.ID)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", task.ID)
+	fmt.Fprintf(tw, "Title:\t%s\n", task.Title)
+	fmt.Fprintf(tw, "Status:\t%s\n", task.Status)
+	fmt.Fprintf(tw, "Priority:\t%s\n", orDash(task.Priority))
+	fmt.Fprintf(tw, "Dependencies:\t%s\n", orDash(strings.Join(task.Dependencies, ", ")))
+	if task.Complexity > 0 {
+		fmt.Fprintf(tw, "Complexity:\t%d\n", task.Complexity)
+	}
+	if len(task.Tags) > 0 {
+		fmt.Fprintf(tw, "Tags:\t%s\n", strings.Join(task.Tags, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, section := range []struct{ title, body string }{
+		{"Description", task.Description},
+		{"Details", task.Details},
+		{"Test Strategy", task.TestStrategy},
+	} {
+		if strings.TrimSpace(section.body) == "" {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n%s\n", section.title, strings.TrimRight(section.body, "\n"))
+	}
+
+	if len(task.Subtasks) > 0 {
+		fmt.Fprintln(w, "\nSubtasks:")
+		subtasks := make([]*taskmaster.Task, 0, len(task.Subtasks))
+		for i := range task.Subtasks {
+			subtasks = append(subtasks, &task.Subtasks[i])
+		}
+		return writeTasks(w, OutputTable, subtasks)
+	}
+	return nil
+}
+
+// writeWarnings writes validation warnings. The ids format lists each task
+// with warnings once.
+func writeWarnings(w io.Writer, format string, warnings []taskmaster.ValidationWarning) error {
+	switch format {
+	case OutputJSON:
+		records := make([]warningRecord, 0, len(warnings))
+		for _, warning := range warnings {
+			records = append(records, warningRecord{TaskID: warning.TaskID, Message: warning.Message})
+		}
+		return writeJSON(w, records)
+	case OutputIDs:
+		seen := make(map[string]bool)
+		for _, warning := range warnings {
+			if seen[warning.TaskID] {
+				continue
+			}
+			seen[warning.TaskID] = true
+			if _, err := fmt.Fprintln(w, warning.TaskID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(warnings) == 0 {
+		_, err := fmt.Fprintln(w, "No validation warnings")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TASK\tWARNING")
+	for _, warning := range warnings {
+		fmt.Fprintf(tw, "%s\t%s\n", warning.TaskID, warning.Message)
+	}
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
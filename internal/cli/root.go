@@ -23,13 +23,22 @@ func NewRootCommand() *cobra.Command {
 		Long: `Task Master TUI is a terminal user interface for managing and executing
 development tasks with Task Master AI. It provides an interactive way to
 view, organize, and track your project tasks.`,
-		RunE: runTUI,
+		Args:          rootArgs,
+		RunE:          runTUI,
+		SilenceErrors: true,
 	}
-	
+
+	// Flag parsing errors are usage errors; subcommands inherit this
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return &ExitError{Code: ExitUsage, Err: err}
+	})
+
 	// Add flags
 	cmd.PersistentFlags().Bool("clear-state", false, "Clear the TUI state before starting")
 	cmd.PersistentFlags().String("tag", "", "Specify the active tag to use for loading tasks (defaults to 'master')")
 
+	addHeadlessCommands(cmd)
+
 	return cmd
 }
 
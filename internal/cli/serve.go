@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/agreen757/tm-tui/internal/server"
+	"github.com/spf13/cobra"
+)
+
+func newServeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve tasks over a local HTTP/JSON API",
+		Long: `Serve the active tag's tasks over a local HTTP/JSON API for editors and
+dashboards. Changes to tasks.json are pushed to clients on /api/events as
+Server-Sent Events.
+
+Use --socket to listen on a Unix socket instead of TCP, and --token (or the
+TM_TUI_TOKEN environment variable) to require a bearer token on every request.
+A non-loopback --addr is refused unless a token is set.`,
+		Args:         noArgs,
+		SilenceUsage: true,
+		RunE:         runServe,
+	}
+	cmd.Flags().String("addr", "127.0.0.1:7420", "TCP address to listen on")
+	cmd.Flags().String("socket", "", "Listen on this Unix socket instead of --addr")
+	cmd.Flags().String("token", "", "Require this bearer token (defaults to $TM_TUI_TOKEN)")
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	svc, err := loadService(cmd)
+	if err != nil {
+		return err
+	}
+	if err := svc.StartWatcher(ctx); err != nil {
+		// Log warning but don't fail - clients can still poll
+		fmt.Fprintf(os.Stderr, "Warning: failed to start task watcher: %v\n", err)
+	}
+	defer svc.StopWatcher()
+
+	addr, _ := cmd.Flags().GetString("addr")
+	socket, _ := cmd.Flags().GetString("socket")
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		token = os.Getenv("TM_TUI_TOKEN")
+	}
+
+	srv := server.New(svc, server.Options{Addr: addr, Socket: socket, Token: token})
+	fmt.Fprintf(cmd.ErrOrStderr(), "Serving tasks from %s on %s\n", svc.RootDir, srv.Address())
+	return srv.Serve(ctx)
+}
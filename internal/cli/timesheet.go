@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/timetrack"
+	"github.com/spf13/cobra"
+)
+
+func newTimesheetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timesheet",
+		Short: "Show time tracked per day and task",
+		Long: `Show the time tracked with the TUI task timer per day and task. The range
+is inclusive and defaults to the current week up to today. A timer that is
+still running counts up to now.
+
+With --csv the timesheet is written as CSV (date, task_id, title, hours)
+instead of a table.`,
+		Args:         noArgs,
+		SilenceUsage: true,
+		RunE:         runTimesheet,
+	}
+	cmd.Flags().String("from", "", "First day to include (YYYY-MM-DD, default: start of this week)")
+	cmd.Flags().String("to", "", "Last day to include (YYYY-MM-DD, default: today)")
+	cmd.Flags().Bool("csv", false, "Write CSV instead of a table")
+	addOutputFlag(cmd)
+	return cmd
+}
+
+func runTimesheet(cmd *cobra.Command, args []string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format == OutputIDs {
+		return usageError("timesheet supports --output table or json")
+	}
+	fromFlag, _ := cmd.Flags().GetString("from")
+	toFlag, _ := cmd.Flags().GetString("to")
+	from, to, err := timetrack.ParseDateRange(fromFlag, toFlag, time.Now())
+	if err != nil {
+		return usageError("%v", err)
+	}
+	svc, err := loadService(cmd)
+	if err != nil {
+		return err
+	}
+
+	sheet, err := svc.Timesheet(context.Background(), from, to)
+	if err != nil {
+		return err
+	}
+	if asCSV, _ := cmd.Flags().GetBool("csv"); asCSV {
+		return sheet.WriteCSV(cmd.OutOrStdout())
+	}
+	if format == OutputJSON {
+		return writeJSON(cmd.OutOrStdout(), sheet)
+	}
+	return writeTimesheet(cmd.OutOrStdout(), sheet)
+}
+
+// writeTimesheet writes one row per day and task followed by a total for
+// each day.
+func writeTimesheet(w io.Writer, sheet *timetrack.Timesheet) error {
+	if len(sheet.Rows) == 0 {
+		_, err := fmt.Fprintf(w, "No time tracked between %s and %s\n",
+			sheet.From.Format(timetrack.DateLayout), sheet.To.AddDate(0, 0, -1).Format(timetrack.DateLayout))
+		return err
+	}
+
+	totals := sheet.DailyTotals()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATE\tTASK\tTITLE\tHOURS")
+	for i, row := range sheet.Rows {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f\n", row.Date, row.TaskID, row.Title, row.Hours)
+		if i == len(sheet.Rows)-1 || sheet.Rows[i+1].Date != row.Date {
+			fmt.Fprintf(tw, "\t\tday total\t%.2f\n", totals[row.Date])
+		}
+	}
+	fmt.Fprintf(tw, "\t\ttotal\t%.2f\n", sheet.TotalHours)
+	return tw.Flush()
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Scoring weight names accepted under "complexity.weights".
+const (
+	WeightDescriptionLength  = "descriptionLength"
+	WeightSubtaskCount       = "subtaskCount"
+	WeightDependencyCount    = "dependencyCount"
+	WeightComplexityKeywords = "complexityKeywords"
+	WeightEstimatedHours     = "estimatedHours"
+	WeightPriorityFactor     = "priorityFactor"
+)
+
+// ScoringWeightNames lists every weight name accepted in config files.
+var ScoringWeightNames = []string{
+	WeightDescriptionLength,
+	WeightSubtaskCount,
+	WeightDependencyCount,
+	WeightComplexityKeywords,
+	WeightEstimatedHours,
+	WeightPriorityFactor,
+}
+
+// scoringPriorities lists the priorities accepted under "priorityWeights".
+// The empty key applies to tasks without a priority.
+var scoringPriorities = []string{"critical", "high", "medium", "low", ""}
+
+// ComplexityScoring overrides how complexity scores are computed. Every
+// field is optional; anything left unset keeps the built-in default.
+type ComplexityScoring struct {
+	Weights         map[string]float64 `json:"weights,omitempty"`
+	Keywords        []string           `json:"keywords,omitempty"`
+	PriorityWeights map[string]float64 `json:"priorityWeights,omitempty"`
+}
+
+// ComplexityConfig holds project-wide scoring overrides plus per-tag
+// overrides layered on top of them.
+type ComplexityConfig struct {
+	ComplexityScoring
+	Tags map[string]ComplexityScoring `json:"tags,omitempty"`
+}
+
+// ForTag returns the scoring overrides in effect for tag. Tag weights and
+// priority weights replace project values key by key; tag keywords replace
+// the project keyword list entirely.
+func (c ComplexityConfig) ForTag(tag string) ComplexityScoring {
+	resolved := ComplexityScoring{
+		Weights:         copyWeights(c.Weights),
+		Keywords:        append([]string(nil), c.Keywords...),
+		PriorityWeights: copyWeights(c.PriorityWeights),
+	}
+	override, ok := c.Tags[tag]
+	if !ok {
+		return resolved
+	}
+	for name, value := range override.Weights {
+		if resolved.Weights == nil {
+			resolved.Weights = make(map[string]float64)
+		}
+		resolved.Weights[name] = value
+	}
+	for priority, value := range override.PriorityWeights {
+		if resolved.PriorityWeights == nil {
+			resolved.PriorityWeights = make(map[string]float64)
+		}
+		resolved.PriorityWeights[priority] = value
+	}
+	if len(override.Keywords) > 0 {
+		resolved.Keywords = append([]string(nil), override.Keywords...)
+	}
+	return resolved
+}
+
+// Validate reports the first invalid weight, priority or keyword found in
+// the project settings or any tag override.
+func (c ComplexityConfig) Validate() error {
+	if err := c.ComplexityScoring.validate(); err != nil {
+		return err
+	}
+	tags := make([]string, 0, len(c.Tags))
+	for tag := range c.Tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("complexity.tags: tag name must not be empty")
+		}
+		if err := c.Tags[tag].validate(); err != nil {
+			return fmt.Errorf("tag %q: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+func (s ComplexityScoring) validate() error {
+	for name, value := range s.Weights {
+		if !containsString(ScoringWeightNames, name) {
+			return fmt.Errorf("complexity.weights: unknown weight %q (expected one of %s)", name, strings.Join(ScoringWeightNames, ", "))
+		}
+		if value < 0 {
+			return fmt.Errorf("complexity.weights.%s: must not be negative, got %g", name, value)
+		}
+	}
+	for priority, value := range s.PriorityWeights {
+		if !containsString(scoringPriorities, priority) {
+			return fmt.Errorf("complexity.priorityWeights: unknown priority %q", priority)
+		}
+		if value <= 0 {
+			return fmt.Errorf("complexity.priorityWeights.%s: must be positive, got %g", priority, value)
+		}
+	}
+	for i, keyword := range s.Keywords {
+		if strings.TrimSpace(keyword) == "" {
+			return fmt.Errorf("complexity.keywords[%d]: keyword must not be empty", i)
+		}
+	}
+	return nil
+}
+
+// merge layers other on top of c, key by key.
+func (c *ComplexityConfig) merge(other ComplexityConfig) {
+	for name, value := range other.Weights {
+		if c.Weights == nil {
+			c.Weights = make(map[string]float64)
+		}
+		c.Weights[name] = value
+	}
+	for priority, value := range other.PriorityWeights {
+		if c.PriorityWeights == nil {
+			c.PriorityWeights = make(map[string]float64)
+		}
+		c.PriorityWeights[priority] = value
+	}
+	if len(other.Keywords) > 0 {
+		c.Keywords = other.Keywords
+	}
+	for tag, override := range other.Tags {
+		if c.Tags == nil {
+			c.Tags = make(map[string]ComplexityScoring)
+		}
+		c.Tags[tag] = override
+	}
+}
+
+func copyWeights(weights map[string]float64) map[string]float64 {
+	if weights == nil {
+		return nil
+	}
+	copied := make(map[string]float64, len(weights))
+	for key, value := range weights {
+		copied[key] = value
+	}
+	return copied
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveComplexityWeights writes weights into projectRoot's
+// .taskmaster/config.json under "complexity.weights", or under the tag's
+// override when tag is non-empty. Other settings in the file are preserved.
+func SaveComplexityWeights(projectRoot, tag string, weights map[string]float64) error {
+	if err := (ComplexityScoring{Weights: weights}).validate(); err != nil {
+		return err
+	}
+
+	configPath := filepath.Join(projectRoot, ".taskmaster", "config.json")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	// Read existing config or start with empty object
+	settings := make(map[string]interface{})
+	if data, err := os.ReadFile(configPath); err == nil {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	complexity := childObject(settings, "complexity")
+	target := complexity
+	if tag != "" {
+		target = childObject(childObject(complexity, "tags"), tag)
+	}
+	saved := childObject(target, "weights")
+	for name, value := range weights {
+		saved[name] = value
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// childObject returns parent[key] as a JSON object, creating or replacing it
+// when missing or not an object.
+func childObject(parent map[string]interface{}, key string) map[string]interface{} {
+	if child, ok := parent[key].(map[string]interface{}); ok {
+		return child
+	}
+	child := make(map[string]interface{})
+	parent[key] = child
+	return child
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeComplexityConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	base := filepath.Join(tmpDir, "base.json")
+	project := filepath.Join(tmpDir, "project.json")
+	os.WriteFile(base, []byte(`{"complexity": {"weights": {"subtaskCount": 4, "dependencyCount": 1}}}`), 0600)
+	os.WriteFile(project, []byte(`{
+  "complexity": {
+    "weights": {"dependencyCount": 5},
+    "keywords": ["payments"],
+    "tags": {"feature-x": {"weights": {"subtaskCount": 0}, "keywords": ["oauth"]}}
+  }
+}`), 0600)
+
+	cfg := defaultConfig()
+	if err := mergeConfigFile(cfg, base); err != nil {
+		t.Fatalf("failed to merge base config: %v", err)
+	}
+	if err := mergeConfigFile(cfg, project); err != nil {
+		t.Fatalf("failed to merge project config: %v", err)
+	}
+
+	master := cfg.Complexity.ForTag("master")
+	if master.Weights[WeightSubtaskCount] != 4 || master.Weights[WeightDependencyCount] != 5 {
+		t.Errorf("expected weights merged key by key, got %v", master.Weights)
+	}
+	if len(master.Keywords) != 1 || master.Keywords[0] != "payments" {
+		t.Errorf("expected project keywords, got %v", master.Keywords)
+	}
+
+	feature := cfg.Complexity.ForTag("feature-x")
+	if feature.Weights[WeightSubtaskCount] != 0 || feature.Weights[WeightDependencyCount] != 5 {
+		t.Errorf("expected tag override on top of project weights, got %v", feature.Weights)
+	}
+	if len(feature.Keywords) != 1 || feature.Keywords[0] != "oauth" {
+		t.Errorf("expected tag keywords to replace project keywords, got %v", feature.Keywords)
+	}
+	if cfg.Complexity.Weights[WeightSubtaskCount] != 4 {
+		t.Errorf("resolving a tag must not modify project weights")
+	}
+}
+
+func TestComplexityConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{"unknown weight", `{"complexity": {"weights": {"linesOfCode": 1}}}`, "unknown weight"},
+		{"negative weight", `{"complexity": {"weights": {"subtaskCount": -1}}}`, "must not be negative"},
+		{"zero priority weight", `{"complexity": {"priorityWeights": {"high": 0}}}`, "must be positive"},
+		{"unknown priority", `{"complexity": {"priorityWeights": {"urgent": 2}}}`, "unknown priority"},
+		{"empty keyword", `{"complexity": {"keywords": ["api", " "]}}`, "keyword must not be empty"},
+		{"invalid tag override", `{"complexity": {"tags": {"v2": {"weights": {"estimatedHours": -2}}}}}`, `tag "v2"`},
+	}
+
+	tmpDir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, "config.json")
+			os.WriteFile(path, []byte(tt.json), 0600)
+
+			cfg := defaultConfig()
+			err := mergeConfigFile(cfg, path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(cfg.Complexity.Weights) != 0 || len(cfg.Complexity.Keywords) != 0 {
+				t.Errorf("invalid config must not be merged, got %+v", cfg.Complexity)
+			}
+		})
+	}
+}
+
+func TestSaveComplexityWeights(t *testing.T) {
+	root := t.TempDir()
+	configPath := filepath.Join(root, ".taskmaster", "config.json")
+	os.MkdirAll(filepath.Dir(configPath), 0755)
+	os.WriteFile(configPath, []byte(`{"modelProvider": "anthropic", "complexity": {"keywords": ["api"]}}`), 0600)
+
+	if err := SaveComplexityWeights(root, "", map[string]float64{WeightSubtaskCount: 2.5}); err != nil {
+		t.Fatalf("SaveComplexityWeights returned error: %v", err)
+	}
+	if err := SaveComplexityWeights(root, "legacy", map[string]float64{WeightDependencyCount: 4}); err != nil {
+		t.Fatalf("SaveComplexityWeights for tag returned error: %v", err)
+	}
+	if err := SaveComplexityWeights(root, "", map[string]float64{WeightSubtaskCount: -1}); err == nil {
+		t.Fatalf("expected negative weight to be rejected")
+	}
+
+	cfg := defaultConfig()
+	if err := mergeConfigFile(cfg, configPath); err != nil {
+		t.Fatalf("saved config does not load: %v", err)
+	}
+	if cfg.ModelProvider != "anthropic" || len(cfg.Complexity.Keywords) != 1 {
+		t.Errorf("expected unrelated settings to be preserved, got %+v", cfg)
+	}
+	legacy := cfg.Complexity.ForTag("legacy")
+	if legacy.Weights[WeightSubtaskCount] != 2.5 || legacy.Weights[WeightDependencyCount] != 4 {
+		t.Errorf("expected project and tag weights, got %v", legacy.Weights)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
@@ -34,6 +35,9 @@ type Config struct {
 	ModelProvider       string            `json:"modelProvider,omitempty"`
 	ModelName           string            `json:"modelName,omitempty"`
 	ActiveTag           string            `json:"activeTag,omitempty"` // Specific tag to use in tasks.json
+	Complexity          ComplexityConfig  `json:"complexity,omitempty"`
+	TimeTracking        TimeTracking      `json:"timeTracking"`
+	Git                 GitConfig         `json:"git"`
 }
 
 // ThemeConfig defines color and styling options
@@ -54,16 +58,94 @@ type UIConfig struct {
 	RefreshInterval int    `json:"refreshInterval"`
 }
 
+// TimeTracking configures the task timer
+type TimeTracking struct {
+	// IdleMinutes stops a running timer after this many minutes without
+	// input; a negative value disables idle detection
+	IdleMinutes int `json:"idleMinutes"`
+}
+
+// IdleTimeout returns the idle timeout, or zero when idle detection is disabled
+func (t TimeTracking) IdleTimeout() time.Duration {
+	if t.IdleMinutes < 0 {
+		return 0
+	}
+	return time.Duration(t.IdleMinutes) * time.Minute
+}
+
+// GitConfig configures the git integration
+type GitConfig struct {
+	// BranchPrefix starts the names of task branches, which continue with
+	// the task ID and a slug of its title
+	BranchPrefix string `json:"branchPrefix"`
+	// MainBranch is the branch task branches merge into; detected from
+	// origin/HEAD, main or master when empty
+	MainBranch string `json:"mainBranch,omitempty"`
+	// AutoStatus moves a pending task to in-progress on its first commit
+	// and to done when its work is merged into the main branch
+	AutoStatus bool `json:"autoStatus"`
+}
+
 // UIState represents the persisted TUI state between sessions
 type UIState struct {
-	ExpandedIDs      []string       `json:"expandedIds"`
-	SelectedID       string         `json:"selectedId"`
-	ViewMode         string         `json:"viewMode"`
-	FocusedPanel     string         `json:"focusedPanel"`
-	ShowDetailsPanel bool           `json:"showDetailsPanel"`
-	ShowLogPanel     bool           `json:"showLogPanel"`
-	PanelHeights     map[string]int `json:"panelHeights,omitempty"`
-	LastPrdPath      string         `json:"lastPrdPath,omitempty"`
+	ExpandedIDs      []string        `json:"expandedIds"`
+	SelectedID       string          `json:"selectedId"`
+	ViewMode         string          `json:"viewMode"`
+	FocusedPanel     string          `json:"focusedPanel"`
+	ShowDetailsPanel bool            `json:"showDetailsPanel"`
+	ShowLogPanel     bool            `json:"showLogPanel"`
+	PanelHeights     map[string]int  `json:"panelHeights,omitempty"`
+	LastPrdPath      string          `json:"lastPrdPath,omitempty"`
+	KanbanCollapsed  map[string]bool `json:"kanbanCollapsed,omitempty"`
+	SortMode         string          `json:"sortMode,omitempty"`
+	SavedViews       SavedViews      `json:"savedViews,omitempty"`
+	DefaultView      string          `json:"defaultView,omitempty"`
+	RawDetails       bool            `json:"rawDetails,omitempty"`
+}
+
+// SavedView is a named preset of task list filters and layout
+type SavedView struct {
+	Name         string   `json:"name"`
+	Query        string   `json:"query,omitempty"`
+	StatusFilter string   `json:"statusFilter,omitempty"`
+	ViewMode     string   `json:"viewMode"`
+	SortMode     string   `json:"sortMode,omitempty"`
+	ExpandedIDs  []string `json:"expandedIds,omitempty"`
+}
+
+// SavedViews is the list of saved views of a project, in the order saved
+type SavedViews []SavedView
+
+// Find returns the saved view with the given name, ignoring case
+func (v SavedViews) Find(name string) (SavedView, bool) {
+	for _, view := range v {
+		if strings.EqualFold(view.Name, name) {
+			return view, true
+		}
+	}
+	return SavedView{}, false
+}
+
+// Put adds a saved view, replacing any view with the same name
+func (v *SavedViews) Put(view SavedView) {
+	for i := range *v {
+		if strings.EqualFold((*v)[i].Name, view.Name) {
+			(*v)[i] = view
+			return
+		}
+	}
+	*v = append(*v, view)
+}
+
+// Delete removes the saved view with the given name, reporting whether it existed
+func (v *SavedViews) Delete(name string) bool {
+	for i := range *v {
+		if strings.EqualFold((*v)[i].Name, name) {
+			*v = append((*v)[:i], (*v)[i+1:]...)
+			return true
+		}
+	}
+	return false
 }
 
 // Load loads configuration from the specified path
@@ -173,6 +255,26 @@ func mergeConfigFile(target *Config, path string) error {
 		target.ProjectRegistryPath = partial.ProjectRegistryPath
 	}
 
+	if partial.TimeTracking.IdleMinutes != 0 {
+		target.TimeTracking.IdleMinutes = partial.TimeTracking.IdleMinutes
+	}
+
+	if partial.Git.BranchPrefix != "" {
+		target.Git.BranchPrefix = partial.Git.BranchPrefix
+	}
+	if partial.Git.MainBranch != "" {
+		target.Git.MainBranch = partial.Git.MainBranch
+	}
+	if partial.Git.AutoStatus {
+		target.Git.AutoStatus = true
+	}
+
+	// Merge complexity scoring, rejecting invalid weights before they are used
+	if err := partial.Complexity.Validate(); err != nil {
+		return fmt.Errorf("invalid complexity scoring in %s: %w", path, err)
+	}
+	target.Complexity.merge(partial.Complexity)
+
 	return nil
 }
 
@@ -227,6 +329,12 @@ func defaultConfig() *Config {
 			AutoRefresh:     true,
 			RefreshInterval: 5,
 		},
+		TimeTracking: TimeTracking{
+			IdleMinutes: 10,
+		},
+		Git: GitConfig{
+			BranchPrefix: "task/",
+		},
 	}
 }
 
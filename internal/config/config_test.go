@@ -170,6 +170,32 @@ func TestSaveState(t *testing.T) {
 	})
 }
 
+func TestSavedViewsRoundTrip(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	state := &UIState{ViewMode: "tree", DefaultView: "Backend"}
+	state.SavedViews.Put(SavedView{Name: "Backend", Query: "tag:api", ViewMode: "list"})
+	state.SavedViews.Put(SavedView{Name: "Blocked", StatusFilter: "blocked", ViewMode: "kanban"})
+	state.SavedViews.Put(SavedView{Name: "backend", Query: "tag:api -status:done", ViewMode: "tree", ExpandedIDs: []string{"3"}})
+
+	if err := SaveState(statePath, state); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+	loaded, err := LoadState(statePath)
+	if err != nil {
+		t.Fatalf("Failed to load state: %v", err)
+	}
+	if len(loaded.SavedViews) != 2 || loaded.DefaultView != "Backend" {
+		t.Fatalf("expected 2 views with default Backend, got %+v", loaded)
+	}
+	view, ok := loaded.SavedViews.Find("BACKEND")
+	if !ok || view.Query != "tag:api -status:done" || view.ExpandedIDs[0] != "3" {
+		t.Fatalf("expected replaced Backend view, got %+v", view)
+	}
+	if !loaded.SavedViews.Delete("blocked") || loaded.SavedViews.Delete("blocked") {
+		t.Fatal("expected Blocked to be deleted exactly once")
+	}
+}
+
 // TestMergeConfigFile tests configuration merging
 func TestMergeConfigFile(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "config-test-*")
@@ -251,6 +277,34 @@ func TestMergeConfigFile(t *testing.T) {
 			t.Errorf("Expected success color to be preserved, got %s", baseConfig.Theme.SuccessColor)
 		}
 	})
+
+	t.Run("merge git settings", func(t *testing.T) {
+		baseConfig := defaultConfig()
+
+		overrideConfig := map[string]interface{}{
+			"git": map[string]interface{}{
+				"mainBranch": "develop",
+				"autoStatus": true,
+			},
+		}
+
+		configPath := filepath.Join(tmpDir, "git.json")
+		data, _ := json.MarshalIndent(overrideConfig, "", "  ")
+		if err := os.WriteFile(configPath, data, 0600); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+
+		if err := mergeConfigFile(baseConfig, configPath); err != nil {
+			t.Fatalf("Failed to merge config: %v", err)
+		}
+
+		if baseConfig.Git.MainBranch != "develop" || !baseConfig.Git.AutoStatus {
+			t.Errorf("Expected git settings to be overridden, got %+v", baseConfig.Git)
+		}
+		if baseConfig.Git.BranchPrefix != "task/" {
+			t.Errorf("Expected default branch prefix to be preserved, got %s", baseConfig.Git.BranchPrefix)
+		}
+	})
 }
 
 // TestGetAPIKeys tests API key retrieval from environment
@@ -0,0 +1,228 @@
+package git
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultScanLimit is the number of most recent commits Scan reads.
+const DefaultScanLimit = 2000
+
+// taskRefPattern matches task references in commit messages: "task 3",
+// "Task #3.1", "subtask 3.1", "tasks: 4" and branch names such as
+// "task/3-search" in merge messages.
+var taskRefPattern = regexp.MustCompile(`(?i)\b(?:sub)?tasks?[ \t#:/_-]*(\d+(?:\.\d+)*)`)
+
+// Commit is a commit that references one or more tasks.
+type Commit struct {
+	Hash    string
+	Author  string
+	Time    time.Time
+	Subject string
+	// Merge is set for commits with more than one parent.
+	Merge   bool
+	TaskIDs []string
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// Activity is the git history of a project's tasks.
+type Activity struct {
+	// MainBranch is the branch task branches merge into; "" when it could
+	// not be determined, in which case nothing is reported as merged.
+	MainBranch string
+	// CurrentBranch is the checked out branch; "" when HEAD is detached.
+	CurrentBranch string
+	// Branches maps task IDs to their local branch.
+	Branches map[string]string
+	// BranchTags maps task IDs to the task tag their branch was created in,
+	// for branches created by SwitchTaskBranch.
+	BranchTags map[string]string
+	// Commits maps task IDs to the commits referencing them, newest first.
+	Commits map[string][]Commit
+	// Merged maps the IDs of tasks whose work reached the main branch to
+	// the commit that brought it there.
+	Merged map[string]Commit
+}
+
+// TaskCommits returns the commits referencing a task, newest first.
+func (a *Activity) TaskCommits(taskID string) []Commit {
+	if a == nil {
+		return nil
+	}
+	return a.Commits[taskID]
+}
+
+// Branch returns the local branch of a task, or "".
+func (a *Activity) Branch(taskID string) string {
+	if a == nil {
+		return ""
+	}
+	return a.Branches[taskID]
+}
+
+// BranchTag returns the task tag the branch of a task was created in, or ""
+// when the branch has none recorded.
+func (a *Activity) BranchTag(taskID string) string {
+	if a == nil {
+		return ""
+	}
+	return a.BranchTags[taskID]
+}
+
+// MergedCommit returns the commit that merged a task's work into the main
+// branch.
+func (a *Activity) MergedCommit(taskID string) (Commit, bool) {
+	if a == nil {
+		return Commit{}, false
+	}
+	commit, ok := a.Merged[taskID]
+	return commit, ok
+}
+
+// TaskRefs returns the task IDs a commit message references, in order of
+// first appearance. Branch names with the repo's prefix count as references.
+func (r *Repo) TaskRefs(message string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, match := range taskRefPattern.FindAllStringSubmatch(message, -1) {
+		add(match[1])
+	}
+	for _, match := range r.branchRef.FindAllStringSubmatch(message, -1) {
+		add(match[1])
+	}
+	return ids
+}
+
+// Scan reads the most recent commits of every local and remote branch and
+// links them to the tasks they reference. A task counts as merged when a
+// merge commit on the main branch references it, or when its branch is
+// merged into the main branch, has moved on from the commit it was created
+// at and its last commit references it. A limit of zero or less reads
+// DefaultScanLimit commits.
+func (r *Repo) Scan(ctx context.Context, limit int) (*Activity, error) {
+	if limit <= 0 {
+		limit = DefaultScanLimit
+	}
+	activity := &Activity{
+		Branches:   make(map[string]string),
+		BranchTags: make(map[string]string),
+		Commits:    make(map[string][]Commit),
+		Merged:     make(map[string]Commit),
+	}
+
+	var err error
+	if activity.CurrentBranch, err = r.CurrentBranch(ctx); err != nil {
+		return nil, err
+	}
+	if activity.MainBranch, err = r.MainBranch(ctx); err != nil {
+		return nil, err
+	}
+	branches, err := r.taskBranches(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range branches {
+		if _, ok := activity.Branches[branch.taskID]; !ok || branch.name == activity.CurrentBranch {
+			activity.Branches[branch.taskID] = branch.name
+			activity.BranchTags[branch.taskID] = branch.tag
+		}
+	}
+
+	out, err := r.run(ctx, "log", "--all", "-n", strconv.Itoa(limit), "--format=%H%x1f%P%x1f%an%x1f%at%x1f%B%x1e")
+	if err != nil {
+		return nil, err
+	}
+	byHash := make(map[string]Commit)
+	for _, record := range strings.Split(out, "\x1e") {
+		commit, ok := r.parseCommit(record)
+		if !ok {
+			continue
+		}
+		byHash[commit.Hash] = commit
+		for _, id := range commit.TaskIDs {
+			activity.Commits[id] = append(activity.Commits[id], commit)
+		}
+	}
+
+	if activity.MainBranch == "" {
+		return activity, nil
+	}
+	out, err = r.run(ctx, "rev-list", "-n", strconv.Itoa(limit), activity.MainBranch)
+	if err != nil {
+		return nil, err
+	}
+	for _, hash := range strings.Fields(out) {
+		if commit, ok := byHash[hash]; ok && commit.Merge {
+			for _, id := range commit.TaskIDs {
+				if _, done := activity.Merged[id]; !done {
+					activity.Merged[id] = commit
+				}
+			}
+		}
+	}
+	merged, err := r.taskBranches(ctx, activity.MainBranch)
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range merged {
+		tip, ok := byHash[branch.tip]
+		if _, done := activity.Merged[branch.taskID]; done || !ok || branch.name == activity.MainBranch {
+			continue
+		}
+		// A branch still at the commit it was created from has no work of
+		// its own, even when that commit mentions the task
+		base, err := r.branchBase(ctx, branch)
+		if err != nil {
+			return nil, err
+		}
+		if base == "" || base == branch.tip {
+			continue
+		}
+		for _, id := range tip.TaskIDs {
+			if id == branch.taskID {
+				activity.Merged[id] = tip
+			}
+		}
+	}
+	return activity, nil
+}
+
+// parseCommit reads one record of the log format used by Scan, keeping only
+// commits that reference a task.
+func (r *Repo) parseCommit(record string) (Commit, bool) {
+	fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 5)
+	if len(fields) != 5 {
+		return Commit{}, false
+	}
+	message := strings.TrimSpace(fields[4])
+	ids := r.TaskRefs(message)
+	if len(ids) == 0 {
+		return Commit{}, false
+	}
+	subject, _, _ := strings.Cut(message, "\n")
+	seconds, _ := strconv.ParseInt(fields[3], 10, 64)
+	return Commit{
+		Hash:    fields[0],
+		Author:  fields[2],
+		Time:    time.Unix(seconds, 0),
+		Subject: strings.TrimSpace(subject),
+		Merge:   len(strings.Fields(fields[1])) > 1,
+		TaskIDs: ids,
+	}, true
+}
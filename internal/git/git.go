@@ -0,0 +1,333 @@
+// Package git links tasks to a project's git history. It shells out to the
+// local git binary to create and switch task branches and to find the
+// commits and merges that reference each task.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// DefaultBranchPrefix starts the names of task branches when none is
+// configured.
+const DefaultBranchPrefix = "task/"
+
+// maxSlugLength caps the title part of a branch name.
+const maxSlugLength = 40
+
+// Keys under "branch.<name>." in the repository config recording where
+// SwitchTaskBranch created a task branch.
+const (
+	// configTagKey holds the task tag the branch was created in.
+	configTagKey = "tmtag"
+	// configBaseKey holds the commit the branch was created at.
+	configBaseKey = "tmbase"
+)
+
+var (
+	// ErrNotRepository is returned when the project is not in a git work tree.
+	ErrNotRepository = errors.New("not a git repository")
+	// ErrGitNotFound is returned when no git binary is on the PATH.
+	ErrGitNotFound = errors.New("git executable not found")
+)
+
+var (
+	slugSeparator = regexp.MustCompile(`[^a-z0-9]+`)
+	taskIDPattern = regexp.MustCompile(`^\d+(?:\.\d+)*`)
+)
+
+// Repo runs git commands in a project directory. It holds no state besides
+// its settings, so it is safe for concurrent use.
+type Repo struct {
+	// Dir is the directory git commands run in.
+	Dir string
+
+	prefix     string
+	mainBranch string
+	// branchRef matches task branch names in commit messages, such as
+	// "Merge branch 'task/3-search'"
+	branchRef *regexp.Regexp
+}
+
+// NewRepo creates a repo for dir. Task branches are named with prefix, or
+// DefaultBranchPrefix when empty; mainBranch is the branch task branches
+// merge into, detected from origin/HEAD, main or master when empty.
+func NewRepo(dir, prefix, mainBranch string) *Repo {
+	if prefix == "" {
+		prefix = DefaultBranchPrefix
+	}
+	return &Repo{
+		Dir:        dir,
+		prefix:     prefix,
+		mainBranch: mainBranch,
+		branchRef:  regexp.MustCompile(`(?:^|[\s'"/:(])` + regexp.QuoteMeta(prefix) + `(\d+(?:\.\d+)*)(?:$|[-\s'")])`),
+	}
+}
+
+// BranchName returns the branch name for a task: the prefix, the task ID
+// and a slug of the title, such as "task/3-add-search".
+func (r *Repo) BranchName(taskID, title string) string {
+	slug := strings.Trim(slugSeparator.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if cut := strings.LastIndex(slug, "-"); cut > 0 {
+			slug = slug[:cut]
+		}
+		slug = strings.Trim(slug, "-")
+	}
+	if slug == "" {
+		return r.prefix + taskID
+	}
+	return r.prefix + taskID + "-" + slug
+}
+
+// TaskIDFromBranch returns the ID of the task a branch belongs to, or ""
+// when branch is not a task branch.
+func (r *Repo) TaskIDFromBranch(branch string) string {
+	rest, ok := strings.CutPrefix(branch, r.prefix)
+	if !ok {
+		return ""
+	}
+	id := taskIDPattern.FindString(rest)
+	if id == "" || (len(rest) > len(id) && rest[len(id)] != '-') {
+		return ""
+	}
+	return id
+}
+
+// CurrentBranch returns the checked out branch, or "" when HEAD is detached.
+func (r *Repo) CurrentBranch(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "symbolic-ref", "--short", "-q", "HEAD")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil
+	}
+	return strings.TrimSpace(out), err
+}
+
+// SwitchTaskBranch checks out the branch of a task, creating it from HEAD
+// when the task has none yet. An existing branch is found by task ID, so a
+// task keeps its branch when its title changes. A new branch records tag,
+// the task tag it belongs to, and the commit it starts at in the repository
+// config. It reports the branch and whether it was created.
+func (r *Repo) SwitchTaskBranch(ctx context.Context, taskID, title, tag string) (string, bool, error) {
+	branches, err := r.taskBranches(ctx, "")
+	if err != nil {
+		return "", false, err
+	}
+	name := r.BranchName(taskID, title)
+	existing := ""
+	for _, branch := range branches {
+		if branch.taskID == taskID && (existing == "" || branch.name == name) {
+			existing = branch.name
+		}
+	}
+
+	if existing == "" {
+		// HEAD does not resolve before the first commit
+		base, _ := r.run(ctx, "rev-parse", "--verify", "-q", "HEAD")
+		if _, err := r.run(ctx, "checkout", "-b", name); err != nil {
+			return "", false, err
+		}
+		settings := map[string]string{configTagKey: tag, configBaseKey: strings.TrimSpace(base)}
+		for key, value := range settings {
+			if value == "" {
+				continue
+			}
+			if _, err := r.run(ctx, "config", "branch."+name+"."+key, value); err != nil {
+				return "", false, err
+			}
+		}
+		return name, true, nil
+	}
+	current, err := r.CurrentBranch(ctx)
+	if err != nil || current == existing {
+		return existing, false, err
+	}
+	if _, err := r.run(ctx, "checkout", existing, "--"); err != nil {
+		return "", false, err
+	}
+	return existing, false, nil
+}
+
+// MainBranch returns the configured main branch, or the first of the
+// remote's default branch, main and master that exists locally. It returns
+// "" when none does.
+func (r *Repo) MainBranch(ctx context.Context) (string, error) {
+	if r.mainBranch != "" {
+		return r.mainBranch, nil
+	}
+	candidates := []string{"main", "master"}
+	if out, err := r.run(ctx, "symbolic-ref", "--short", "-q", "refs/remotes/origin/HEAD"); err == nil {
+		if remote, ok := strings.CutPrefix(strings.TrimSpace(out), "origin/"); ok {
+			candidates = append([]string{remote}, candidates...)
+		}
+	} else if errors.Is(err, ErrNotRepository) || errors.Is(err, ErrGitNotFound) {
+		return "", err
+	}
+	for _, candidate := range candidates {
+		if _, err := r.run(ctx, "rev-parse", "--verify", "-q", "refs/heads/"+candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// taskBranch is a local branch named for a task. tag and base are set for
+// branches created by SwitchTaskBranch.
+type taskBranch struct {
+	name   string
+	taskID string
+	tip    string
+	tag    string
+	base   string
+}
+
+// taskBranches lists the local task branches, limited to those merged into
+// mergedInto when it is set.
+func (r *Repo) taskBranches(ctx context.Context, mergedInto string) ([]taskBranch, error) {
+	args := []string{"for-each-ref", "--format=%(refname:short)%09%(objectname)"}
+	if mergedInto != "" {
+		args = append(args, "--merged="+mergedInto)
+	}
+	out, err := r.run(ctx, append(args, "refs/heads")...)
+	if err != nil {
+		return nil, err
+	}
+	var branches []taskBranch
+	for _, line := range strings.Split(out, "\n") {
+		name, tip, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		if id := r.TaskIDFromBranch(name); id != "" {
+			branches = append(branches, taskBranch{name: name, taskID: id, tip: tip})
+		}
+	}
+	if len(branches) == 0 {
+		return nil, nil
+	}
+
+	settings, err := r.branchSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range branches {
+		branches[i].tag = settings[branches[i].name][configTagKey]
+		branches[i].base = settings[branches[i].name][configBaseKey]
+	}
+	return branches, nil
+}
+
+// branchSettings reads the keys SwitchTaskBranch records, by branch name.
+func (r *Repo) branchSettings(ctx context.Context) (map[string]map[string]string, error) {
+	out, err := r.run(ctx, "config", "--get-regexp", `^branch\..*\.(`+configTagKey+`|`+configBaseKey+`)$`)
+	var gitErr *Error
+	if errors.As(err, &gitErr) {
+		var exitErr *exec.ExitError
+		if errors.As(gitErr.Err, &exitErr) && exitErr.ExitCode() == 1 {
+			// No branch has any of the keys
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		rest, ok := strings.CutPrefix(key, "branch.")
+		if !ok {
+			continue
+		}
+		cut := strings.LastIndex(rest, ".")
+		if cut < 0 {
+			continue
+		}
+		name := rest[:cut]
+		if settings[name] == nil {
+			settings[name] = make(map[string]string)
+		}
+		settings[name][rest[cut+1:]] = value
+	}
+	return settings, nil
+}
+
+// branchBase returns the commit a branch was created at: the one recorded
+// by SwitchTaskBranch, else the oldest entry of the branch's reflog. It
+// returns "" when neither is known.
+func (r *Repo) branchBase(ctx context.Context, branch taskBranch) (string, error) {
+	if branch.base != "" {
+		return branch.base, nil
+	}
+	out, err := r.run(ctx, "reflog", "show", "--format=%H", "refs/heads/"+branch.name, "--")
+	if err != nil {
+		var gitErr *Error
+		if errors.As(err, &gitErr) {
+			// No reflog for the branch
+			return "", nil
+		}
+		return "", err
+	}
+	hashes := strings.Fields(out)
+	if len(hashes) == 0 {
+		return "", nil
+	}
+	return hashes[len(hashes)-1], nil
+}
+
+// run runs git in the repo directory and returns its standard output.
+func (r *Repo) run(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", r.Dir}, args...)...)
+	// Untranslated messages, so a missing repository can be recognised
+	cmd.Env = append(os.Environ(), "LC_ALL=C", "GIT_TERMINAL_PROMPT=0")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", ErrGitNotFound
+		}
+		message := strings.TrimSpace(stderr.String())
+		if strings.Contains(message, "not a git repository") {
+			return "", ErrNotRepository
+		}
+		if message == "" {
+			return "", &Error{Args: args, Err: err}
+		}
+		return "", &Error{Args: args, Message: message, Err: err}
+	}
+	return stdout.String(), nil
+}
+
+// Error is a failed git command.
+type Error struct {
+	Args    []string
+	Message string // what git wrote to standard error
+	Err     error
+}
+
+// Error returns the first line git wrote, which names the problem.
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("git %s: %v", e.Args[0], e.Err)
+	}
+	line, _, _ := strings.Cut(e.Message, "\n")
+	for _, prefix := range []string{"fatal: ", "error: "} {
+		line = strings.TrimPrefix(line, prefix)
+	}
+	return fmt.Sprintf("git %s: %s", e.Args[0], strings.TrimSpace(line))
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,180 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBranchName(t *testing.T) {
+	repo := NewRepo(t.TempDir(), "", "")
+	tests := []struct {
+		id, title, want string
+	}{
+		{"3", "Add search", "task/3-add-search"},
+		{"3.1", "  Watch the `tasks` file!  ", "task/3.1-watch-the-tasks-file"},
+		{"4", "Make the importer handle very long section titles gracefully", "task/4-make-the-importer-handle-very-long"},
+		{"5", "???", "task/5"},
+	}
+	for _, tt := range tests {
+		if got := repo.BranchName(tt.id, tt.title); got != tt.want {
+			t.Errorf("BranchName(%q, %q) = %q, want %q", tt.id, tt.title, got, tt.want)
+		}
+		if got := repo.TaskIDFromBranch(tt.want); got != tt.id {
+			t.Errorf("TaskIDFromBranch(%q) = %q, want %q", tt.want, got, tt.id)
+		}
+	}
+	for _, branch := range []string{"main", "task/x-search", "task/3search", "feature/3-search"} {
+		if got := repo.TaskIDFromBranch(branch); got != "" {
+			t.Errorf("TaskIDFromBranch(%q) = %q, want no task", branch, got)
+		}
+	}
+}
+
+func TestTaskRefs(t *testing.T) {
+	repo := NewRepo(t.TempDir(), "feature/", "")
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{"Task 3: add search", []string{"3"}},
+		{"Fix tokenizer (subtask #3.1) and task-4", []string{"3.1", "4"}},
+		{"Merge branch 'feature/7-login' into main", []string{"7"}},
+		{"Merge pull request #12 from dev/feature/8-logout", []string{"8"}},
+		{"Update tasks.json for 2 tasks", nil},
+	}
+	for _, tt := range tests {
+		if got := repo.TaskRefs(tt.message); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TaskRefs(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestSwitchTaskBranch(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	branch, created, err := repo.SwitchTaskBranch(ctx, "3", "Add search", "feature-x")
+	if err != nil || !created || branch != "task/3-add-search" {
+		t.Fatalf("expected task/3-add-search to be created, got %q created=%v err=%v", branch, created, err)
+	}
+	branches, err := repo.taskBranches(ctx, "")
+	if err != nil || len(branches) != 1 || branches[0].tag != "feature-x" || branches[0].base != branches[0].tip {
+		t.Fatalf("expected the tag and start commit recorded, got %+v err=%v", branches, err)
+	}
+	git(t, repo.Dir, "checkout", "-q", "main")
+
+	// A renamed task keeps its branch
+	branch, created, err = repo.SwitchTaskBranch(ctx, "3", "Full-text search", "feature-x")
+	if err != nil || created || branch != "task/3-add-search" {
+		t.Fatalf("expected to switch back to task/3-add-search, got %q created=%v err=%v", branch, created, err)
+	}
+	if current, _ := repo.CurrentBranch(ctx); current != "task/3-add-search" {
+		t.Fatalf("expected task/3-add-search checked out, got %q", current)
+	}
+}
+
+func TestScanLinksCommitsAndMerges(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	// Task 3 is merged with a merge commit and its branch deleted
+	git(t, repo.Dir, "checkout", "-q", "-b", "task/3-search")
+	commit(t, repo.Dir, "Task 3: index documents")
+	git(t, repo.Dir, "checkout", "-q", "main")
+	git(t, repo.Dir, "merge", "-q", "--no-ff", "-m", "Merge branch 'task/3-search'", "task/3-search")
+	git(t, repo.Dir, "branch", "-q", "-d", "task/3-search")
+
+	// Task 4 is fast-forwarded into main
+	git(t, repo.Dir, "checkout", "-q", "-b", "task/4-login")
+	commit(t, repo.Dir, "Start login form (task 4)")
+	commit(t, repo.Dir, "Finish task 4")
+	git(t, repo.Dir, "checkout", "-q", "main")
+	git(t, repo.Dir, "merge", "-q", "--ff-only", "task/4-login")
+
+	// Task 7 has a fresh branch from a main commit that mentions it
+	commit(t, repo.Dir, "Prepare for task 7")
+	if _, _, err := repo.SwitchTaskBranch(ctx, "7", "Export", "master"); err != nil {
+		t.Fatalf("SwitchTaskBranch returned error: %v", err)
+	}
+	git(t, repo.Dir, "checkout", "-q", "main")
+
+	// Task 5 has work on its branch only, task 6 a branch without commits
+	git(t, repo.Dir, "checkout", "-q", "-b", "task/5-logout")
+	commit(t, repo.Dir, "task 5: logout button")
+	git(t, repo.Dir, "branch", "task/6-profile", "main")
+
+	activity, err := repo.Scan(ctx, 0)
+	if err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+	if activity.MainBranch != "main" || activity.CurrentBranch != "task/5-logout" {
+		t.Fatalf("unexpected branches: main %q, current %q", activity.MainBranch, activity.CurrentBranch)
+	}
+	if commits := activity.TaskCommits("4"); len(commits) != 2 || commits[0].Subject != "Finish task 4" {
+		t.Fatalf("expected task 4 commits newest first, got %+v", commits)
+	}
+	if got := activity.Branch("6"); got != "task/6-profile" {
+		t.Fatalf("expected task 6 branch, got %q", got)
+	}
+	if activity.BranchTag("7") != "master" || activity.BranchTag("5") != "" {
+		t.Fatalf("expected only task 7's branch to have a tag, got %v", activity.BranchTags)
+	}
+	for id, merged := range map[string]bool{"3": true, "4": true, "5": false, "6": false, "7": false} {
+		if _, ok := activity.MergedCommit(id); ok != merged {
+			t.Errorf("task %s merged = %v, want %v", id, ok, merged)
+		}
+	}
+	if merge, _ := activity.MergedCommit("3"); !merge.Merge {
+		t.Errorf("expected task 3 merged by its merge commit, got %+v", merge)
+	}
+}
+
+func TestScanOutsideRepository(t *testing.T) {
+	requireGit(t)
+	_, err := NewRepo(t.TempDir(), "", "").Scan(context.Background(), 0)
+	if !errors.Is(err, ErrNotRepository) {
+		t.Fatalf("expected ErrNotRepository, got %v", err)
+	}
+}
+
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+}
+
+// newTestRepo creates a repository with one commit on main.
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+	requireGit(t)
+	dir := t.TempDir()
+	git(t, dir, "init", "-q", "-b", "main")
+	git(t, dir, "config", "user.name", "Test")
+	git(t, dir, "config", "user.email", "test@example.com")
+	git(t, dir, "config", "commit.gpgsign", "false")
+	commit(t, dir, "Initial commit")
+	return NewRepo(dir, "", "")
+}
+
+func commit(t *testing.T, dir, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "log.txt"), []byte(message), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	git(t, dir, "add", "log.txt")
+	git(t, dir, "commit", "-q", "-m", message)
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
@@ -43,7 +43,7 @@ func (b *BadgerMemory) Store(ctx context.Context, key string, value []byte) erro
 	}
 
 	return b.db.Update(func(txn *badger.Txn) error {
-		entry := badger.NewEntry([]byte(key), value).WithTTL(0) // No TTL
+		entry := badger.NewEntry([]byte(key), value) // No TTL; WithTTL(0) would expire immediately
 		return txn.SetEntry(entry)
 	})
 }
@@ -1,7 +1,10 @@
 package memory
 
 import (
+	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/dgraph-io/badger/v4"
@@ -57,4 +60,64 @@ func TestSimpleBadgerDB(t *testing.T) {
 	if string(retrievedValue) != string(value) {
 		t.Fatalf("Retrieved value doesn't match: got %s, want %s", retrievedValue, value)
 	}
-}
\ No newline at end of file
+}
+
+func TestBadgerMemoryPersistsAcrossReopen(t *testing.T) {
+	tempDir := t.TempDir()
+	ctx := context.Background()
+
+	store, err := OpenStore(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	badgerStore, ok := store.(*BadgerMemory)
+	if !ok {
+		t.Fatalf("Expected BadgerDB store, got %T", store)
+	}
+	if err := store.Store(ctx, "timer", []byte("running")); err != nil {
+		t.Fatalf("Failed to store data: %v", err)
+	}
+
+	// Keys must never expire, whenever they are read back
+	err = badgerStore.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte("timer"))
+		if err != nil {
+			return err
+		}
+		if item.ExpiresAt() != 0 {
+			t.Errorf("Expected no expiry, got %d", item.ExpiresAt())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to read entry: %v", err)
+	}
+	store.Close()
+
+	store, err = OpenStore(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer store.Close()
+	value, err := store.Retrieve(ctx, "timer")
+	if err != nil || string(value) != "running" {
+		t.Fatalf("Expected value to survive reopen, got %q (%v)", value, err)
+	}
+}
+
+func TestOpenStoreReportsLock(t *testing.T) {
+	tempDir := t.TempDir()
+
+	store, err := OpenStore(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	defer store.Close()
+
+	if _, err := OpenStore(tempDir); !errors.Is(err, ErrStoreLocked) {
+		t.Fatalf("Expected ErrStoreLocked while the store is open, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".taskmaster", "memory.json")); !os.IsNotExist(err) {
+		t.Fatalf("Expected no JSON fallback store, got %v", err)
+	}
+}
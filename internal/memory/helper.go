@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // Common keys and prefixes for agent memory
@@ -41,31 +43,40 @@ func DefaultHelper() (*Helper, error) {
 		return nil, err
 	}
 	
-	// Try BadgerDB first (primary backend)
-	var store Memory
-	badgerPath := filepath.Join(cwd, DefaultDBPath)
-	badgerStore, err := NewBadgerMemory(badgerPath)
+	store, err := OpenStore(cwd)
 	if err != nil {
-		// Fallback to InMemoryStorage on error
-		memoryFilePath := filepath.Join(cwd, ".taskmaster", "memory.json")
-		
-		// Ensure the directory exists
-		err = os.MkdirAll(filepath.Dir(memoryFilePath), 0755)
-		if err != nil {
-			return nil, err
-		}
-		
-		store, err = NewInMemoryStorage(memoryFilePath)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		store = badgerStore
+		return nil, err
 	}
 	
 	return NewHelper(store), nil
 }
 
+// OpenStore opens the memory store of the project rooted at root. BadgerDB
+// is the primary backend; a JSON file is used when Badger cannot be opened.
+// ErrStoreLocked is returned while another process holds the Badger store,
+// since the JSON file would not see its data.
+func OpenStore(root string) (Memory, error) {
+	// Try BadgerDB first (primary backend)
+	badgerPath := filepath.Join(root, DefaultDBPath)
+	badgerStore, err := NewBadgerMemory(badgerPath)
+	if err == nil {
+		return badgerStore, nil
+	}
+	if strings.Contains(err.Error(), "Cannot acquire directory lock") {
+		return nil, fmt.Errorf("%w: %v", ErrStoreLocked, err)
+	}
+	
+	// Fallback to InMemoryStorage on error
+	memoryFilePath := filepath.Join(root, ".taskmaster", "memory.json")
+	
+	// Ensure the directory exists
+	if err := os.MkdirAll(filepath.Dir(memoryFilePath), 0755); err != nil {
+		return nil, err
+	}
+	
+	return NewInMemoryStorage(memoryFilePath)
+}
+
 // StoreJSON stores a JSON-serializable object in memory
 func (h *Helper) StoreJSON(ctx context.Context, key string, value interface{}) error {
 	data, err := json.Marshal(value)
@@ -9,6 +9,7 @@ import (
 var (
 	ErrKeyNotFound = errors.New("key not found")
 	ErrKeyEmpty    = errors.New("key cannot be empty")
+	ErrStoreLocked = errors.New("memory store is locked by another process")
 )
 
 // Memory defines the interface for agent memory storage
@@ -0,0 +1,69 @@
+package prd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// Metadata keys of the source anchor recorded on tasks generated from a PRD.
+// Together they let a revised PRD be matched back to the tasks it produced.
+const (
+	AnchorSource  = "prdSource"
+	AnchorSection = "prdSection"
+	AnchorHash    = "prdHash"
+)
+
+// SectionSeparator joins the titles of nested sections in a section path.
+const SectionSeparator = " > "
+
+// SectionPaths returns the path of every parsed task: the titles of its
+// enclosing sections and its own, joined by SectionSeparator. Sections that
+// share a title with an earlier sibling get a " (2)", " (3)"... suffix so
+// each path is unique.
+func SectionPaths(nodes []*Node) map[*Node]string {
+	paths := make(map[*Node]string)
+	var walk func(nodes []*Node, prefix string)
+	walk = func(nodes []*Node, prefix string) {
+		seen := make(map[string]int)
+		for _, node := range nodes {
+			path := prefix + node.Title
+			seen[path]++
+			if n := seen[path]; n > 1 {
+				path += " (" + strconv.Itoa(n) + ")"
+			}
+			paths[node] = path
+			walk(node.Children, path+SectionSeparator)
+		}
+	}
+	walk(nodes, "")
+	return paths
+}
+
+// MarkSections records the section path and content hash of every parsed
+// node in its Section and Hash fields, so later edits to the nodes do not
+// change the anchors BuildAnchoredTaskDocuments writes.
+func MarkSections(nodes []*Node) {
+	for node, path := range SectionPaths(nodes) {
+		node.Section = path
+		node.Hash = ContentHash(node)
+	}
+}
+
+// ContentHash returns a short hash of a task's description and acceptance
+// criteria. The title is left out so a renamed section keeps its hash.
+func ContentHash(n *Node) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(n.Description) + "\x00" + strings.TrimSpace(n.TestStrategy)))
+	return hex.EncodeToString(sum[:6])
+}
+
+// Anchor returns the task metadata linking n to the section at path in the
+// PRD file source.
+func Anchor(source, path string, n *Node) map[string]string {
+	return map[string]string{
+		AnchorSource:  source,
+		AnchorSection: path,
+		AnchorHash:    ContentHash(n),
+	}
+}
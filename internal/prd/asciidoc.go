@@ -0,0 +1,87 @@
+package prd
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	adocHeadingPattern    = regexp.MustCompile(`^(={1,6})\s+(.+?)(?:\s+=+)?$`)
+	adocAttributePattern  = regexp.MustCompile(`^:!?([\w][\w-]*)!?:(?:\s+(.*))?$`)
+	adocListPattern       = regexp.MustCompile(`^(\*{1,6}|\.{1,6}|-)\s+(.*)$`)
+	adocBlockTitlePattern = regexp.MustCompile(`^\.([^.\s].*)$`)
+	adocLabelPattern      = regexp.MustCompile(`^(.+?)(?::{2,4}|;;)(?:\s+(.*))?$`)
+	adocMacroPattern      = regexp.MustCompile(`^[a-z]+::\S*\[.*\]$`)
+	adocDelimiterPattern  = regexp.MustCompile(`^(?:-{4,}|\.{4,}|={4,}|\*{4,}|_{4,}|\+{4,}|~{4,}|\|={3,}|--|\+)$`)
+	adocLinkPattern       = regexp.MustCompile(`(?:link:)?(https?://[^\s\[]+)\[([^\]]+)\]`)
+)
+
+// asciidocToMarkdown rewrites an AsciiDoc PRD as the Markdown Parse reads.
+// Section titles become headings by their number of "=" signs; "*" and "."
+// lists become bullets nested by marker length, with "[*]" read as a checked
+// box; block titles become label lines, so ".Acceptance criteria" starts the
+// test strategy; labelled lists become "Term: text" lines; priority and
+// status attributes in the document header supply the defaults. Comments,
+// other attributes, block delimiters and macros are dropped.
+func asciidocToMarkdown(content string) string {
+	var (
+		out       []string
+		front     frontMatter
+		inHeader  = true
+		inComment bool
+	)
+	for _, line := range splitLines(content) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "////" {
+			inComment = !inComment
+			continue
+		}
+		if inComment || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+
+		if match := adocHeadingPattern.FindStringSubmatch(line); match != nil {
+			inHeader = len(match[1]) == 1
+			out = append(out, strings.Repeat("#", len(match[1]))+" "+asciidocInline(match[2]))
+			continue
+		}
+		if match := adocAttributePattern.FindStringSubmatch(line); match != nil {
+			if inHeader {
+				setDefault(&front, match[1], match[2])
+			}
+			continue
+		}
+		if trimmed == "" {
+			inHeader = false
+		}
+		if adocDelimiterPattern.MatchString(trimmed) || adocMacroPattern.MatchString(trimmed) ||
+			strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") && !strings.HasPrefix(trimmed, "[[") {
+			continue
+		}
+
+		if match := adocListPattern.FindStringSubmatch(trimmed); match != nil {
+			depth := len(match[1]) - 1
+			text := match[2]
+			if strings.HasPrefix(text, "[*] ") {
+				text = "[x] " + text[4:]
+			}
+			out = append(out, strings.Repeat("  ", depth)+"- "+asciidocInline(text))
+			continue
+		}
+		if match := adocBlockTitlePattern.FindStringSubmatch(trimmed); match != nil {
+			out = append(out, asciidocInline(match[1])+":")
+			continue
+		}
+		if match := adocLabelPattern.FindStringSubmatch(trimmed); match != nil {
+			out = append(out, strings.TrimSpace(match[1])+": "+asciidocInline(match[2]))
+			continue
+		}
+		out = append(out, asciidocInline(line))
+	}
+	return withFrontMatter(front, strings.Join(out, "\n"))
+}
+
+// asciidocInline rewrites AsciiDoc links as Markdown links.
+func asciidocInline(text string) string {
+	return adocLinkPattern.ReplaceAllString(text, "[$2]($1)")
+}
@@ -1,38 +1,156 @@
 package prd
 
+import (
+	"strconv"
+	"strings"
+)
+
 // BuildTaskDocuments converts parsed nodes into JSON-friendly maps that align with
 // the Task Master tasks.json format. The first root task will receive the
 // provided startID value and subsequent tasks increment sequentially.
+//
+// DependsOn references are matched against the titles of all parsed tasks, or
+// taken as task IDs when numeric. A top-level task depending on a subtask
+// depends on that subtask's top-level task instead. References that match
+// nothing, or the task itself or its own ancestors and descendants, are
+// dropped.
 func BuildTaskDocuments(nodes []*Node, startID int) []map[string]interface{} {
+	return buildTaskDocuments(nodes, startID, nil)
+}
+
+// BuildAnchoredTaskDocuments is BuildTaskDocuments with a source anchor
+// (see Anchor) recorded in the metadata of every task, so the tasks can be
+// re-synced when the PRD file source is revised. Nodes marked by
+// MarkSections keep the section path and hash they were parsed with.
+func BuildAnchoredTaskDocuments(source string, nodes []*Node, startID int) []map[string]interface{} {
+	anchors := make(map[*Node]map[string]string)
+	for node, path := range SectionPaths(nodes) {
+		anchor := Anchor(source, path, node)
+		if node.Section != "" {
+			anchor[AnchorSection] = node.Section
+			anchor[AnchorHash] = node.Hash
+		}
+		anchors[node] = anchor
+	}
+	return buildTaskDocuments(nodes, startID, anchors)
+}
+
+func buildTaskDocuments(nodes []*Node, startID int, anchors map[*Node]map[string]string) []map[string]interface{} {
+	r := newDependencyResolver(nodes, startID)
 	docs := make([]map[string]interface{}, 0, len(nodes))
 	id := startID
 	for _, node := range nodes {
-		docs = append(docs, buildDocument(node, id))
+		docs = append(docs, buildDocument(node, id, r, anchors))
 		id++
 	}
 	return docs
 }
 
-func buildDocument(node *Node, id int) map[string]interface{} {
+func buildDocument(node *Node, id int, r *dependencyResolver, anchors map[*Node]map[string]string) map[string]interface{} {
 	doc := map[string]interface{}{
 		"id":       id,
 		"title":    node.Title,
 		"status":   "pending",
 		"priority": "medium",
 	}
+	if node.Status != "" {
+		doc["status"] = node.Status
+	}
+	if node.Priority != "" {
+		doc["priority"] = node.Priority
+	}
 
 	if node.Description != "" {
 		doc["description"] = node.Description
 	}
+	if node.TestStrategy != "" {
+		doc["testStrategy"] = node.TestStrategy
+	}
+	if deps := r.resolve(node); len(deps) > 0 {
+		doc["dependencies"] = deps
+	}
+	if anchor := anchors[node]; anchor != nil {
+		doc["metadata"] = anchor
+	}
 
 	if len(node.Children) > 0 {
 		subtasks := make([]map[string]interface{}, 0, len(node.Children))
 		for i, child := range node.Children {
 			childID := i + 1
-			subtasks = append(subtasks, buildDocument(child, childID))
+			subtasks = append(subtasks, buildDocument(child, childID, r, anchors))
 		}
 		doc["subtasks"] = subtasks
 	}
 
 	return doc
 }
+
+// dependencyResolver maps the parsed tasks to the IDs they will be written
+// with, so "depends on" references can be turned into dependencies.
+type dependencyResolver struct {
+	ids    map[*Node]string
+	titles map[string]string
+}
+
+func newDependencyResolver(nodes []*Node, startID int) *dependencyResolver {
+	r := &dependencyResolver{ids: make(map[*Node]string), titles: make(map[string]string)}
+	var walk func(nodes []*Node, prefix string, first int)
+	walk = func(nodes []*Node, prefix string, first int) {
+		for i, node := range nodes {
+			id := prefix + strconv.Itoa(first+i)
+			r.ids[node] = id
+			if key := referenceKey(node.Title); key != "" {
+				if _, taken := r.titles[key]; !taken {
+					r.titles[key] = id
+				}
+			}
+			walk(node.Children, id+".", 1)
+		}
+	}
+	walk(nodes, "", startID)
+	return r
+}
+
+// resolve returns the dependencies of node: numbers for top-level tasks and
+// dotted IDs for subtasks, as Task Master writes them.
+func (r *dependencyResolver) resolve(node *Node) []interface{} {
+	own := r.ids[node]
+	topLevel := !strings.Contains(own, ".")
+	seen := make(map[string]bool)
+	var deps []interface{}
+	for _, ref := range node.DependsOn {
+		id, ok := r.titles[referenceKey(ref)]
+		if !ok {
+			id = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(ref)), "task "), "#")
+			if !isTaskID(id) {
+				continue
+			}
+		}
+		if topLevel {
+			id, _, _ = strings.Cut(id, ".")
+		}
+		if id == own || strings.HasPrefix(own, id+".") || strings.HasPrefix(id, own+".") || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if n, err := strconv.Atoi(id); err == nil {
+			deps = append(deps, n)
+		} else {
+			deps = append(deps, id)
+		}
+	}
+	return deps
+}
+
+// isTaskID reports whether s looks like a task ID such as "3" or "3.2".
+func isTaskID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, part := range strings.Split(s, ".") {
+		if _, err := strconv.Atoi(part); err != nil || part == "" {
+			return false
+		}
+	}
+	return true
+}
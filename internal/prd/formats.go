@@ -0,0 +1,149 @@
+package prd
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies the markup a PRD is written in.
+type Format string
+
+const (
+	FormatMarkdown         Format = "markdown"
+	FormatReStructuredText Format = "rst"
+	FormatAsciiDoc         Format = "asciidoc"
+	FormatOrg              Format = "org"
+	FormatHTML             Format = "html"
+)
+
+// Extensions lists the file extensions of the PRD formats the parser reads.
+var Extensions = []string{".md", ".markdown", ".txt", ".rst", ".adoc", ".asciidoc", ".org", ".html", ".htm"}
+
+var (
+	atxHeadingPattern    = regexp.MustCompile(`^#{1,6}\s+\S`)
+	orgSniffPattern      = regexp.MustCompile(`^(?:#\+\w+:|\*+\s+(?:TODO|DONE)\s)`)
+	asciidocSniffPattern = regexp.MustCompile(`^={1,6}\s+\S`)
+	rstDirectivePattern  = regexp.MustCompile(`^\.\.\s+[\w-]+::`)
+	htmlSniffPattern     = regexp.MustCompile(`(?i)^<(?:!doctype\s+html|html|head|body|h[1-6]|ul|ol|p|div|section|article)[\s>]`)
+	lineEndingPattern    = regexp.MustCompile(`\r\n?`)
+)
+
+// ParseFile parses a PRD in the format its file name or content indicates
+// (see DetectFormat).
+func ParseFile(name, content string) ([]*Node, error) {
+	return ParseFormat(content, DetectFormat(name, content))
+}
+
+// ParseFormat parses a PRD written in format. Other formats are rewritten as
+// the Markdown Parse reads, so headings, lists, checkboxes, priority and
+// dependency markers and acceptance criteria sections produce the same task
+// tree whatever the markup.
+func ParseFormat(content string, format Format) ([]*Node, error) {
+	switch format {
+	case FormatReStructuredText:
+		content = rstToMarkdown(content)
+	case FormatAsciiDoc:
+		content = asciidocToMarkdown(content)
+	case FormatOrg:
+		content = orgToMarkdown(content)
+	case FormatHTML:
+		converted, err := htmlToMarkdown(content)
+		if err != nil {
+			return nil, err
+		}
+		content = converted
+	}
+	return Parse(content)
+}
+
+// DetectFormat picks the format of a PRD from the extension of name, or for
+// .txt and unknown extensions from markers in the content: an HTML tag,
+// Org "#+" keywords or TODO headlines, AsciiDoc "=" section titles, Markdown
+// "#" headings or reStructuredText underlined titles and directives.
+// Anything else is read as Markdown.
+func DetectFormat(name, content string) Format {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown", ".mdown", ".mkd":
+		return FormatMarkdown
+	case ".rst", ".rest":
+		return FormatReStructuredText
+	case ".adoc", ".asciidoc", ".asc":
+		return FormatAsciiDoc
+	case ".org":
+		return FormatOrg
+	case ".html", ".htm", ".xhtml":
+		return FormatHTML
+	}
+	return sniffFormat(content)
+}
+
+func sniffFormat(content string) Format {
+	lines := splitLines(content)
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			if htmlSniffPattern.MatchString(trimmed) {
+				return FormatHTML
+			}
+			break
+		}
+	}
+
+	var asciidoc, markdown, rst bool
+	for i, line := range lines {
+		switch {
+		case orgSniffPattern.MatchString(line):
+			return FormatOrg
+		case asciidocSniffPattern.MatchString(line):
+			asciidoc = true
+		case atxHeadingPattern.MatchString(line):
+			markdown = true
+		case rstDirectivePattern.MatchString(line):
+			rst = true
+		case i+1 < len(lines) && isRSTUnderline(line, lines[i+1]):
+			rst = true
+		}
+	}
+	switch {
+	case asciidoc:
+		return FormatAsciiDoc
+	case markdown:
+		return FormatMarkdown
+	case rst:
+		return FormatReStructuredText
+	}
+	return FormatMarkdown
+}
+
+// splitLines splits content into lines, dropping a byte order mark and
+// carriage returns.
+func splitLines(content string) []string {
+	content = strings.TrimPrefix(content, "\ufeff")
+	return strings.Split(lineEndingPattern.ReplaceAllString(content, "\n"), "\n")
+}
+
+// setDefault records a document-level priority or status declared by a
+// format's own metadata syntax.
+func setDefault(front *frontMatter, name, value string) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "priority":
+		front.Priority = strings.TrimSpace(value)
+	case "status":
+		front.Status = strings.TrimSpace(value)
+	}
+}
+
+// withFrontMatter prepends the document defaults as YAML front matter, so
+// Parse validates and applies them as it does for Markdown.
+func withFrontMatter(front frontMatter, body string) string {
+	if front == (frontMatter{}) {
+		return body
+	}
+	header, err := yaml.Marshal(front)
+	if err != nil {
+		return body
+	}
+	return "---\n" + string(header) + "---\n" + body
+}
@@ -0,0 +1,90 @@
+package prd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFileFormatsMatchMarkdown(t *testing.T) {
+	want := parseFormatFixture(t, "spec.md")
+	if len(want) != 1 || len(want[0].Children) != 2 || want[0].Children[0].TestStrategy == "" {
+		t.Fatalf("unexpected Markdown tree %s", dumpNodes(want))
+	}
+
+	for _, name := range []string{"spec.rst", "spec.adoc", "spec.org", "spec.html"} {
+		got := parseFormatFixture(t, name)
+		if dumpNodes(got) != dumpNodes(want) {
+			t.Errorf("%s parsed differently from spec.md:\n got %s\nwant %s", name, dumpNodes(got), dumpNodes(want))
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Format
+	}{
+		{"spec.RST", "", FormatReStructuredText},
+		{"spec.asciidoc", "", FormatAsciiDoc},
+		{"notes.org", "", FormatOrg},
+		{"spec.htm", "", FormatHTML},
+		{"spec.md", "Title\n=====\n", FormatMarkdown},
+		{"spec.txt", "# Title\n\n- item\n", FormatMarkdown},
+		{"spec.txt", "Title\n=====\n\nText\n", FormatReStructuredText},
+		{"spec.txt", ".. note:: Draft\n", FormatReStructuredText},
+		{"spec.txt", "= Title\n\n== Section\n", FormatAsciiDoc},
+		{"spec.txt", "#+TITLE: Spec\n* Section\n", FormatOrg},
+		{"spec.txt", "* TODO Section\n", FormatOrg},
+		{"spec", "  <!doctype html><h1>Spec</h1>", FormatHTML},
+		{"spec.txt", "Plain notes\n", FormatMarkdown},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.name, tt.content); got != tt.want {
+			t.Errorf("DetectFormat(%q, %q) = %s, want %s", tt.name, tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestParseOrgHeadlineKeywords(t *testing.T) {
+	nodes, err := ParseFormat("* DONE [#C] Setup :infra:\n* TODO Launch\n#+BEGIN_COMMENT\n* Hidden\n#+END_COMMENT\n", FormatOrg)
+	if err != nil {
+		t.Fatalf("ParseFormat returned error: %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("expected commented headlines dropped, got %s", dumpNodes(nodes))
+	}
+	if nodes[0].Title != "Setup" || nodes[0].Status != "done" || nodes[0].Priority != "low" {
+		t.Fatalf("expected DONE [#C] read as done and low, got %+v", nodes[0])
+	}
+	if nodes[1].Title != "Launch" || nodes[1].Status != "pending" {
+		t.Fatalf("expected TODO read as pending, got %+v", nodes[1])
+	}
+}
+
+func TestParseHTMLInvalidDefaults(t *testing.T) {
+	_, err := ParseFormat(`<meta name="priority" content="urgent"><h1>Spec</h1>`, FormatHTML)
+	if err == nil {
+		t.Fatalf("expected an invalid meta priority to be rejected")
+	}
+}
+
+func parseFormatFixture(t *testing.T, name string) []*Node {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "formats", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	nodes, err := ParseFile(name, string(data))
+	if err != nil {
+		t.Fatalf("ParseFile(%s) returned error: %v", name, err)
+	}
+	return nodes
+}
+
+func dumpNodes(nodes []*Node) string {
+	data, _ := json.MarshalIndent(nodes, "", "  ")
+	return string(data)
+}
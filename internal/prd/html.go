@@ -0,0 +1,180 @@
+package prd
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlToMarkdown rewrites an HTML PRD as the Markdown Parse reads. h1-h6
+// become headings, list items become bullets with checkbox inputs as task
+// list boxes, other blocks become paragraphs, and <meta name="priority"> and
+// <meta name="status"> supply the document defaults.
+func htmlToMarkdown(content string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("invalid HTML PRD: %w", err)
+	}
+	c := &htmlConverter{}
+	c.walk(doc)
+	c.flush()
+	return withFrontMatter(c.front, c.out.String()), nil
+}
+
+// htmlConverter writes the Markdown lines of an HTML document as it walks
+// the tree. Inline text collects in line and is written, after prefix, when
+// a block ends.
+type htmlConverter struct {
+	out    strings.Builder
+	line   string
+	prefix string
+	lists  int
+	front  frontMatter
+}
+
+func (c *htmlConverter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		c.line += n.Data
+		return
+	case html.DocumentNode:
+		c.children(n)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Title, atom.Template, atom.Noscript:
+	case atom.Meta:
+		setDefault(&c.front, htmlAttr(n, "name"), htmlAttr(n, "content"))
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		c.paragraph()
+		c.prefix = strings.Repeat("#", int(n.Data[1]-'0')) + " "
+		c.children(n)
+		c.paragraph()
+	case atom.Ul, atom.Ol:
+		c.flush()
+		c.lists++
+		c.children(n)
+		c.lists--
+		c.paragraph()
+	case atom.Li:
+		c.flush()
+		c.prefix = strings.Repeat("  ", max(c.lists-1, 0)) + "- "
+		c.children(n)
+		c.flush()
+	case atom.Input:
+		if strings.EqualFold(htmlAttr(n, "type"), "checkbox") {
+			if htmlHasAttr(n, "checked") {
+				c.line += "[x] "
+			} else {
+				c.line += "[ ] "
+			}
+		}
+	case atom.Br:
+		c.flush()
+	case atom.Pre:
+		c.paragraph()
+		for _, line := range strings.Split(htmlText(n), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				c.out.WriteString(line + "\n")
+			}
+		}
+		c.paragraph()
+	case atom.Code:
+		c.line += "`"
+		c.children(n)
+		c.line += "`"
+	case atom.A:
+		start := len(c.line)
+		c.children(n)
+		href := htmlAttr(n, "href")
+		inner := c.line[start:]
+		if text := strings.TrimSpace(inner); text != "" && (strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://")) {
+			lead := inner[:len(inner)-len(strings.TrimLeft(inner, " \t\n"))]
+			trail := inner[len(strings.TrimRight(inner, " \t\n")):]
+			c.line = c.line[:start] + lead + "[" + text + "](" + href + ")" + trail
+		}
+	case atom.Dt:
+		c.flush()
+		c.children(n)
+		c.line = strings.TrimSpace(c.line) + ":"
+	case atom.Dd:
+		c.line += " "
+		c.children(n)
+		c.flush()
+	case atom.Tr:
+		c.flush()
+		c.children(n)
+		c.flush()
+	case atom.Td, atom.Th:
+		if strings.TrimSpace(c.line) != "" {
+			c.line += " | "
+		}
+		c.children(n)
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Main, atom.Nav,
+		atom.Aside, atom.Blockquote, atom.Table, atom.Dl, atom.Figure, atom.Details, atom.Summary, atom.Hr:
+		c.paragraph()
+		c.children(n)
+		c.paragraph()
+	default:
+		c.children(n)
+	}
+}
+
+func (c *htmlConverter) children(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.walk(child)
+	}
+}
+
+// flush writes the pending line, with its whitespace collapsed.
+func (c *htmlConverter) flush() {
+	if text := strings.Join(strings.Fields(c.line), " "); text != "" {
+		c.out.WriteString(c.prefix + text + "\n")
+	}
+	c.line, c.prefix = "", ""
+}
+
+// paragraph ends a block: outside lists a blank line separates it from the
+// next.
+func (c *htmlConverter) paragraph() {
+	c.flush()
+	if c.lists == 0 && c.out.Len() > 0 && !strings.HasSuffix(c.out.String(), "\n\n") {
+		c.out.WriteString("\n")
+	}
+}
+
+func htmlAttr(n *html.Node, name string) string {
+	for _, attr := range n.Attr {
+		if strings.EqualFold(attr.Key, name) {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func htmlHasAttr(n *html.Node, name string) bool {
+	for _, attr := range n.Attr {
+		if strings.EqualFold(attr.Key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlText returns the text inside n.
+func htmlText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(htmlText(child))
+	}
+	return b.String()
+}
@@ -0,0 +1,213 @@
+package prd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Task statuses and priorities the parser can assign. They mirror the values
+// Task Master accepts in tasks.json.
+var (
+	knownStatuses   = []string{"pending", "in-progress", "done", "deferred", "cancelled", "blocked"}
+	knownPriorities = []string{"critical", "high", "medium", "low"}
+)
+
+// frontMatter holds the defaults a PRD can declare in YAML front matter.
+// Other keys, such as a title or author, are ignored.
+type frontMatter struct {
+	Priority string `yaml:"priority,omitempty"`
+	Status   string `yaml:"status,omitempty"`
+}
+
+// priorityMarker matches a "(P0)" or "[high]" style priority marker.
+const priorityMarker = `(?:\((p[0-3]|critical|high|medium|low)\)|\[(p[0-3]|critical|high|medium|low)\])`
+
+var (
+	checkboxPattern      = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	priorityPrefix       = regexp.MustCompile(`(?i)^\s*` + priorityMarker)
+	prioritySuffix       = regexp.MustCompile(`(?i)\s*` + priorityMarker + `\s*$`)
+	priorityLinePattern  = regexp.MustCompile(`(?i)^\**priority\**\s*:\s*\**\s*(p[0-3]|critical|high|medium|low)\b`)
+	dependsOnPattern     = regexp.MustCompile(`(?i)\bdepends on\b:?\s*([^.;()\n]+)`)
+	dependsTitlePattern  = regexp.MustCompile(`(?i)\s*\(depends on\b[^)]*\)`)
+	dependsSuffixPattern = regexp.MustCompile(`(?i)[\s,;:–—-]*\bdepends on\b.*$`)
+	referenceSeparator   = regexp.MustCompile(`(?i)\s*(?:,|&|\band\b)\s*`)
+	strategyTitles       = []string{"acceptance criteria", "acceptance tests", "test plan", "test strategy", "testing strategy"}
+)
+
+// splitFrontMatter separates YAML front matter delimited by "---" lines from
+// the rest of the document. Content without front matter is returned as is.
+func splitFrontMatter(content string) (frontMatter, string, error) {
+	var front frontMatter
+	content = strings.TrimPrefix(content, "\ufeff")
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return front, content, nil
+	}
+	for i := 1; i < len(lines); i++ {
+		if marker := strings.TrimSpace(lines[i]); marker != "---" && marker != "..." {
+			continue
+		}
+		header := strings.Join(lines[1:i], "")
+		// A block that is not a YAML mapping is ordinary content, such as
+		// text between two rules.
+		var fields map[string]interface{}
+		if err := yaml.Unmarshal([]byte(header), &fields); err != nil {
+			return frontMatter{}, content, nil
+		}
+		if err := yaml.Unmarshal([]byte(header), &front); err != nil {
+			return front, "", fmt.Errorf("invalid PRD front matter: %w", err)
+		}
+		if front.Priority != "" {
+			priority, ok := normalizePriority(front.Priority)
+			if !ok {
+				return front, "", fmt.Errorf("invalid PRD front matter priority %q", front.Priority)
+			}
+			front.Priority = priority
+		}
+		if front.Status != "" {
+			front.Status = strings.ToLower(strings.TrimSpace(front.Status))
+			if !contains(knownStatuses, front.Status) {
+				return front, "", fmt.Errorf("invalid PRD front matter status %q", front.Status)
+			}
+		}
+		return front, strings.Join(lines[i+1:], ""), nil
+	}
+	// An unterminated block is ordinary content, such as a leading rule.
+	return frontMatter{}, content, nil
+}
+
+// applyDefaults fills the priority and status of n and its descendants from
+// the front matter where the document did not set them.
+func applyDefaults(n *Node, front frontMatter) {
+	if n.Priority == "" {
+		n.Priority = front.Priority
+	}
+	if n.Status == "" {
+		n.Status = front.Status
+	}
+	for _, child := range n.Children {
+		applyDefaults(child, front)
+	}
+}
+
+// parseCheckbox strips a GitHub task list box from a bullet's text and
+// reports the status it stands for.
+func parseCheckbox(text string) (string, string) {
+	match := checkboxPattern.FindStringSubmatch(text)
+	if match == nil {
+		return text, ""
+	}
+	status := "pending"
+	if match[1] != " " {
+		status = "done"
+	}
+	return strings.TrimSpace(text[len(match[0]):]), status
+}
+
+// parseTitle removes priority markers such as "(P0)" or "[high]" at the
+// start or end of a heading or bullet, and "(depends on ...)" or trailing
+// ", depends on ..." notes, returning what they set. A marker in the middle
+// of a title, as in "Support [low] latency reads", is part of the title.
+func parseTitle(text string) (title, priority string, dependsOn []string) {
+	text, priority = cutPriority(text)
+	for _, note := range dependsTitlePattern.FindAllString(text, -1) {
+		dependsOn = append(dependsOn, parseDependsOn(note)...)
+	}
+	text = dependsTitlePattern.ReplaceAllString(text, "")
+	if loc := dependsSuffixPattern.FindStringIndex(text); loc != nil && loc[0] > 0 {
+		dependsOn = append(dependsOn, parseDependsOn(text[loc[0]:])...)
+		text = text[:loc[0]]
+	}
+	if priority == "" {
+		// The marker may have been followed by a dependency note
+		text, priority = cutPriority(text)
+	}
+	return strings.Join(strings.Fields(text), " "), priority, dependsOn
+}
+
+// cutPriority removes a priority marker from the start or end of text and
+// returns the priority it sets.
+func cutPriority(text string) (string, string) {
+	for _, pattern := range []*regexp.Regexp{priorityPrefix, prioritySuffix} {
+		loc := pattern.FindStringSubmatchIndex(text)
+		if loc == nil {
+			continue
+		}
+		var marker string
+		if loc[2] >= 0 {
+			marker = text[loc[2]:loc[3]]
+		} else {
+			marker = text[loc[4]:loc[5]]
+		}
+		priority, _ := normalizePriority(marker)
+		return text[:loc[0]] + " " + text[loc[1]:], priority
+	}
+	return text, ""
+}
+
+// parseDependsOn returns the sections named by "depends on" phrases in line.
+func parseDependsOn(line string) []string {
+	var refs []string
+	for _, match := range dependsOnPattern.FindAllStringSubmatch(line, -1) {
+		for _, ref := range referenceSeparator.Split(match[1], -1) {
+			ref = strings.Trim(ref, " \t\"'`*_")
+			ref = strings.TrimPrefix(strings.TrimPrefix(ref, "the "), "The ")
+			ref = strings.TrimSuffix(strings.TrimSuffix(ref, " sections"), " section")
+			if ref = strings.Trim(ref, " \t\"'`*_"); ref != "" {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+// parsePriorityLine recognises a "Priority: high" line in a section's text.
+func parsePriorityLine(line string) (string, bool) {
+	match := priorityLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	return normalizePriority(match[1])
+}
+
+// isStrategyTitle reports whether a heading or label line starts an
+// acceptance criteria or test plan section.
+func isStrategyTitle(text string) bool {
+	text = strings.ToLower(strings.Trim(text, " \t*_:"))
+	text = strings.TrimSuffix(strings.Trim(text, " \t*_"), ":")
+	return contains(strategyTitles, strings.TrimSpace(text))
+}
+
+// normalizePriority maps a priority word or P0-P3 level to a Task Master
+// priority.
+func normalizePriority(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "p0":
+		return "critical", true
+	case "p1":
+		return "high", true
+	case "p2":
+		return "medium", true
+	case "p3":
+		return "low", true
+	}
+	return value, contains(knownPriorities, value)
+}
+
+// referenceKey normalises a section title or reference for matching.
+func referenceKey(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(strings.Trim(text, " \t\"'`*_#")), " "))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package prd
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	orgHeadlinePattern = regexp.MustCompile(`^(\*+)\s+(.*)$`)
+	orgKeywordPattern  = regexp.MustCompile(`^#\+(\w+):\s*(.*)$`)
+	orgPriorityCookie  = regexp.MustCompile(`\s*\[#([A-Ca-c])\]`)
+	orgTagsPattern     = regexp.MustCompile(`\s+:[\w@#%:]+:\s*$`)
+	orgDrawerPattern   = regexp.MustCompile(`^:[\w-]+:$`)
+	orgPlanningPattern = regexp.MustCompile(`^(?:SCHEDULED|DEADLINE|CLOSED):`)
+	orgLinkPattern     = regexp.MustCompile(`\[\[([^\]]+)\](?:\[([^\]]+)\])?\]`)
+	orgVerbatimPattern = regexp.MustCompile(`(^|[\s(])[=~]([^\s=~](?:[^=~]*[^\s=~])?)[=~]($|[\s).,;:!?])`)
+	orgDescListPattern = regexp.MustCompile(`^(\s*[-+]\s+.*?)\s+::\s+(.*)$`)
+)
+
+// orgPriorities maps the default Org priority cookies to task priorities.
+var orgPriorities = map[string]string{"a": "high", "b": "medium", "c": "low"}
+
+// orgToMarkdown rewrites an Org-mode PRD as the Markdown Parse reads.
+// Headlines become headings by their number of stars, with TODO and DONE
+// read as pending and done and [#A]-[#C] priority cookies as high, medium
+// and low; tags, property drawers, planning lines, comments and keywords
+// are dropped, except "#+PROPERTY: priority ..." and "status ..." which
+// supply the document defaults.
+func orgToMarkdown(content string) string {
+	var (
+		out     []string
+		front   frontMatter
+		inBlock string // the end line of a comment block or drawer being skipped
+	)
+	for _, line := range splitLines(content) {
+		trimmed := strings.TrimSpace(line)
+		if inBlock != "" {
+			if strings.EqualFold(trimmed, inBlock) {
+				inBlock = ""
+			}
+			continue
+		}
+
+		if match := orgHeadlinePattern.FindStringSubmatch(line); match != nil {
+			out = append(out, strings.Repeat("#", len(match[1]))+" "+orgHeadline(match[2]))
+			continue
+		}
+		if strings.EqualFold(trimmed, "#+BEGIN_COMMENT") {
+			inBlock = "#+END_COMMENT"
+			continue
+		}
+		if match := orgKeywordPattern.FindStringSubmatch(trimmed); match != nil {
+			if strings.EqualFold(match[1], "PROPERTY") {
+				name, value, _ := strings.Cut(match[2], " ")
+				setDefault(&front, name, value)
+			}
+			continue
+		}
+		if trimmed == "#" || strings.HasPrefix(trimmed, "# ") || strings.HasPrefix(trimmed, "#+") {
+			continue
+		}
+		if orgDrawerPattern.MatchString(trimmed) && !strings.EqualFold(trimmed, ":END:") {
+			inBlock = ":END:"
+			continue
+		}
+		if orgPlanningPattern.MatchString(trimmed) {
+			continue
+		}
+
+		line = strings.Replace(line, "[-] ", "[ ] ", 1)
+		if match := orgDescListPattern.FindStringSubmatch(line); match != nil {
+			line = match[1] + ": " + match[2]
+		}
+		out = append(out, orgInline(line))
+	}
+	return withFrontMatter(front, strings.Join(out, "\n"))
+}
+
+// orgHeadline rewrites a headline's TODO keyword as a checkbox and its
+// priority cookie as a marker, and drops its tags.
+func orgHeadline(text string) string {
+	text = orgTagsPattern.ReplaceAllString(text, "")
+	priority := ""
+	if match := orgPriorityCookie.FindStringSubmatch(text); match != nil {
+		priority = " [" + orgPriorities[strings.ToLower(match[1])] + "]"
+		text = orgPriorityCookie.ReplaceAllString(text, "")
+	}
+	keyword, rest, _ := strings.Cut(strings.TrimSpace(text), " ")
+	switch keyword {
+	case "TODO":
+		text = "[ ] " + rest
+	case "DONE":
+		text = "[x] " + rest
+	}
+	return orgInline(strings.TrimSpace(text)) + priority
+}
+
+// orgInline rewrites Org links as Markdown links and =verbatim= and ~code~
+// as code spans.
+func orgInline(text string) string {
+	text = orgLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := orgLinkPattern.FindStringSubmatch(link)
+		if match[2] == "" {
+			return match[1]
+		}
+		return "[" + match[2] + "](" + match[1] + ")"
+	})
+	return orgVerbatimPattern.ReplaceAllString(text, "$1`$2`$3")
+}
@@ -15,20 +15,49 @@ var ErrNoTasks = errors.New("no tasks detected in PRD")
 type Node struct {
 	Title       string
 	Description string
-	Children    []*Node
+	// Status is "done" or "pending" for GitHub task list items, or the front
+	// matter default; empty leaves it to the importer.
+	Status   string
+	Priority string
+	// TestStrategy collects the acceptance criteria or test plan of a section.
+	TestStrategy string
+	// DependsOn lists the titles (or task IDs) of the sections this one
+	// depends on, resolved by BuildTaskDocuments.
+	DependsOn []string
+	// Section and Hash, when set by MarkSections, are the section path and
+	// content hash the node was parsed with. They are recorded in its anchor
+	// instead of ones derived from its current title and text, so a task
+	// edited before it is written stays matched to its section.
+	Section  string
+	Hash     string
+	Children []*Node
 }
 
 // Summary contains lightweight data about a parsed task for UI display.
 type Summary struct {
 	Title        string
 	Description  string
+	Priority     string
+	Status       string
 	SubtaskCount int
 }
 
 var bulletPattern = regexp.MustCompile(`^(?:\d+[\.)]|[-+*])\s+`)
 
 // Parse converts a PRD document into a hierarchical set of Nodes.
+//
+// Headings and bullets become tasks and the text under them their
+// descriptions. On top of that it recognises YAML front matter declaring a
+// default priority and status, GitHub checkboxes ("[x]" is done), priority
+// markers such as "(P0)" or "[high]" and "Priority:" lines, "depends on"
+// references to other sections, and "Acceptance criteria" or "Test plan"
+// sections, which become the test strategy of the enclosing task.
 func Parse(content string) ([]*Node, error) {
+	front, content, err := splitFrontMatter(content)
+	if err != nil {
+		return nil, err
+	}
+
 	scanner := bufio.NewScanner(strings.NewReader(content))
 	// Increase the scanner buffer to tolerate wide markdown tables or paragraphs.
 	buf := make([]byte, 0, 64*1024)
@@ -39,6 +68,7 @@ func Parse(content string) ([]*Node, error) {
 		headingStack []*Node
 		bulletStack  []*Node
 		lastNode     *Node
+		strategy     *Node // task receiving acceptance criteria lines
 	)
 
 	lineNum := 0
@@ -48,26 +78,48 @@ func Parse(content string) ([]*Node, error) {
 		trimmed := strings.TrimSpace(raw)
 
 		if trimmed == "" {
-			appendParagraphBreak(lastNode)
+			if strategy == nil {
+				appendParagraphBreak(lastNode)
+			}
+			continue
+		}
+		if isThematicBreak(trimmed) {
 			continue
 		}
 
 		if level, title := parseHeading(trimmed); level > 0 {
-			node := &Node{Title: title}
+			title, status := parseCheckbox(title)
+			strategy = nil
 			headingStack = trimStack(headingStack, level-1)
+			bulletStack = bulletStack[:0]
+			if isStrategyTitle(title) {
+				if parent := last(headingStack); parent != nil {
+					strategy = parent
+					lastNode = nil
+					continue
+				}
+			}
+			node := newNode(title)
+			node.Status = status
 			if parent := last(headingStack); parent != nil {
 				parent.Children = append(parent.Children, node)
 			} else {
 				roots = append(roots, node)
 			}
 			headingStack = append(headingStack, node)
-			bulletStack = bulletStack[:0]
 			lastNode = node
 			continue
 		}
 
+		if strategy != nil {
+			appendStrategyLine(strategy, raw)
+			continue
+		}
+
 		if indent, text, ok := parseBullet(raw); ok {
-			node := &Node{Title: text}
+			text, status := parseCheckbox(text)
+			node := newNode(text)
+			node.Status = status
 			depth := indent / 2
 			if depth < 0 {
 				depth = 0
@@ -101,9 +153,18 @@ func Parse(content string) ([]*Node, error) {
 			continue
 		}
 
+		if isStrategyTitle(trimmed) && strings.HasSuffix(strings.Trim(trimmed, "*_ "), ":") {
+			if target := last(headingStack); target != nil {
+				strategy = target
+				bulletStack = bulletStack[:0]
+				lastNode = nil
+				continue
+			}
+		}
+
 		if lastNode == nil {
 			// Treat miscellaneous text as a standalone task when nothing has been created yet.
-			node := &Node{Title: trimmed}
+			node := newNode(trimmed)
 			roots = append(roots, node)
 			headingStack = append(headingStack, node)
 			bulletStack = bulletStack[:0]
@@ -111,6 +172,11 @@ func Parse(content string) ([]*Node, error) {
 			continue
 		}
 
+		if priority, ok := parsePriorityLine(trimmed); ok {
+			lastNode.Priority = priority
+			continue
+		}
+		lastNode.DependsOn = append(lastNode.DependsOn, parseDependsOn(trimmed)...)
 		if lastNode.Description != "" {
 			lastNode.Description += "\n"
 		}
@@ -127,10 +193,38 @@ func Parse(content string) ([]*Node, error) {
 
 	for _, n := range roots {
 		trimDescriptions(n)
+		applyDefaults(n, front)
 	}
 	return roots, nil
 }
 
+// newNode creates a task from a heading or bullet, taking its priority
+// marker and dependency notes out of the title.
+func newNode(text string) *Node {
+	title, priority, dependsOn := parseTitle(text)
+	return &Node{Title: title, Priority: priority, DependsOn: dependsOn}
+}
+
+// appendStrategyLine adds a line of an acceptance criteria section to the
+// test strategy of n, keeping bullets and their nesting as Markdown.
+func appendStrategyLine(n *Node, raw string) {
+	line := strings.TrimSpace(raw)
+	if indent, text, ok := parseBullet(raw); ok {
+		text, status := parseCheckbox(text)
+		box := ""
+		if status == "done" {
+			box = "[x] "
+		} else if status == "pending" {
+			box = "[ ] "
+		}
+		line = strings.Repeat("  ", indent/2) + "- " + box + text
+	}
+	if n.TestStrategy != "" {
+		n.TestStrategy += "\n"
+	}
+	n.TestStrategy += line
+}
+
 // Summaries flattens parsed nodes for presentation purposes.
 func Summaries(nodes []*Node) []Summary {
 	summaries := make([]Summary, 0, len(nodes))
@@ -144,6 +238,8 @@ func summarizeNode(n *Node) Summary {
 	summary := Summary{
 		Title:        n.Title,
 		Description:  snippet(n.Description, 140),
+		Priority:     n.Priority,
+		Status:       n.Status,
 		SubtaskCount: len(n.Children),
 	}
 	return summary
@@ -175,6 +271,21 @@ func parseHeading(line string) (int, string) {
 	return hashes, title
 }
 
+// isThematicBreak reports whether line is a horizontal rule such as "---"
+// or "* * *", which only separates content.
+func isThematicBreak(line string) bool {
+	compact := strings.ReplaceAll(line, " ", "")
+	if len(compact) < 3 {
+		return false
+	}
+	for _, marker := range []string{"-", "*", "_"} {
+		if strings.Trim(compact, marker) == "" {
+			return true
+		}
+	}
+	return false
+}
+
 func parseBullet(line string) (int, string, bool) {
 	indent := countLeadingSpaces(line)
 	trimmed := strings.TrimLeft(line, " \t")
@@ -194,6 +305,7 @@ func parseBullet(line string) (int, string, bool) {
 
 func trimDescriptions(n *Node) {
 	n.Description = strings.TrimSpace(n.Description)
+	n.TestStrategy = strings.TrimSpace(n.TestStrategy)
 	for _, child := range n.Children {
 		trimDescriptions(child)
 	}
@@ -1,6 +1,10 @@
 package prd
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestParseHeadingsAndBullets(t *testing.T) {
 	content := `# Feature A
@@ -67,6 +71,27 @@ func TestBuildTaskDocuments(t *testing.T) {
 	}
 }
 
+func TestBuildAnchoredTaskDocuments(t *testing.T) {
+	nodes := []*Node{{
+		Title:       "Task A",
+		Description: "Desc",
+		Children:    []*Node{{Title: "Step"}, {Title: "Step"}},
+	}}
+
+	docs := BuildAnchoredTaskDocuments("docs/prd.md", nodes, 1)
+	anchor := docs[0]["metadata"].(map[string]string)
+	if anchor[AnchorSource] != "docs/prd.md" || anchor[AnchorSection] != "Task A" || anchor[AnchorHash] != ContentHash(nodes[0]) {
+		t.Fatalf("unexpected anchor %v", anchor)
+	}
+	sub := docs[0]["subtasks"].([]map[string]interface{})
+	if got := sub[1]["metadata"].(map[string]string)[AnchorSection]; got != "Task A > Step (2)" {
+		t.Fatalf("expected repeated titles numbered, got %q", got)
+	}
+	if _, ok := BuildTaskDocuments(nodes, 1)[0]["metadata"]; ok {
+		t.Fatalf("expected no anchor without a source")
+	}
+}
+
 func TestSummaries(t *testing.T) {
 	nodes := []*Node{{Title: "Task", Description: "A long description"}}
 	s := Summaries(nodes)
@@ -77,3 +102,154 @@ func TestSummaries(t *testing.T) {
 		t.Fatalf("unexpected title %s", s[0].Title)
 	}
 }
+
+func parseFixture(t *testing.T, name string) []*Node {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	nodes, err := Parse(string(data))
+	if err != nil {
+		t.Fatalf("Parse(%s) returned error: %v", name, err)
+	}
+	return nodes
+}
+
+func TestParseChecklists(t *testing.T) {
+	nodes := parseFixture(t, "checklist.md")
+	items := nodes[0].Children
+	if len(items) != 3 {
+		t.Fatalf("expected 3 checklist items, got %d", len(items))
+	}
+	if items[0].Title != "Register the domain" || items[0].Status != "done" {
+		t.Fatalf("expected checked item done, got %+v", items[0])
+	}
+	if items[1].Status != "pending" || items[1].Children[0].Status != "done" || items[1].Children[1].Status != "pending" {
+		t.Fatalf("expected nested boxes mapped, got %+v", items[1])
+	}
+	if items[2].Status != "" {
+		t.Fatalf("expected plain bullet without status, got %q", items[2].Status)
+	}
+}
+
+func TestParseAcceptanceCriteria(t *testing.T) {
+	nodes := parseFixture(t, "acceptance.md")
+	login := nodes[0]
+	want := "- [ ] Wrong passwords show an error\n- Sessions expire after 30 minutes\n  - Expiry is configurable"
+	if login.TestStrategy != want {
+		t.Fatalf("unexpected test strategy:\n%s", login.TestStrategy)
+	}
+	if len(login.Children) != 1 || login.Children[0].Title != "Remember me" {
+		t.Fatalf("expected criteria not to become tasks, got %d children", len(login.Children))
+	}
+	remember := login.Children[0]
+	if remember.Description != "Keep users signed in for 30 days." || remember.TestStrategy != "Clear cookies and reopen the browser." {
+		t.Fatalf("expected test plan label to start the strategy, got %+v", remember)
+	}
+	if len(nodes) != 2 || nodes[1].TestStrategy != "" || len(nodes[1].Children) != 1 {
+		t.Fatalf("expected the next heading to end the strategy, got %+v", nodes[1])
+	}
+}
+
+func TestParsePriorities(t *testing.T) {
+	nodes := parseFixture(t, "priorities.md")
+	payments, reports := nodes[0], nodes[1]
+	cases := []struct {
+		node     *Node
+		title    string
+		priority string
+	}{
+		{payments, "Payments", "critical"},
+		{payments.Children[0], "Refunds", "high"},
+		{payments.Children[1], "Invoices", "low"},
+		{reports, "Reports", "low"},
+		{reports.Children[0], "Export to CSV", "medium"},
+		{reports.Children[1], "Schedule emails", "high"},
+	}
+	for _, tc := range cases {
+		if tc.node.Title != tc.title || tc.node.Priority != tc.priority {
+			t.Fatalf("expected %s with priority %s, got %q %q", tc.title, tc.priority, tc.node.Title, tc.node.Priority)
+		}
+	}
+	if payments.Children[1].Description != "" {
+		t.Fatalf("expected the priority line kept out of the description, got %q", payments.Children[1].Description)
+	}
+
+	// Only markers at the start or end of a title set the priority
+	nodes, err := Parse("# Support [low] latency reads\n# Login (P0) (depends on Support [low] latency reads)\n")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if nodes[0].Title != "Support [low] latency reads" || nodes[0].Priority != "" {
+		t.Fatalf("expected a mid-title bracket kept in the title, got %q %q", nodes[0].Title, nodes[0].Priority)
+	}
+	if nodes[1].Title != "Login" || nodes[1].Priority != "critical" {
+		t.Fatalf("expected a marker before a dependency note, got %q %q", nodes[1].Title, nodes[1].Priority)
+	}
+}
+
+func TestParseDependencies(t *testing.T) {
+	nodes := parseFixture(t, "dependencies.md")
+	if nodes[1].Title != "API" {
+		t.Fatalf("expected dependency note stripped from the title, got %q", nodes[1].Title)
+	}
+
+	docs := BuildTaskDocuments(nodes, 4)
+	assertDeps := func(doc map[string]interface{}, want ...interface{}) {
+		t.Helper()
+		got, _ := doc["dependencies"].([]interface{})
+		if len(got) != len(want) {
+			t.Fatalf("expected dependencies %v for %s, got %v", want, doc["title"], got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected dependencies %v for %s, got %v", want, doc["title"], got)
+			}
+		}
+	}
+	assertDeps(docs[0])
+	assertDeps(docs[1], 4)
+	// The Endpoints subtask resolves to its top-level task, already listed.
+	assertDeps(docs[2], 5)
+	subtasks := docs[1]["subtasks"].([]map[string]interface{})
+	if subtasks[2]["title"] != "Rate limiting" {
+		t.Fatalf("expected trailing dependency note stripped, got %q", subtasks[2]["title"])
+	}
+	assertDeps(subtasks[2], "5.2")
+}
+
+func TestParseFrontMatterDefaults(t *testing.T) {
+	nodes := parseFixture(t, "frontmatter.md")
+	if len(nodes) != 1 || nodes[0].Title != "Offline mode" {
+		t.Fatalf("expected front matter kept out of the tasks, got %+v", nodes)
+	}
+	offline := nodes[0]
+	if offline.Priority != "high" || offline.Status != "deferred" {
+		t.Fatalf("expected defaults applied, got %q %q", offline.Priority, offline.Status)
+	}
+	if cached := offline.Children[0]; cached.Status != "done" || cached.Priority != "high" {
+		t.Fatalf("expected checkbox to override the default status, got %+v", cached)
+	}
+	if sync := offline.Children[1]; sync.Priority != "low" || sync.Status != "deferred" {
+		t.Fatalf("expected marker to override the default priority, got %+v", sync)
+	}
+
+	docs := BuildTaskDocuments(nodes, 1)
+	if docs[0]["status"] != "deferred" || docs[0]["priority"] != "high" {
+		t.Fatalf("expected defaults written, got %v", docs[0])
+	}
+
+	if _, err := Parse("---\npriority: urgent\n---\n# Task\n"); err == nil {
+		t.Fatalf("expected an invalid front matter priority to be rejected")
+	}
+
+	// Text between two rules is not front matter
+	nodes, err := Parse("---\n# Product\nSome intro text\n---\n## Login\n- Build form\n")
+	if err != nil {
+		t.Fatalf("expected a leading rule parsed as content, got %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Title != "Product" || len(nodes[0].Children) != 1 || nodes[0].Children[0].Title != "Login" {
+		t.Fatalf("expected the heading between the rules kept, got %+v", nodes)
+	}
+}
@@ -0,0 +1,146 @@
+package prd
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// rstAdornments are the punctuation characters reStructuredText accepts in
+// section title underlines and overlines.
+const rstAdornments = "=-~^\"'`#*+:._<>"
+
+var (
+	rstFieldPattern   = regexp.MustCompile(`^:([A-Za-z][\w .-]*):(?:\s+(.*))?$`)
+	rstAutoEnumPrefix = regexp.MustCompile(`^(\s*)#\.\s+`)
+	rstLinkPattern    = regexp.MustCompile("`([^`<]+?)\\s*<([^>]+)>`_{1,2}")
+	rstRefPattern     = regexp.MustCompile("`([^`]+)`_{1,2}")
+	rstRolePattern    = regexp.MustCompile(":[\\w-]+:`([^`]+)`")
+	rstLiteralPattern = regexp.MustCompile("``([^`]+)``")
+)
+
+// rstToMarkdown rewrites a reStructuredText PRD as the Markdown Parse reads.
+// Section titles become headings, levelled by the order their adornment
+// styles first appear; "#." lists become bullets; a field list before the
+// first title supplies the document defaults and later fields become
+// "Name: value" lines; comments, directive markup and transitions are
+// dropped.
+func rstToMarkdown(content string) string {
+	lines := splitLines(content)
+	var (
+		out       []string
+		front     frontMatter
+		styles    []string
+		titled    bool
+		inComment bool
+	)
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if inComment {
+			if trimmed == "" || line[0] == ' ' || line[0] == '\t' {
+				continue
+			}
+			inComment = false
+		}
+
+		if isRSTAdornment(trimmed) && i+2 < len(lines) && strings.TrimSpace(lines[i+2]) == trimmed {
+			if title := strings.TrimSpace(lines[i+1]); title != "" && !isRSTAdornment(title) {
+				out = append(out, rstHeading(&styles, "over"+trimmed[:1], title))
+				titled = true
+				i += 2
+				continue
+			}
+		}
+		if i+1 < len(lines) && isRSTUnderline(line, lines[i+1]) {
+			out = append(out, rstHeading(&styles, "under"+lines[i+1][:1], trimmed))
+			titled = true
+			i++
+			continue
+		}
+		if isRSTAdornment(trimmed) && len(trimmed) >= 4 {
+			// A transition.
+			continue
+		}
+
+		if trimmed == ".." || strings.HasPrefix(trimmed, ".. ") {
+			// Comments and hyperlink targets are dropped with their
+			// indented body; directive bodies are kept as text.
+			inComment = !strings.Contains(trimmed, "::")
+			continue
+		}
+		if trimmed == "::" {
+			continue
+		}
+
+		if match := rstFieldPattern.FindStringSubmatch(trimmed); match != nil {
+			switch {
+			case !titled:
+				setDefault(&front, match[1], match[2])
+			case line[0] == ':':
+				out = append(out, match[1]+": "+rstInline(match[2]))
+			}
+			// Indented fields are directive options.
+			continue
+		}
+
+		line = rstAutoEnumPrefix.ReplaceAllString(line, "$1- ")
+		if strings.HasSuffix(line, "::") {
+			line = strings.TrimSuffix(line, ":")
+		}
+		out = append(out, rstInline(line))
+	}
+	return withFrontMatter(front, strings.Join(out, "\n"))
+}
+
+// rstHeading returns a Markdown heading for a section title whose adornment
+// style is style, recording the style's level on first use.
+func rstHeading(styles *[]string, style, title string) string {
+	level := 0
+	for i, known := range *styles {
+		if known == style {
+			level = i + 1
+			break
+		}
+	}
+	if level == 0 {
+		*styles = append(*styles, style)
+		level = len(*styles)
+	}
+	if level > 6 {
+		level = 6
+	}
+	return strings.Repeat("#", level) + " " + rstInline(title)
+}
+
+// isRSTUnderline reports whether next underlines line as a section title:
+// a run of one adornment character at least as long as the title.
+func isRSTUnderline(line, next string) bool {
+	title := strings.TrimSpace(line)
+	if title == "" || line[0] == ' ' || line[0] == '\t' || isRSTAdornment(title) {
+		return false
+	}
+	if next != strings.TrimRight(next, " \t") || !isRSTAdornment(next) {
+		return false
+	}
+	return utf8.RuneCountInString(next) >= utf8.RuneCountInString(title)
+}
+
+// isRSTAdornment reports whether s is a run of two or more of the same
+// adornment character.
+func isRSTAdornment(s string) bool {
+	if len(s) < 2 || !strings.ContainsRune(rstAdornments, rune(s[0])) {
+		return false
+	}
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+// rstInline rewrites inline markup: links become Markdown links, inline
+// literals become code spans and roles and references keep their text.
+func rstInline(text string) string {
+	text = rstLinkPattern.ReplaceAllString(text, "[$1]($2)")
+	text = rstRolePattern.ReplaceAllString(text, "$1")
+	text = rstLiteralPattern.ReplaceAllString(text, "`$1`")
+	return rstRefPattern.ReplaceAllString(text, "$1")
+}
@@ -0,0 +1,549 @@
+// Package server exposes a taskmaster.Service over a local HTTP/JSON API so
+// editors and dashboards can drive the task board without the TUI.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+)
+
+// TaskService defines the subset of taskmaster.Service behavior served over HTTP.
+type TaskService interface {
+	GetTasks() ([]taskmaster.Task, []string)
+	GetTaskByID(id string) (*taskmaster.Task, bool)
+	GetNextTask() (*taskmaster.Task, error)
+	GetValidationWarnings() []taskmaster.ValidationWarning
+	SetTaskStatus(taskID, status string) error
+	DeleteTasks(ctx context.Context, taskIDs []string, opts taskmaster.DeleteOptions) (*taskmaster.DeleteResult, error)
+	UndoAction(ctx context.Context, actionID string) error
+	AnalyzeComplexity(ctx context.Context, scope string, taskID string, tags []string) (*taskmaster.ComplexityReport, error)
+	ReloadEvents() <-chan struct{}
+}
+
+// Options configures a Server.
+type Options struct {
+	// Addr is the TCP address to listen on, e.g. "127.0.0.1:7420". Addresses
+	// other than loopback are refused unless Token is set.
+	Addr string
+
+	// Socket, when set, is a Unix socket path used instead of Addr.
+	Socket string
+
+	// Token, when set, must be presented as "Authorization: Bearer <token>"
+	// on every request.
+	Token string
+}
+
+// Server serves the task API.
+type Server struct {
+	svc  TaskService
+	opts Options
+	mux  *http.ServeMux
+
+	// subscribers receive a signal for every reload event
+	subscribers map[chan struct{}]struct{}
+	mu          sync.Mutex
+}
+
+// New creates a server for svc. Call Serve to start listening.
+func New(svc TaskService, opts Options) *Server {
+	s := &Server{
+		svc:         svc,
+		opts:        opts,
+		mux:         http.NewServeMux(),
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+
+	s.mux.HandleFunc("GET /api/tasks", s.handleListTasks)
+	s.mux.HandleFunc("GET /api/tasks/{id}", s.handleGetTask)
+	s.mux.HandleFunc("PUT /api/tasks/{id}/status", s.handleSetStatus)
+	s.mux.HandleFunc("DELETE /api/tasks/{id}", s.handleDeleteTask)
+	s.mux.HandleFunc("POST /api/undo/{token}", s.handleUndo)
+	s.mux.HandleFunc("GET /api/next", s.handleNext)
+	s.mux.HandleFunc("GET /api/validation", s.handleValidation)
+	s.mux.HandleFunc("POST /api/complexity", s.handleComplexity)
+	s.mux.HandleFunc("GET /api/events", s.handleEvents)
+	return s
+}
+
+// ServeHTTP implements http.Handler, enforcing the bearer token if configured.
+// Without a token, TCP requests must name a loopback host and come from the
+// same origin, so a web page cannot reach the API through DNS rebinding.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.opts.Token != "" {
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="tm-tui"`)
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+			return
+		}
+	} else if s.opts.Socket == "" {
+		if err := checkLocalRequest(r); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// Serve listens on the configured address or socket and blocks until ctx is
+// cancelled or the listener fails.
+func (s *Server) Serve(ctx context.Context) error {
+	listener, err := s.listen()
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go s.broadcastReloads(ctx)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := httpServer.Shutdown(shutdownCtx)
+		if s.opts.Socket != "" {
+			os.Remove(s.opts.Socket)
+		}
+		return err
+	case err := <-errChan:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+// Address describes where the server listens, for startup messages.
+func (s *Server) Address() string {
+	if s.opts.Socket != "" {
+		return "unix:" + s.opts.Socket
+	}
+	return "http://" + s.opts.Addr
+}
+
+func (s *Server) listen() (net.Listener, error) {
+	if s.opts.Socket == "" {
+		// The API can change and delete tasks, so only serve other hosts
+		// when requests must carry a token
+		if !isLoopback(s.opts.Addr) && s.opts.Token == "" {
+			return nil, fmt.Errorf("refusing to listen on non-loopback address %s without a token", s.opts.Addr)
+		}
+		listener, err := net.Listen("tcp", s.opts.Addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on %s: %w", s.opts.Addr, err)
+		}
+		return listener, nil
+	}
+
+	if err := removeStaleSocket(s.opts.Socket); err != nil {
+		return nil, err
+	}
+
+	// Bind inside a private 0700 directory and move the socket into place
+	// once it is 0600, so it is never reachable by other users
+	dir, err := os.MkdirTemp(filepath.Dir(s.opts.Socket), ".tm-tui-sock-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", s.opts.Socket, err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmpPath := filepath.Join(dir, "sock")
+	listener, err := net.Listen("unix", tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", s.opts.Socket, err)
+	}
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.opts.Socket); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", s.opts.Socket, err)
+	}
+	return listener, nil
+}
+
+// removeStaleSocket removes a socket left behind by a previous run. It
+// refuses to replace anything that is not a socket, or a socket another
+// process is still serving.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check socket %s: %w", path, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("refusing to replace %s: not a socket", path)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("socket %s is already in use", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
+
+// isLoopback reports whether addr only accepts connections from this host.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	return isLoopbackHost(host)
+}
+
+// isLoopbackHost reports whether host names this machine.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// checkLocalRequest rejects requests addressed to a non-loopback host name or
+// sent by a page from another origin.
+func checkLocalRequest(r *http.Request) error {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if !isLoopbackHost(host) {
+		return fmt.Errorf("host %q is not a loopback address", r.Host)
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || u.Host != r.Host {
+			return fmt.Errorf("cross-origin request from %q", origin)
+		}
+	}
+	return nil
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	header := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) == 1
+}
+
+// broadcastReloads fans the service's single reload channel out to every
+// connected event stream.
+func (s *Server) broadcastReloads(ctx context.Context) {
+	events := s.svc.ReloadEvents()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-events:
+			s.mu.Lock()
+			for sub := range s.subscribers {
+				select {
+				case sub <- struct{}{}:
+				default:
+					// Subscriber already has a pending reload
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *Server) subscribe() chan struct{} {
+	sub := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+	return sub
+}
+
+func (s *Server) unsubscribe(sub chan struct{}) {
+	s.mu.Lock()
+	delete(s.subscribers, sub)
+	s.mu.Unlock()
+}
+
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	statuses := splitParam(query["status"])
+	priorities := splitParam(query["priority"])
+	withSubtasks := query.Get("subtasks") == "true"
+	filter, err := taskmaster.ParseQuery(query.Get("q"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	tasks, _ := s.svc.GetTasks()
+	matches := make([]taskmaster.Task, 0, len(tasks))
+	var walk func(tasks []taskmaster.Task)
+	walk = func(tasks []taskmaster.Task) {
+		for _, task := range tasks {
+			if matchesAny(task.Status, statuses) && matchesAny(task.Priority, priorities) && filter.Match(&task) {
+				matches = append(matches, task)
+			}
+			if withSubtasks {
+				walk(task.Subtasks)
+			}
+		}
+	}
+	walk(tasks)
+
+	writeJSON(w, http.StatusOK, matches)
+}
+
+func (s *Server) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	task, ok := s.svc.GetTaskByID(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("task not found: %s", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) handleNext(w http.ResponseWriter, r *http.Request) {
+	task, err := s.svc.GetNextTask()
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) handleValidation(w http.ResponseWriter, r *http.Request) {
+	type warning struct {
+		TaskID  string `json:"taskId"`
+		Message string `json:"message"`
+	}
+	warnings := s.svc.GetValidationWarnings()
+	records := make([]warning, 0, len(warnings))
+	for _, item := range warnings {
+		records = append(records, warning{TaskID: item.TaskID, Message: item.Message})
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+func (s *Server) handleSetStatus(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if probe := (taskmaster.Task{Status: body.Status}); !probe.IsValidStatus() {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid status %q", body.Status))
+		return
+	}
+	if _, ok := s.svc.GetTaskByID(id); !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("task not found: %s", id))
+		return
+	}
+	if err := s.svc.SetTaskStatus(id, body.Status); err != nil {
+		var conflict *taskmaster.MergeConflictError
+		if errors.As(err, &conflict) {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	task, ok := s.svc.GetTaskByID(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("task not found: %s", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, ok := s.svc.GetTaskByID(id); !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("task not found: %s", id))
+		return
+	}
+	opts := taskmaster.DeleteOptions{
+		Recursive: r.URL.Query().Get("recursive") == "true",
+		Force:     r.URL.Query().Get("force") == "true",
+	}
+	result, err := s.svc.DeleteTasks(r.Context(), []string{id}, opts)
+	if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	type undoRecord struct {
+		Token     string    `json:"token"`
+		Summary   string    `json:"summary"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+	response := struct {
+		DeletedCount int         `json:"deletedCount"`
+		Warnings     []string    `json:"warnings"`
+		Undo         *undoRecord `json:"undo,omitempty"`
+	}{
+		DeletedCount: result.DeletedCount,
+		Warnings:     result.Warnings,
+	}
+	if response.Warnings == nil {
+		response.Warnings = []string{}
+	}
+	if result.Undo != nil {
+		response.Undo = &undoRecord{
+			Token:     result.Undo.ID,
+			Summary:   result.Undo.Summary,
+			ExpiresAt: result.Undo.ExpiresAt,
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleUndo(w http.ResponseWriter, r *http.Request) {
+	err := s.svc.UndoAction(r.Context(), r.PathValue("token"))
+	switch {
+	case errors.Is(err, taskmaster.ErrUndoNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, taskmaster.ErrUndoExpired):
+		writeError(w, http.StatusGone, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) handleComplexity(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Scope  string   `json:"scope"`
+		TaskID string   `json:"taskId"`
+		Tags   []string `json:"tags"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if body.Scope == "" {
+		body.Scope = "all"
+	}
+	report, err := s.svc.AnalyzeComplexity(r.Context(), body.Scope, body.TaskID, body.Tags)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// handleEvents streams a "reload" Server-Sent Event whenever tasks.json is
+// reloaded from disk.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+
+	sub := s.subscribe()
+	defer s.unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-sub:
+			fmt.Fprintf(w, "event: reload\ndata: {\"time\":%q}\n\n", time.Now().Format(time.RFC3339))
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// splitParam flattens repeated and comma-separated query values.
+func splitParam(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+func matchesAny(value string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, candidate := range allowed {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+)
+
+func setupService(t *testing.T) *taskmaster.Service {
+	t.Helper()
+	tmpDir := t.TempDir()
+	tasksDir := filepath.Join(tmpDir, ".taskmaster", "tasks")
+	if err := os.MkdirAll(tasksDir, 0755); err != nil {
+		t.Fatalf("failed to create tasks dir: %v", err)
+	}
+	tasks := []taskmaster.Task{
+		{ID: "1", Title: "Setup", Status: taskmaster.StatusDone, Priority: taskmaster.PriorityHigh},
+		{ID: "2", Title: "Build", Status: taskmaster.StatusPending, Priority: taskmaster.PriorityMedium, Dependencies: []string{"1"}},
+		{ID: "3", Title: "Docs", Status: taskmaster.StatusPending, Priority: taskmaster.PriorityLow},
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{"tasks": tasks}, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal tasks: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tasksDir, "tasks.json"), data, 0644); err != nil {
+		t.Fatalf("failed to write tasks: %v", err)
+	}
+	svc, err := taskmaster.NewService(&config.Config{TaskMasterPath: tmpDir})
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+	return svc
+}
+
+func do(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Host = "127.0.0.1:7420"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestTaskEndpoints(t *testing.T) {
+	svc := setupService(t)
+	srv := New(svc, Options{})
+
+	rec := do(t, srv, http.MethodGet, "/api/tasks?status=pending", "")
+	var tasks []taskmaster.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &tasks); err != nil || len(tasks) != 2 {
+		t.Fatalf("expected 2 pending tasks, got %d (%v): %s", len(tasks), err, rec.Body)
+	}
+
+	if rec := do(t, srv, http.MethodGet, "/api/tasks/42", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown task, got %d", rec.Code)
+	}
+
+	rec = do(t, srv, http.MethodGet, "/api/next", "")
+	var next taskmaster.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &next); err != nil || next.ID != "2" {
+		t.Fatalf("expected next task 2, got %q (%v)", next.ID, err)
+	}
+
+	rec = do(t, srv, http.MethodPut, "/api/tasks/3/status", `{"status":"in-progress"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 from status update, got %d: %s", rec.Code, rec.Body)
+	}
+	if task, _ := svc.GetTaskByID("3"); task.Status != taskmaster.StatusInProgress {
+		t.Fatalf("expected status in-progress, got %q", task.Status)
+	}
+	if rec := do(t, srv, http.MethodPut, "/api/tasks/3/status", `{"status":"bogus"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid status, got %d", rec.Code)
+	}
+
+	rec = do(t, srv, http.MethodPost, "/api/complexity", "")
+	var report taskmaster.ComplexityReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil || len(report.Tasks) != 3 {
+		t.Fatalf("expected complexity for 3 tasks, got %+v (%v)", report, err)
+	}
+}
+
+func TestDeleteAndUndo(t *testing.T) {
+	svc := setupService(t)
+	srv := New(svc, Options{})
+
+	rec := do(t, srv, http.MethodDelete, "/api/tasks/3", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 from delete, got %d: %s", rec.Code, rec.Body)
+	}
+	var result struct {
+		DeletedCount int `json:"deletedCount"`
+		Undo         struct {
+			Token string `json:"token"`
+		} `json:"undo"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || result.DeletedCount != 1 || result.Undo.Token == "" {
+		t.Fatalf("unexpected delete response: %s", rec.Body)
+	}
+	if _, ok := svc.GetTaskByID("3"); ok {
+		t.Fatalf("expected task 3 to be deleted")
+	}
+
+	if rec := do(t, srv, http.MethodPost, "/api/undo/"+result.Undo.Token, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 from undo, got %d: %s", rec.Code, rec.Body)
+	}
+	if _, ok := svc.GetTaskByID("3"); !ok {
+		t.Fatalf("expected task 3 to be restored")
+	}
+	if rec := do(t, srv, http.MethodPost, "/api/undo/"+result.Undo.Token, ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for consumed undo token, got %d", rec.Code)
+	}
+
+	// Task 2 depends on task 1, so a non-recursive delete must be refused
+	if rec := do(t, srv, http.MethodDelete, "/api/tasks/1", ""); rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for delete with dependents, got %d", rec.Code)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	srv := New(setupService(t), Options{Token: "secret"})
+
+	if rec := do(t, srv, http.MethodGet, "/api/tasks", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 with token, got %d", rec.Code)
+	}
+}
+
+func TestRejectsForeignHost(t *testing.T) {
+	srv := New(setupService(t), Options{Addr: "127.0.0.1:7420"})
+
+	for _, host := range []string{"evil.example:7420", "evil.example", "192.168.1.5:7420"} {
+		req := httptest.NewRequest(http.MethodDelete, "/api/tasks/3", nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("expected 403 for host %q, got %d", host, rec.Code)
+		}
+	}
+	for _, host := range []string{"127.0.0.1:7420", "localhost:7420", "[::1]:7420"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200 for host %q, got %d", host, rec.Code)
+		}
+	}
+}
+
+func TestRejectsCrossOrigin(t *testing.T) {
+	svc := setupService(t)
+	srv := New(svc, Options{Addr: "127.0.0.1:7420"})
+
+	for _, origin := range []string{"http://evil.example", "http://localhost:3000", "null"} {
+		req := httptest.NewRequest(http.MethodPut, "/api/tasks/3/status", strings.NewReader(`{"status":"done"}`))
+		req.Host = "127.0.0.1:7420"
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("expected 403 for origin %q, got %d", origin, rec.Code)
+		}
+	}
+	if task, _ := svc.GetTaskByID("3"); task.Status != taskmaster.StatusPending {
+		t.Fatalf("expected task 3 untouched, got %s", task.Status)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+	req.Host = "127.0.0.1:7420"
+	req.Header.Set("Origin", "http://127.0.0.1:7420")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a same-origin request, got %d", rec.Code)
+	}
+}
+
+func TestEventsStreamReloads(t *testing.T) {
+	reloads := make(chan struct{}, 1)
+	srv := New(&reloadService{Service: setupService(t), reloads: reloads}, Options{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go srv.broadcastReloads(ctx)
+
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/events")
+	if err != nil {
+		t.Fatalf("failed to open event stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected event stream content type, got %q", ct)
+	}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	// Wait for the stream to be established before signalling a reload
+	<-lines
+	reloads <- struct{}{}
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("event stream closed before reload event")
+			}
+			if line == "event: reload" {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for reload event")
+		}
+	}
+}
+
+// reloadService lets tests trigger reload events without a file watcher.
+type reloadService struct {
+	*taskmaster.Service
+	reloads chan struct{}
+}
+
+func (s *reloadService) ReloadEvents() <-chan struct{} {
+	return s.reloads
+}
+
+func TestListenSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "tm-sock")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "tm.sock")
+
+	// A stale socket from a previous run is replaced
+	stale, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to create stale socket: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	srv := New(setupService(t), Options{Socket: socket})
+	listener, err := srv.listen()
+	if err != nil {
+		t.Fatalf("listen returned error: %v", err)
+	}
+	defer listener.Close()
+	info, err := os.Stat(socket)
+	if err != nil {
+		t.Fatalf("expected socket at %s: %v", socket, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 || info.Mode().Perm() != 0600 {
+		t.Fatalf("expected a 0600 socket, got %v", info.Mode())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("expected only the socket left in %s, got %v", dir, entries)
+	}
+
+	// A socket that is still being served is left alone
+	if _, err := srv.listen(); err == nil || !strings.Contains(err.Error(), "already in use") {
+		t.Fatalf("expected an in-use error, got %v", err)
+	}
+
+	// So is anything that is not a socket
+	file := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(file, []byte("keep"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if _, err := New(setupService(t), Options{Socket: file}).listen(); err == nil {
+		t.Fatalf("expected an error for a regular file")
+	}
+	if data, _ := os.ReadFile(file); string(data) != "keep" {
+		t.Fatalf("expected the file untouched, got %q", data)
+	}
+}
+
+func TestListenRefusesRemoteWithoutToken(t *testing.T) {
+	if _, err := New(setupService(t), Options{Addr: "0.0.0.0:0"}).listen(); err == nil || !strings.Contains(err.Error(), "non-loopback") {
+		t.Fatalf("expected a non-loopback address to be refused, got %v", err)
+	}
+	for _, opts := range []Options{{Addr: "127.0.0.1:0"}, {Addr: "localhost:0"}, {Addr: "0.0.0.0:0", Token: "secret"}} {
+		listener, err := New(setupService(t), opts).listen()
+		if err != nil {
+			t.Fatalf("listen %+v returned error: %v", opts, err)
+		}
+		listener.Close()
+	}
+}
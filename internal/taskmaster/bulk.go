@@ -0,0 +1,425 @@
+package taskmaster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BulkAction names a change applied to several tasks at once.
+type BulkAction string
+
+const (
+	// BulkSetStatus sets the status of every task to Value.
+	BulkSetStatus BulkAction = "set-status"
+	// BulkSetPriority sets the priority of every task to Value.
+	BulkSetPriority BulkAction = "set-priority"
+	// BulkAddTag adds the label Value to every task.
+	BulkAddTag BulkAction = "add-tag"
+	// BulkRemoveTag removes the label Value from every task.
+	BulkRemoveTag BulkAction = "remove-tag"
+	// BulkAddDependency makes every task depend on the task with ID Value.
+	BulkAddDependency BulkAction = "add-dependency"
+	// BulkMoveToTag moves top-level tasks, with their subtasks, into the tag
+	// context Value.
+	BulkMoveToTag BulkAction = "move-to-tag"
+	// BulkExpand breaks every task into subtasks with the task-master CLI.
+	BulkExpand BulkAction = "expand"
+)
+
+// BulkOperation is one change applied to a set of tasks.
+type BulkOperation struct {
+	Action BulkAction
+	Value  string
+	Expand ExpandTaskOptions // used by BulkExpand
+}
+
+// BulkFailure explains why a task was left out of a bulk change.
+type BulkFailure struct {
+	TaskID string
+	Reason string
+}
+
+// BulkResult reports the outcome of a bulk change for each requested task.
+type BulkResult struct {
+	Changed   []string
+	Unchanged []string // already in the requested state
+	Failed    []BulkFailure
+	Notes     []string // side effects such as renumbered IDs or dropped dependencies
+}
+
+// Describe summarises the operation applied to count tasks, as shown in the
+// undo history.
+func (op BulkOperation) Describe(count int) string {
+	tasks := pluralTasks(count)
+	switch op.Action {
+	case BulkSetStatus:
+		return fmt.Sprintf("Set %s to %s", tasks, op.Value)
+	case BulkSetPriority:
+		return fmt.Sprintf("Set priority of %s to %s", tasks, op.Value)
+	case BulkAddTag:
+		return fmt.Sprintf("Tagged %s with %s", tasks, op.Value)
+	case BulkRemoveTag:
+		return fmt.Sprintf("Removed tag %s from %s", op.Value, tasks)
+	case BulkAddDependency:
+		return fmt.Sprintf("Made %s depend on %s", tasks, op.Value)
+	case BulkMoveToTag:
+		return fmt.Sprintf("Moved %s to tag %s", tasks, op.Value)
+	case BulkExpand:
+		return fmt.Sprintf("Expanded %s", tasks)
+	}
+	return fmt.Sprintf("Changed %s", tasks)
+}
+
+// ApplyBulk applies op to every task in taskIDs as a single change with one
+// undo entry. Tasks the change cannot apply to are reported in the result
+// rather than failing the whole operation; an error means nothing was
+// written.
+func (s *Service) ApplyBulk(ctx context.Context, taskIDs []string, op BulkOperation) (*BulkResult, error) {
+	if len(taskIDs) == 0 {
+		return nil, fmt.Errorf("no tasks provided")
+	}
+	ids := append([]string(nil), taskIDs...)
+	SortTaskIDs(ids)
+	if op.Action == BulkExpand {
+		return s.expandTasks(ctx, ids, op)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	value := strings.TrimSpace(op.Value)
+	if value == "" {
+		return nil, fmt.Errorf("%s needs a value", op.Action)
+	}
+	op.Value = value
+	if op.Action == BulkMoveToTag {
+		return s.moveTasksToTagLocked(ctx, ids, op)
+	}
+	if err := s.validateBulkValueLocked(op); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	working := cloneTaskTree(s.Tasks)
+	index, _ := buildTaskIndex(working)
+	now := Now()
+	result := &BulkResult{}
+	for _, id := range ids {
+		task, ok := index[id]
+		if !ok {
+			result.Failed = append(result.Failed, BulkFailure{TaskID: id, Reason: "task not found"})
+			continue
+		}
+		update, changed := bulkTaskUpdate(task, op)
+		if !changed {
+			result.Unchanged = append(result.Unchanged, id)
+			continue
+		}
+		if err := applyTaskUpdate(task, update, index); err != nil {
+			result.Failed = append(result.Failed, BulkFailure{TaskID: id, Reason: err.Error()})
+			continue
+		}
+		task.UpdatedAt = now
+		result.Changed = append(result.Changed, id)
+	}
+	if len(result.Changed) == 0 {
+		return result, nil
+	}
+
+	actionType := UndoActionEdit
+	if op.Action == BulkSetStatus {
+		actionType = UndoActionStatus
+	}
+	if _, err := s.commitTasksLocked(working, newUndoAction(actionType, op.Describe(len(result.Changed)))); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validateBulkValueLocked rejects values that would fail for every task.
+func (s *Service) validateBulkValueLocked(op BulkOperation) error {
+	switch op.Action {
+	case BulkSetStatus:
+		if probe := (Task{Status: op.Value}); !probe.IsValidStatus() {
+			return fmt.Errorf("invalid status: %s", op.Value)
+		}
+	case BulkSetPriority:
+		if probe := (Task{Priority: op.Value}); !probe.IsValidPriority() {
+			return fmt.Errorf("invalid priority: %s", op.Value)
+		}
+	case BulkAddDependency:
+		if _, ok := s.TaskIndex[op.Value]; !ok {
+			return fmt.Errorf("dependency %s not found", op.Value)
+		}
+	case BulkAddTag, BulkRemoveTag:
+	default:
+		return fmt.Errorf("unsupported bulk action %q", op.Action)
+	}
+	return nil
+}
+
+// bulkTaskUpdate builds the update op makes to task, reporting false when the
+// task is already in the requested state.
+func bulkTaskUpdate(task *Task, op BulkOperation) (TaskUpdate, bool) {
+	value := op.Value
+	switch op.Action {
+	case BulkSetStatus:
+		return TaskUpdate{Status: &value}, task.Status != value
+	case BulkSetPriority:
+		return TaskUpdate{Priority: &value}, task.Priority != value
+	case BulkAddTag:
+		tags := append(append([]string{}, task.Tags...), value)
+		return TaskUpdate{Tags: &tags}, !slices.Contains(task.Tags, value)
+	case BulkRemoveTag:
+		tags := removeID(task.Tags, value)
+		return TaskUpdate{Tags: &tags}, len(tags) != len(task.Tags)
+	case BulkAddDependency:
+		deps := append(append([]string{}, task.Dependencies...), value)
+		return TaskUpdate{Dependencies: &deps}, !slices.Contains(task.Dependencies, value)
+	}
+	return TaskUpdate{}, false
+}
+
+// moveTasksToTagLocked moves the top-level tasks in ids into the tag context
+// op.Value, writing both tags in one go. Moved tasks are renumbered after the
+// highest ID in the target tag, and dependencies between moved and remaining
+// tasks are dropped since they cannot cross tags. Must be called with write
+// lock held.
+func (s *Service) moveTasksToTagLocked(ctx context.Context, ids []string, op BulkOperation) (*BulkResult, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	path := s.tasksFilePath()
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tasks file: %w", err)
+	}
+	if sha256.Sum256(before) != s.fileHash {
+		return nil, fmt.Errorf("tasks.json changed on disk; reload before moving tasks")
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(before, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse tasks file: %w", err)
+	}
+	source := detectTaskContainerKey(raw, s.config)
+	if source == "" {
+		return nil, fmt.Errorf("tasks.json has no tag contexts to move tasks between")
+	}
+	target := op.Value
+	if target == source {
+		return nil, fmt.Errorf("tasks are already in tag %s", target)
+	}
+	targetRaw, ok := raw[target]
+	if !ok {
+		return nil, fmt.Errorf("tag %s not found", target)
+	}
+	var targetTasks struct {
+		Tasks []Task `json:"tasks"`
+	}
+	if err := json.Unmarshal(targetRaw, &targetTasks); err != nil {
+		return nil, fmt.Errorf("failed to parse tag %s: %w", target, err)
+	}
+
+	result := &BulkResult{}
+	working := cloneTaskTree(s.Tasks)
+	index, _ := buildTaskIndex(working)
+	selected := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+	moving := make(map[string]bool)
+	for _, id := range ids {
+		task, ok := index[id]
+		switch {
+		case !ok:
+			result.Failed = append(result.Failed, BulkFailure{TaskID: id, Reason: "task not found"})
+		case siblingPosition(working, id) >= 0:
+			moving[id] = true
+		case !selected[topLevelID(id)]:
+			result.Failed = append(result.Failed, BulkFailure{TaskID: id, Reason: fmt.Sprintf("subtasks move with their parent; select task %s", topLevelID(task.ID))})
+		}
+	}
+	if len(moving) == 0 {
+		return result, nil
+	}
+
+	// Renumber the moved trees and note every old ID that leaves the tag.
+	nextID := 1
+	for _, task := range targetTasks.Tasks {
+		if n, err := strconv.Atoi(task.ID); err == nil && n >= nextID {
+			nextID = n + 1
+		}
+	}
+	now := Now()
+	leaving := make(map[string]string)
+	var remaining, moved []Task
+	for _, task := range working {
+		if !moving[task.ID] {
+			remaining = append(remaining, task)
+			continue
+		}
+		oldIDs := make([]string, 0)
+		for _, t := range flattenTasks([]Task{task}) {
+			oldIDs = append(oldIDs, t.ID)
+		}
+		oldID := task.ID
+		task.ID = strconv.Itoa(nextID)
+		nextID++
+		task.UpdatedAt = now
+		remap := renumberSubtasks(&task)
+		remap[oldID] = task.ID
+		for _, id := range oldIDs {
+			if renamed, ok := remap[id]; ok {
+				leaving[id] = renamed
+			} else {
+				leaving[id] = id
+			}
+		}
+		if task.ID != oldID {
+			result.Notes = append(result.Notes, fmt.Sprintf("Task %s is task %s in %s", oldID, task.ID, target))
+		}
+		result.Changed = append(result.Changed, oldID)
+		moved = append(moved, task)
+	}
+
+	for _, task := range flattenTasks(moved) {
+		deps := task.Dependencies[:0]
+		for _, dep := range task.Dependencies {
+			renamed, ok := leaving[dep]
+			if !ok {
+				result.Notes = append(result.Notes, fmt.Sprintf("Dropped dependency of %s on %s, which stays in %s", task.ID, dep, source))
+				continue
+			}
+			deps = append(deps, renamed)
+		}
+		task.Dependencies = deps
+	}
+	for _, task := range flattenTasks(remaining) {
+		deps := task.Dependencies[:0]
+		for _, dep := range task.Dependencies {
+			if _, ok := leaving[dep]; ok {
+				result.Notes = append(result.Notes, fmt.Sprintf("Dropped dependency of %s on %s, which moved to %s", task.ID, dep, target))
+				continue
+			}
+			deps = append(deps, dep)
+		}
+		task.Dependencies = deps
+	}
+	if remaining == nil {
+		remaining = []Task{}
+	}
+
+	for tag, tasks := range map[string][]Task{
+		source: remaining,
+		target: append(targetTasks.Tasks, moved...),
+	} {
+		entry := map[string]interface{}{}
+		_ = json.Unmarshal(raw[tag], &entry)
+		entry["tasks"] = tasks
+		updated, err := json.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tag entry: %w", err)
+		}
+		raw[tag] = updated
+	}
+	final, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tasks file: %w", err)
+	}
+	if err := writeFileAtomic(path, final, 0644); err != nil {
+		return nil, err
+	}
+
+	s.Tasks = remaining
+	s.rebuildIndexAndValidate()
+	s.noteTasksFileLocked(final)
+	s.recordHistoryLocked(newUndoAction(UndoActionEdit, op.Describe(len(result.Changed))), before)
+	return result, nil
+}
+
+// expandTasks runs `task-master expand` for each task and records the
+// subtasks they gained as one undo entry. A task that fails to expand does
+// not stop the others.
+func (s *Service) expandTasks(ctx context.Context, ids []string, op BulkOperation) (*BulkResult, error) {
+	if !s.available {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+
+	before := s.tasksFileSnapshot()
+	result := &BulkResult{}
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			result.Failed = append(result.Failed, BulkFailure{TaskID: id, Reason: "cancelled"})
+			continue
+		}
+		args := []string{"expand", fmt.Sprintf("--id=%s", id)}
+		if op.Expand.UseAI {
+			args = append(args, "--research")
+		}
+		if op.Expand.NumSubtasks > 0 {
+			args = append(args, fmt.Sprintf("--num=%d", op.Expand.NumSubtasks))
+		}
+		if op.Expand.Force {
+			args = append(args, "--force")
+		}
+		if _, err := s.runSimpleTaskMasterCommand(ctx, args...); err != nil {
+			result.Failed = append(result.Failed, BulkFailure{TaskID: id, Reason: commandFailureReason(err)})
+			continue
+		}
+		result.Changed = append(result.Changed, id)
+	}
+	if len(result.Changed) == 0 {
+		return result, nil
+	}
+
+	s.recordCommandChange(newUndoAction(UndoActionExpand, op.Describe(len(result.Changed))), before)
+	reloadCtx := context.WithValue(context.Background(), "force", true)
+	if err := s.LoadTasks(reloadCtx); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// TagNames lists the tag contexts in tasks.json without calling the CLI.
+func (s *Service) TagNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.availableTagNames()
+}
+
+// topLevelID returns the ID of the top-level task id belongs to.
+func topLevelID(id string) string {
+	top, _, _ := strings.Cut(id, ".")
+	return top
+}
+
+// commandFailureReason keeps the last line of a CLI failure, which is
+// usually the message task-master printed.
+func commandFailureReason(err error) string {
+	lines := strings.Split(strings.TrimSpace(err.Error()), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+func pluralTasks(count int) string {
+	if count == 1 {
+		return "1 task"
+	}
+	return fmt.Sprintf("%d tasks", count)
+}
@@ -0,0 +1,146 @@
+package taskmaster
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/config"
+)
+
+func TestApplyBulkIsOneUndoableChange(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	result, err := svc.ApplyBulk(ctx, []string{"3", "1", "2.1", "9"}, BulkOperation{Action: BulkSetStatus, Value: StatusInProgress})
+	if err != nil {
+		t.Fatalf("ApplyBulk returned error: %v", err)
+	}
+	if !equalIDs(result.Changed, []string{"1", "2.1", "3"}) {
+		t.Fatalf("expected 1, 2.1 and 3 changed, got %v", result.Changed)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].TaskID != "9" {
+		t.Fatalf("expected missing task 9 reported, got %+v", result.Failed)
+	}
+
+	history := svc.UndoHistory()
+	if len(history.Actions) != 1 || history.Actions[0].Summary != "Set 3 tasks to in-progress" {
+		t.Fatalf("expected a single undo entry, got %+v", history.Actions)
+	}
+	if _, err := svc.Undo(ctx); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	for id, want := range map[string]string{"1": StatusDone, "2.1": StatusPending, "3": StatusPending} {
+		if got := taskStatus(t, svc, id); got != want {
+			t.Fatalf("expected task %s restored to %s, got %s", id, want, got)
+		}
+	}
+}
+
+func TestApplyBulkReportsPerTaskFailures(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	// 2 already depends on 1, and 1 cannot depend on itself.
+	result, err := svc.ApplyBulk(ctx, []string{"1", "2", "3"}, BulkOperation{Action: BulkAddDependency, Value: "1"})
+	if err != nil {
+		t.Fatalf("ApplyBulk returned error: %v", err)
+	}
+	if !equalIDs(result.Changed, []string{"3"}) || !equalIDs(result.Unchanged, []string{"2"}) {
+		t.Fatalf("expected only 3 changed and 2 unchanged, got %+v", result)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].TaskID != "1" {
+		t.Fatalf("expected self-dependency on 1 to fail, got %+v", result.Failed)
+	}
+
+	if _, err := svc.ApplyBulk(ctx, []string{"1", "3"}, BulkOperation{Action: BulkAddTag, Value: "api"}); err != nil {
+		t.Fatalf("ApplyBulk add-tag returned error: %v", err)
+	}
+	result, err = svc.ApplyBulk(ctx, []string{"1", "2"}, BulkOperation{Action: BulkRemoveTag, Value: "api"})
+	if err != nil {
+		t.Fatalf("ApplyBulk remove-tag returned error: %v", err)
+	}
+	if !equalIDs(result.Changed, []string{"1"}) || !equalIDs(result.Unchanged, []string{"2"}) {
+		t.Fatalf("expected tag removed from 1 only, got %+v", result)
+	}
+	if task, _ := svc.GetTaskByID("3"); len(task.Tags) != 1 || task.Tags[0] != "api" {
+		t.Fatalf("expected task 3 to keep its tag, got %v", task.Tags)
+	}
+
+	if _, err := svc.ApplyBulk(ctx, []string{"1"}, BulkOperation{Action: BulkSetPriority, Value: "urgent"}); err == nil {
+		t.Fatalf("expected invalid priority to be rejected")
+	}
+	if got := len(svc.UndoHistory().Actions); got != 3 {
+		t.Fatalf("expected 3 undo entries, got %d", got)
+	}
+}
+
+func TestApplyBulkMovesTasksToTag(t *testing.T) {
+	tmpDir := t.TempDir()
+	tasksDir := filepath.Join(tmpDir, ".taskmaster", "tasks")
+	if err := os.MkdirAll(tasksDir, 0755); err != nil {
+		t.Fatalf("failed to create tasks dir: %v", err)
+	}
+	payload := map[string]interface{}{
+		"master":  map[string]interface{}{"tasks": mutateFixture()},
+		"feature": map[string]interface{}{"tasks": []Task{{ID: "1", Title: "Other", Status: StatusPending}}},
+	}
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal tasks: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tasksDir, "tasks.json"), data, 0644); err != nil {
+		t.Fatalf("failed to write tasks: %v", err)
+	}
+	svc, err := NewService(&config.Config{TaskMasterPath: tmpDir})
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := svc.ApplyBulk(ctx, []string{"2", "2.1", "3.1"}, BulkOperation{Action: BulkMoveToTag, Value: "feature"})
+	if err != nil {
+		t.Fatalf("ApplyBulk returned error: %v", err)
+	}
+	if !equalIDs(result.Changed, []string{"2"}) || len(result.Failed) != 1 || result.Failed[0].TaskID != "3.1" {
+		t.Fatalf("expected 2 moved and unknown 3.1 failed, got %+v", result)
+	}
+
+	if _, ok := svc.GetTaskByID("2"); ok {
+		t.Fatalf("expected task 2 to leave the active tag")
+	}
+	ship, _ := svc.GetTaskByID("3")
+	if len(ship.Dependencies) != 0 {
+		t.Fatalf("expected dependencies on moved tasks dropped, got %v", ship.Dependencies)
+	}
+
+	feature, err := LoadTasksFromFile(tmpDir, "feature")
+	if err != nil {
+		t.Fatalf("failed to load feature tag: %v", err)
+	}
+	if len(feature) != 2 || feature[1].ID != "2" || feature[1].Title != "Build" {
+		t.Fatalf("expected Build appended to feature as task 2, got %+v", feature)
+	}
+	moved := feature[1]
+	if len(moved.Dependencies) != 0 {
+		t.Fatalf("expected dependency on Setup dropped, got %v", moved.Dependencies)
+	}
+	if len(moved.Subtasks) != 3 || moved.Subtasks[2].Dependencies[0] != "2.2" {
+		t.Fatalf("expected subtasks to move with their dependencies, got %+v", moved.Subtasks)
+	}
+
+	if got := svc.UndoHistory().Actions; len(got) != 1 || got[0].Summary != "Moved 1 task to tag feature" {
+		t.Fatalf("expected one undo entry for the move, got %+v", got)
+	}
+	if _, err := svc.Undo(ctx); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if _, ok := svc.GetTaskByID("2.3"); !ok {
+		t.Fatalf("expected undo to bring task 2 back")
+	}
+	if feature, _ := LoadTasksFromFile(tmpDir, "feature"); len(feature) != 1 {
+		t.Fatalf("expected undo to restore the feature tag, got %d tasks", len(feature))
+	}
+}
@@ -23,19 +23,7 @@ func (s *Service) ExecuteCommand(args ...string) (string, error) {
 	return stdout.String(), nil
 }
 
-// SetTaskStatus sets the status of a task
-func (s *Service) SetTaskStatus(taskID, status string) error {
-	_, err := s.ExecuteCommand("set-status", fmt.Sprintf("--id=%s", taskID), fmt.Sprintf("--status=%s", status))
-	if err != nil {
-		return err
-	}
-
-	// Reload tasks after status change
-	ctx := context.WithValue(context.Background(), "force", true)
-	return s.LoadTasks(ctx)
-}
-
-// ExpandTask expands a task into subtasks
+// ExpandTask expands a task into subtasks via the AI-backed task-master CLI
 func (s *Service) ExpandTask(taskID string, research bool) error {
 	args := []string{"expand", fmt.Sprintf("--id=%s", taskID)}
 	if research {
@@ -52,7 +40,8 @@ func (s *Service) ExpandTask(taskID string, research bool) error {
 	return s.LoadTasks(ctx)
 }
 
-// AddTask adds a new task
+// AddTask adds a new task from a prompt via the AI-backed task-master CLI.
+// Use the native mutation methods in mutate.go for deterministic edits.
 func (s *Service) AddTask(prompt string, research bool) error {
 	args := []string{"add-task", fmt.Sprintf("--prompt=%s", prompt)}
 	if research {
@@ -69,7 +58,8 @@ func (s *Service) AddTask(prompt string, research bool) error {
 	return s.LoadTasks(ctx)
 }
 
-// UpdateTask updates an existing task
+// UpdateTask rewrites an existing task from a prompt via the AI-backed
+// task-master CLI. Use UpdateTaskFields for direct field edits.
 func (s *Service) UpdateTask(taskID, prompt string) error {
 	_, err := s.ExecuteCommand("update-task", fmt.Sprintf("--id=%s", taskID), fmt.Sprintf("--prompt=%s", prompt))
 	if err != nil {
@@ -31,6 +31,8 @@ type ComplexityReport struct {
 	AnalyzedAt   time.Time        `json:"analyzedAt"`
 	Scope        string           `json:"scope"` // "all", "selected", "tag:X"
 	FilteredTags []string         `json:"filteredTags,omitempty"`
+	Tag          string           `json:"tag,omitempty"`     // Active tag the analysis ran against
+	Scoring      *ScoringProfile  `json:"scoring,omitempty"` // Weights that produced the scores
 }
 
 // GetColorForLevel returns the appropriate color identifier for the complexity level
@@ -71,14 +73,18 @@ func GetLevelFromScore(score int, thresholds *LevelThresholds) ComplexityLevel {
 
 // AnalyzeComplexity calculates complexity scores for a set of tasks
 func AnalyzeComplexity(tasks []*Task) []TaskComplexity {
-	result := make([]TaskComplexity, 0, len(tasks))
+	profile := DefaultScoringProfile()
+	return AnalyzeComplexityWithProfile(tasks, &profile)
+}
 
-	// Use default weights and thresholds
-	weights := DefaultScoringWeights()
+// AnalyzeComplexityWithProfile calculates complexity scores for a set of
+// tasks using the given scoring profile and the default thresholds
+func AnalyzeComplexityWithProfile(tasks []*Task, profile *ScoringProfile) []TaskComplexity {
+	result := make([]TaskComplexity, 0, len(tasks))
 	thresholds := DefaultLevelThresholds()
 
 	for _, task := range tasks {
-		complexity := CalculateComplexityScore(task, &weights, &thresholds)
+		complexity := CalculateComplexityScoreWithProfile(task, profile, &thresholds)
 		result = append(result, complexity)
 	}
 
@@ -158,6 +164,8 @@ func (report *ComplexityReport) FilterByLevel(levels []ComplexityLevel) *Complex
 		AnalyzedAt:   report.AnalyzedAt,
 		Scope:        report.Scope,
 		FilteredTags: report.FilteredTags,
+		Tag:          report.Tag,
+		Scoring:      report.Scoring,
 	}
 
 	return filtered
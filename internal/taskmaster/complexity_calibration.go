@@ -0,0 +1,365 @@
+package taskmaster
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/config"
+)
+
+// MinCalibrationSamples is the number of completed tasks with recorded
+// actual hours needed before weights can be calibrated.
+const MinCalibrationSamples = 5
+
+// ErrInsufficientCalibrationData is returned when too few completed tasks
+// record ActualHours to fit weights.
+var ErrInsufficientCalibrationData = errors.New("not enough completed tasks with actual hours to calibrate")
+
+// calibrationFeatures names the weights fitted by calibration, in the order
+// of the feature vector built by calibrationFeatureVector. PriorityFactor is
+// not fitted and is carried over from the current profile.
+var calibrationFeatures = []string{
+	config.WeightDescriptionLength,
+	config.WeightSubtaskCount,
+	config.WeightDependencyCount,
+	config.WeightComplexityKeywords,
+	config.WeightEstimatedHours,
+}
+
+// CalibrationResult describes weights fitted to completed tasks' actual
+// effort and how well they explain it.
+type CalibrationResult struct {
+	Tag        string `json:"tag"`
+	SampleSize int    `json:"sampleSize"`
+
+	// Weights are the calibrated weights, scaled so the sample's average
+	// score matches the current weights. Weights for features that never
+	// occur in the sample keep their current value.
+	Weights        ScoringWeights `json:"weights"`
+	CurrentWeights ScoringWeights `json:"currentWeights"`
+	Fitted         []string       `json:"fitted"`
+
+	// RSquared is the share of variance in actual hours explained by the
+	// calibrated weights; BaselineRSquared is the same for current scores.
+	RSquared         float64 `json:"rSquared"`
+	BaselineRSquared float64 `json:"baselineRSquared"`
+	// RMSE is the root mean squared error of the fit, in hours.
+	RMSE float64 `json:"rmseHours"`
+
+	// Thresholds recalculated from every task's score under each set of weights.
+	CurrentThresholds    LevelThresholds `json:"currentThresholds"`
+	CalibratedThresholds LevelThresholds `json:"calibratedThresholds"`
+}
+
+// WeightMap returns the weights keyed by their config names.
+func (w ScoringWeights) WeightMap() map[string]float64 {
+	return map[string]float64{
+		config.WeightDescriptionLength:  w.DescriptionLength,
+		config.WeightSubtaskCount:       w.SubtaskCount,
+		config.WeightDependencyCount:    w.DependencyCount,
+		config.WeightComplexityKeywords: w.ComplexityKeywords,
+		config.WeightEstimatedHours:     w.EstimatedHours,
+		config.WeightPriorityFactor:     w.PriorityFactor,
+	}
+}
+
+// CalibrateWeights fits scoring weights to the ActualHours of completed
+// tasks with non-negative least squares. tasks should include subtasks;
+// only done tasks with ActualHours > 0 are used as samples, while every
+// task contributes to the threshold preview.
+func CalibrateWeights(tasks []*Task, profile ScoringProfile) (*CalibrationResult, error) {
+	var features [][]float64
+	var hours, baseline []float64
+	for _, task := range tasks {
+		if task.Status != StatusDone || task.ActualHours <= 0 {
+			continue
+		}
+		features = append(features, calibrationFeatureVector(task, profile.Keywords))
+		hours = append(hours, task.ActualHours)
+		baseline = append(baseline, float64(CalculateComplexityScoreWithProfile(task, &profile, nil).Score))
+	}
+	if len(hours) < MinCalibrationSamples {
+		return nil, fmt.Errorf("%w: found %d, need %d", ErrInsufficientCalibrationData, len(hours), MinCalibrationSamples)
+	}
+
+	// Only features that vary in the sample can be fitted
+	var active []int
+	for j := range calibrationFeatures {
+		for _, row := range features {
+			if row[j] != 0 {
+				active = append(active, j)
+				break
+			}
+		}
+	}
+	coefficients := fitNonNegative(features, hours, active)
+
+	current := profile.Weights.WeightMap()
+	fitted := make(map[string]float64, len(current))
+	for name, value := range current {
+		fitted[name] = value
+	}
+	result := &CalibrationResult{
+		Tag:            profile.Tag,
+		SampleSize:     len(hours),
+		CurrentWeights: profile.Weights,
+		Fitted:         []string{},
+	}
+
+	// Scale hours to points so calibrated scores stay comparable with the
+	// thresholds in use.
+	predicted := make([]float64, len(hours))
+	var sumPredicted, sumBaseline float64
+	for i, row := range features {
+		for _, j := range active {
+			predicted[i] += coefficients[j] * row[j]
+		}
+		sumPredicted += predicted[i]
+		sumBaseline += baseline[i]
+	}
+	scale := 1.0
+	if sumPredicted > 0 && sumBaseline > 0 {
+		scale = sumBaseline / sumPredicted
+	}
+	for _, j := range active {
+		name := calibrationFeatures[j]
+		fitted[name] = roundWeight(coefficients[j] * scale)
+		result.Fitted = append(result.Fitted, name)
+	}
+	result.Weights = weightsFromMap(fitted)
+
+	result.RSquared = rSquared(hours, predicted)
+	result.BaselineRSquared = correlation(baseline, hours) * correlation(baseline, hours)
+	var sse float64
+	for i := range hours {
+		sse += (hours[i] - predicted[i]) * (hours[i] - predicted[i])
+	}
+	result.RMSE = math.Sqrt(sse / float64(len(hours)))
+
+	calibrated := profile
+	calibrated.Weights = result.Weights
+	result.CurrentThresholds = RecalculateComplexityThresholds(AnalyzeComplexityWithProfile(tasks, &profile))
+	result.CalibratedThresholds = RecalculateComplexityThresholds(AnalyzeComplexityWithProfile(tasks, &calibrated))
+	return result, nil
+}
+
+// calibrationFeatureVector returns the unweighted inputs to a task's score
+// in calibrationFeatures order.
+func calibrationFeatureVector(task *Task, keywords []string) []float64 {
+	text := strings.ToLower(task.Description + " " + task.Details)
+	matches := 0
+	for _, keyword := range keywords {
+		if strings.Contains(text, strings.ToLower(keyword)) {
+			matches++
+		}
+	}
+	return []float64{
+		float64(len(task.Description) / 80),
+		float64(len(task.Subtasks)),
+		float64(len(task.Dependencies)),
+		float64(matches),
+		task.EstimatedHours,
+	}
+}
+
+// fitNonNegative solves least squares over the active columns, repeatedly
+// dropping the most negative coefficient until all are non-negative.
+func fitNonNegative(x [][]float64, y []float64, active []int) []float64 {
+	coefficients := make([]float64, len(calibrationFeatures))
+	for len(active) > 0 {
+		solution := solveLeastSquares(x, y, active)
+		worst := -1
+		for k, value := range solution {
+			if value < 0 && (worst < 0 || value < solution[worst]) {
+				worst = k
+			}
+		}
+		if worst < 0 {
+			for k, j := range active {
+				coefficients[j] = solution[k]
+			}
+			return coefficients
+		}
+		active = append(active[:worst:worst], active[worst+1:]...)
+	}
+	return coefficients
+}
+
+// solveLeastSquares solves the normal equations for the given columns by
+// Gaussian elimination. Columns that are linear combinations of earlier
+// ones get a zero coefficient.
+func solveLeastSquares(x [][]float64, y []float64, columns []int) []float64 {
+	n := len(columns)
+	a := make([][]float64, n)
+	for r := range a {
+		a[r] = make([]float64, n+1)
+		for c := 0; c < n; c++ {
+			for i := range x {
+				a[r][c] += x[i][columns[r]] * x[i][columns[c]]
+			}
+		}
+		for i := range x {
+			a[r][n] += x[i][columns[r]] * y[i]
+		}
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-9 {
+			continue
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for r := 0; r < n; r++ {
+			if r == col {
+				continue
+			}
+			factor := a[r][col] / a[col][col]
+			for c := col; c <= n; c++ {
+				a[r][c] -= factor * a[col][c]
+			}
+		}
+	}
+
+	solution := make([]float64, n)
+	for r := 0; r < n; r++ {
+		if math.Abs(a[r][r]) >= 1e-9 {
+			solution[r] = a[r][n] / a[r][r]
+		}
+	}
+	return solution
+}
+
+func rSquared(actual, predicted []float64) float64 {
+	var mean float64
+	for _, value := range actual {
+		mean += value
+	}
+	mean /= float64(len(actual))
+
+	var ssRes, ssTot float64
+	for i := range actual {
+		ssRes += (actual[i] - predicted[i]) * (actual[i] - predicted[i])
+		ssTot += (actual[i] - mean) * (actual[i] - mean)
+	}
+	if ssTot == 0 {
+		return 0
+	}
+	return 1 - ssRes/ssTot
+}
+
+// correlation returns the Pearson correlation of a and b, or 0 when either
+// series is constant.
+func correlation(a, b []float64) float64 {
+	var meanA, meanB float64
+	for i := range a {
+		meanA += a[i]
+		meanB += b[i]
+	}
+	meanA /= float64(len(a))
+	meanB /= float64(len(b))
+
+	var cov, varA, varB float64
+	for i := range a {
+		cov += (a[i] - meanA) * (b[i] - meanB)
+		varA += (a[i] - meanA) * (a[i] - meanA)
+		varB += (b[i] - meanB) * (b[i] - meanB)
+	}
+	if varA == 0 || varB == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varA*varB)
+}
+
+func roundWeight(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func weightsFromMap(weights map[string]float64) ScoringWeights {
+	return ScoringWeights{
+		DescriptionLength:  weights[config.WeightDescriptionLength],
+		SubtaskCount:       weights[config.WeightSubtaskCount],
+		DependencyCount:    weights[config.WeightDependencyCount],
+		ComplexityKeywords: weights[config.WeightComplexityKeywords],
+		EstimatedHours:     weights[config.WeightEstimatedHours],
+		PriorityFactor:     weights[config.WeightPriorityFactor],
+	}
+}
+
+// CalibrateComplexity fits scoring weights for the active tag to the actual
+// hours recorded on completed tasks and subtasks.
+func (s *Service) CalibrateComplexity() (*CalibrationResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.available {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	profile := NewScoringProfile(s.complexityConfig, s.activeTag())
+	return CalibrateWeights(flattenTasks(s.Tasks), profile)
+}
+
+// SaveScoringWeights writes weights to the project config, either
+// project-wide or as an override for the active tag, and applies them to
+// later analyses.
+func (s *Service) SaveScoringWeights(weights ScoringWeights, forActiveTag bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return fmt.Errorf("taskmaster not available")
+	}
+	tag := ""
+	if forActiveTag {
+		tag = s.activeTag()
+	}
+	values := weights.WeightMap()
+	if err := config.SaveComplexityWeights(s.RootDir, tag, values); err != nil {
+		return err
+	}
+
+	// Apply immediately rather than waiting for the config watcher
+	updated := s.complexityConfig
+	updated.Weights = copyFloatMap(updated.Weights)
+	updated.Tags = make(map[string]config.ComplexityScoring, len(s.complexityConfig.Tags))
+	for name, override := range s.complexityConfig.Tags {
+		updated.Tags[name] = override
+	}
+	if tag == "" {
+		if updated.Weights == nil {
+			updated.Weights = make(map[string]float64)
+		}
+		for name, value := range values {
+			updated.Weights[name] = value
+		}
+	} else {
+		override := updated.Tags[tag]
+		override.Weights = copyFloatMap(override.Weights)
+		if override.Weights == nil {
+			override.Weights = make(map[string]float64)
+		}
+		for name, value := range values {
+			override.Weights[name] = value
+		}
+		updated.Tags[tag] = override
+	}
+	s.complexityConfig = updated
+	return nil
+}
+
+func copyFloatMap(values map[string]float64) map[string]float64 {
+	if values == nil {
+		return nil
+	}
+	copied := make(map[string]float64, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+	return copied
+}
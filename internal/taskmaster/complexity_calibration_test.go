@@ -0,0 +1,119 @@
+package taskmaster
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// calibrationFixture has actual hours of exactly 3h per subtask plus 2h per
+// dependency.
+func calibrationFixture() []Task {
+	subtasks := func(id string, n int) []Task {
+		var out []Task
+		for i := 1; i <= n; i++ {
+			out = append(out, Task{ID: id + "." + string(rune('0'+i)), Title: "Step", Status: StatusPending})
+		}
+		return out
+	}
+	return []Task{
+		{ID: "10", Title: "A", Status: StatusDone, ActualHours: 3, Subtasks: subtasks("10", 1)},
+		{ID: "11", Title: "B", Status: StatusDone, ActualHours: 8, Subtasks: subtasks("11", 2), Dependencies: []string{"10"}},
+		{ID: "12", Title: "C", Status: StatusDone, ActualHours: 4, Dependencies: []string{"10", "11"}},
+		{ID: "13", Title: "D", Status: StatusDone, ActualHours: 9, Subtasks: subtasks("13", 3)},
+		{ID: "14", Title: "E", Status: StatusDone, ActualHours: 9, Subtasks: subtasks("14", 1), Dependencies: []string{"10", "11", "12"}},
+		{ID: "15", Title: "Open", Status: StatusPending, Subtasks: subtasks("15", 2)},
+	}
+}
+
+func TestCalibrateComplexityFitsActualHours(t *testing.T) {
+	svc := setupDeleteService(t, calibrationFixture())
+
+	result, err := svc.CalibrateComplexity()
+	if err != nil {
+		t.Fatalf("CalibrateComplexity returned error: %v", err)
+	}
+	if result.SampleSize != 5 {
+		t.Fatalf("expected 5 samples, got %d", result.SampleSize)
+	}
+	if len(result.Fitted) != 2 || result.Fitted[0] != "subtaskCount" || result.Fitted[1] != "dependencyCount" {
+		t.Fatalf("expected only subtask and dependency weights fitted, got %v", result.Fitted)
+	}
+	if ratio := result.Weights.SubtaskCount / result.Weights.DependencyCount; math.Abs(ratio-1.5) > 0.02 {
+		t.Errorf("expected subtask/dependency weight ratio 1.5, got %.3f (%+v)", ratio, result.Weights)
+	}
+	if result.RSquared < 0.999 || result.RMSE > 0.01 {
+		t.Errorf("expected an exact fit, got R² %.3f RMSE %.3f", result.RSquared, result.RMSE)
+	}
+	if result.Weights.EstimatedHours != DefaultScoringWeights().EstimatedHours {
+		t.Errorf("expected weights without data to be kept, got %v", result.Weights.EstimatedHours)
+	}
+
+	if err := svc.SaveScoringWeights(result.Weights, false); err != nil {
+		t.Fatalf("SaveScoringWeights returned error: %v", err)
+	}
+	if got := svc.ScoringProfile().Weights; got != result.Weights {
+		t.Errorf("expected saved weights to apply immediately, got %+v", got)
+	}
+	data, err := os.ReadFile(filepath.Join(svc.RootDir, ".taskmaster", "config.json"))
+	if err != nil {
+		t.Fatalf("expected config.json to be written: %v", err)
+	}
+	var saved struct {
+		Complexity struct {
+			Weights map[string]float64 `json:"weights"`
+		} `json:"complexity"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil || saved.Complexity.Weights["subtaskCount"] != result.Weights.SubtaskCount {
+		t.Errorf("expected weights in config.json, got %s (%v)", data, err)
+	}
+}
+
+func TestCalibrateComplexityNeedsSamples(t *testing.T) {
+	svc := setupDeleteService(t, calibrationFixture()[:3])
+
+	_, err := svc.CalibrateComplexity()
+	if !errors.Is(err, ErrInsufficientCalibrationData) {
+		t.Fatalf("expected ErrInsufficientCalibrationData, got %v", err)
+	}
+}
+
+func TestCalibrateWeightsIgnoresTaskIDs(t *testing.T) {
+	// Actual hours are 3h per subtask, 2h per dependency and a quarter of
+	// the estimate
+	build := func(ids []string) []*Task {
+		step := []Task{{Title: "Step", Status: StatusPending}}
+		return []*Task{
+			{ID: ids[0], Title: "A", Status: StatusDone, ActualHours: 3, Subtasks: step},
+			{ID: ids[1], Title: "B", Status: StatusDone, ActualHours: 14, Subtasks: append(step, step...), Dependencies: []string{ids[0]}, EstimatedHours: 24},
+			{ID: ids[2], Title: "C", Status: StatusDone, ActualHours: 14, Dependencies: []string{ids[0], ids[1]}, EstimatedHours: 40},
+			{ID: ids[3], Title: "D", Status: StatusDone, ActualHours: 9, Subtasks: append(step, append(step, step...)...)},
+			{ID: ids[4], Title: "E", Status: StatusDone, ActualHours: 11, Subtasks: step, Dependencies: []string{ids[0], ids[1], ids[2]}, EstimatedHours: 8},
+		}
+	}
+
+	low, err := CalibrateWeights(build([]string{"2", "3", "4", "5", "6"}), DefaultScoringProfile())
+	if err != nil {
+		t.Fatalf("CalibrateWeights returned error: %v", err)
+	}
+	high, err := CalibrateWeights(build([]string{"20", "30", "40", "50", "60"}), DefaultScoringProfile())
+	if err != nil {
+		t.Fatalf("CalibrateWeights returned error: %v", err)
+	}
+	if !reflect.DeepEqual(low, high) {
+		t.Fatalf("expected the same calibration whatever the task IDs, got %+v and %+v", low, high)
+	}
+	if low.RSquared < 0.999 {
+		t.Errorf("expected an exact fit, got R² %.3f", low.RSquared)
+	}
+	if ratio := low.Weights.SubtaskCount / low.Weights.DependencyCount; math.Abs(ratio-1.5) > 0.02 {
+		t.Errorf("expected subtask/dependency weight ratio 1.5, got %.3f (%+v)", ratio, low.Weights)
+	}
+	if low.Weights.EstimatedHours == 0 {
+		t.Errorf("expected the estimate weight fitted, got %+v", low.Weights)
+	}
+}
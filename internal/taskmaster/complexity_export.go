@@ -0,0 +1,276 @@
+package taskmaster
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Complexity report export formats.
+const (
+	ExportFormatCSV      = "csv"
+	ExportFormatJSON     = "json"
+	ExportFormatMarkdown = "markdown"
+)
+
+// ComplexitySummary aggregates a complexity report for export headers.
+type ComplexitySummary struct {
+	TotalTasks   int                     `json:"totalTasks"`
+	AverageScore float64                 `json:"averageScore"`
+	MaxScore     int                     `json:"maxScore"`
+	LevelCounts  map[ComplexityLevel]int `json:"levelCounts"`
+}
+
+// Summarize computes level counts and score statistics for the report.
+func (report *ComplexityReport) Summarize() ComplexitySummary {
+	summary := ComplexitySummary{
+		TotalTasks: len(report.Tasks),
+		LevelCounts: map[ComplexityLevel]int{
+			ComplexityLow:      0,
+			ComplexityMedium:   0,
+			ComplexityHigh:     0,
+			ComplexityVeryHigh: 0,
+		},
+	}
+	total := 0
+	for _, task := range report.Tasks {
+		summary.LevelCounts[task.Level]++
+		total += task.Score
+		if task.Score > summary.MaxScore {
+			summary.MaxScore = task.Score
+		}
+	}
+	if len(report.Tasks) > 0 {
+		summary.AverageScore = float64(total) / float64(len(report.Tasks))
+	}
+	return summary
+}
+
+// ExportExtension returns the file extension used for an export format.
+func ExportExtension(format string) string {
+	if format == ExportFormatMarkdown {
+		return "md"
+	}
+	return format
+}
+
+// complexityReportsDir returns the directory holding persisted reports.
+func (s *Service) complexityReportsDir() string {
+	return filepath.Join(s.RootDir, ".taskmaster", "reports")
+}
+
+// complexityReportPath returns where the latest report for tag is persisted.
+// The name is distinct from task-master's own task-complexity-report.json,
+// which uses a different schema.
+func (s *Service) complexityReportPath(tag string) string {
+	return filepath.Join(s.complexityReportsDir(), fmt.Sprintf("tui-complexity-%s.json", tag))
+}
+
+// activeTag returns the configured tag, defaulting to "master".
+func (s *Service) activeTag() string {
+	if s.config != nil && s.config.ActiveTag != "" {
+		return s.config.ActiveTag
+	}
+	return "master"
+}
+
+// storeComplexityReport caches report for its tag and persists it under
+// .taskmaster/reports, appending it to the tag's history. Persistence
+// failures are not fatal to the analysis.
+func (s *Service) storeComplexityReport(report *ComplexityReport) error {
+	s.reportsMu.Lock()
+	defer s.reportsMu.Unlock()
+	if s.latestReports == nil {
+		s.latestReports = make(map[string]*ComplexityReport)
+	}
+	s.latestReports[report.Tag] = report
+
+	if !s.available {
+		return nil
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal complexity report: %w", err)
+	}
+	if err := os.MkdirAll(s.complexityReportsDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create reports directory: %w", err)
+	}
+	if err := writeFileAtomic(s.complexityReportPath(report.Tag), data, 0644); err != nil {
+		return err
+	}
+	return s.appendComplexityHistory(report)
+}
+
+// GetLatestComplexityReport returns the most recent complexity report for the
+// active tag, loading it from .taskmaster/reports when not cached yet.
+// Returns nil if no analysis has been run for the tag.
+func (s *Service) GetLatestComplexityReport() *ComplexityReport {
+	tag := s.activeTag()
+
+	s.reportsMu.Lock()
+	defer s.reportsMu.Unlock()
+	if report, ok := s.latestReports[tag]; ok {
+		return report
+	}
+	if !s.available {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.complexityReportPath(tag))
+	if err != nil {
+		return nil
+	}
+	var report ComplexityReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil
+	}
+	if s.latestReports == nil {
+		s.latestReports = make(map[string]*ComplexityReport)
+	}
+	s.latestReports[tag] = &report
+	return &report
+}
+
+// ExportComplexityReport writes the latest complexity report in the given
+// format (csv, json or markdown) and returns the absolute path written.
+// An empty outputPath writes a timestamped file under .taskmaster/reports;
+// a relative path is resolved against the project root, and an existing
+// directory receives a timestamped file.
+func (s *Service) ExportComplexityReport(ctx context.Context, format string, outputPath string) (string, error) {
+	report := s.GetLatestComplexityReport()
+	if report == nil {
+		return "", fmt.Errorf("no complexity report available for export")
+	}
+
+	var data []byte
+	var err error
+	switch format {
+	case ExportFormatCSV:
+		data, err = renderComplexityCSV(report)
+	case ExportFormatJSON:
+		data, err = renderComplexityJSON(report)
+	case ExportFormatMarkdown, "md":
+		format = ExportFormatMarkdown
+		data = renderComplexityMarkdown(report)
+	default:
+		return "", fmt.Errorf("unsupported export format: %s", format)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+
+	defaultName := fmt.Sprintf("complexity-report-%s.%s", time.Now().Format("20060102-150405"), ExportExtension(format))
+	path := s.resolveExportPath(defaultName, outputPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	if err := writeFileAtomic(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write export: %w", err)
+	}
+	return path, nil
+}
+
+// resolveExportPath resolves outputPath for an export, using defaultName
+// under .taskmaster/reports when it is empty or names a directory.
+func (s *Service) resolveExportPath(defaultName, outputPath string) string {
+	outputPath = strings.TrimSpace(outputPath)
+	if outputPath == "" {
+		return filepath.Join(s.complexityReportsDir(), defaultName)
+	}
+
+	path := outputPath
+	if strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.RootDir, path)
+	}
+	if info, err := os.Stat(path); (err == nil && info.IsDir()) || strings.HasSuffix(outputPath, string(filepath.Separator)) {
+		return filepath.Join(path, defaultName)
+	}
+	return path
+}
+
+func renderComplexityJSON(report *ComplexityReport) ([]byte, error) {
+	payload := struct {
+		Tag          string            `json:"tag,omitempty"`
+		Scope        string            `json:"scope"`
+		AnalyzedAt   time.Time         `json:"analyzedAt"`
+		FilteredTags []string          `json:"filteredTags,omitempty"`
+		Summary      ComplexitySummary `json:"summary"`
+		Tasks        []TaskComplexity  `json:"tasks"`
+	}{
+		Tag:          report.Tag,
+		Scope:        report.Scope,
+		AnalyzedAt:   report.AnalyzedAt,
+		FilteredTags: report.FilteredTags,
+		Summary:      report.Summarize(),
+		Tasks:        report.Tasks,
+	}
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal complexity report: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// renderComplexityCSV writes a header and one row per task. The summary is
+// left to the JSON and Markdown formats so the file stays plain CSV.
+func renderComplexityCSV(report *ComplexityReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"task_id", "title", "level", "score"})
+	for _, task := range report.Tasks {
+		w.Write([]string{task.TaskID, task.Title, string(task.Level), strconv.Itoa(task.Score)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func renderComplexityMarkdown(report *ComplexityReport) []byte {
+	var buf bytes.Buffer
+	summary := report.Summarize()
+
+	buf.WriteString("# Complexity Report\n\n")
+	fmt.Fprintf(&buf, "- **Tag:** %s\n", orUnknown(report.Tag))
+	fmt.Fprintf(&buf, "- **Scope:** %s\n", report.Scope)
+	fmt.Fprintf(&buf, "- **Analyzed:** %s\n", report.AnalyzedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&buf, "- **Tasks:** %d (average score %.1f, max %d)\n\n", summary.TotalTasks, summary.AverageScore, summary.MaxScore)
+
+	buf.WriteString("## Summary\n\n| Level | Tasks |\n|-------|------:|\n")
+	for _, level := range []ComplexityLevel{ComplexityLow, ComplexityMedium, ComplexityHigh, ComplexityVeryHigh} {
+		fmt.Fprintf(&buf, "| %s | %d |\n", level.String(), summary.LevelCounts[level])
+	}
+
+	buf.WriteString("\n## Tasks\n\n| ID | Title | Level | Score |\n|----|-------|-------|------:|\n")
+	for _, task := range report.Tasks {
+		title := strings.ReplaceAll(task.Title, "|", "\\|")
+		fmt.Fprintf(&buf, "| %s | %s | %s | %d |\n", task.TaskID, title, task.Level.String(), task.Score)
+	}
+	return buf.Bytes()
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,113 @@
+package taskmaster
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/config"
+)
+
+func complexityFixture() []Task {
+	return []Task{
+		{ID: "1", Title: "Small", Status: StatusPending, Description: "tiny"},
+		{ID: "2", Title: "Pipe | title", Status: StatusPending, Details: strings.Repeat("integrate the database and api ", 40),
+			Subtasks: []Task{{ID: "2.1", Title: "Child", Status: StatusPending}},
+		},
+	}
+}
+
+func TestAnalyzeComplexityCachesAndPersistsReport(t *testing.T) {
+	svc := setupDeleteService(t, complexityFixture())
+
+	if report := svc.GetLatestComplexityReport(); report != nil {
+		t.Fatalf("expected no report before analysis, got %+v", report)
+	}
+
+	report, err := svc.AnalyzeComplexity(context.Background(), "all", "", nil)
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity returned error: %v", err)
+	}
+	if report.Tag != "master" {
+		t.Fatalf("expected report tagged master, got %q", report.Tag)
+	}
+	if latest := svc.GetLatestComplexityReport(); latest != report {
+		t.Fatalf("expected cached report to be returned")
+	}
+
+	// A fresh service for the same project loads the persisted report
+	reopened, err := NewService(&config.Config{TaskMasterPath: svc.RootDir})
+	if err != nil {
+		t.Fatalf("failed to reopen service: %v", err)
+	}
+	latest := reopened.GetLatestComplexityReport()
+	if latest == nil || len(latest.Tasks) != len(report.Tasks) {
+		t.Fatalf("expected persisted report with %d tasks, got %+v", len(report.Tasks), latest)
+	}
+}
+
+func TestExportComplexityReportFormats(t *testing.T) {
+	svc := setupDeleteService(t, complexityFixture())
+	ctx := context.Background()
+
+	if _, err := svc.ExportComplexityReport(ctx, ExportFormatCSV, ""); err == nil {
+		t.Fatalf("expected export without a report to fail")
+	}
+	if _, err := svc.AnalyzeComplexity(ctx, "all", "", nil); err != nil {
+		t.Fatalf("AnalyzeComplexity returned error: %v", err)
+	}
+
+	path, err := svc.ExportComplexityReport(ctx, ExportFormatCSV, "")
+	if err != nil {
+		t.Fatalf("CSV export returned error: %v", err)
+	}
+	if filepath.Dir(path) != filepath.Join(svc.RootDir, ".taskmaster", "reports") || filepath.Ext(path) != ".csv" {
+		t.Fatalf("expected default CSV path under reports dir, got %s", path)
+	}
+	if blank, err := svc.ExportComplexityReport(ctx, ExportFormatCSV, "  "); err != nil || filepath.Dir(blank) != filepath.Dir(path) {
+		t.Fatalf("expected a blank path to default under the reports dir, got %s (%v)", blank, err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "task_id,title,level,score\n") || !strings.Contains(string(data), "2,Pipe | title,") {
+		t.Fatalf("unexpected CSV content:\n%s", data)
+	}
+
+	path, err = svc.ExportComplexityReport(ctx, ExportFormatJSON, filepath.Join("out", "report.json"))
+	if err != nil {
+		t.Fatalf("JSON export returned error: %v", err)
+	}
+	if path != filepath.Join(svc.RootDir, "out", "report.json") {
+		t.Fatalf("expected relative path resolved against project root, got %s", path)
+	}
+	data, _ = os.ReadFile(path)
+	var payload struct {
+		Summary ComplexitySummary `json:"summary"`
+		Tasks   []TaskComplexity  `json:"tasks"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("JSON export is invalid: %v", err)
+	}
+	if payload.Summary.TotalTasks != 2 || len(payload.Tasks) != 2 {
+		t.Fatalf("unexpected JSON summary: %+v", payload.Summary)
+	}
+
+	dir := t.TempDir()
+	path, err = svc.ExportComplexityReport(ctx, ExportFormatMarkdown, dir)
+	if err != nil {
+		t.Fatalf("Markdown export returned error: %v", err)
+	}
+	if filepath.Dir(path) != dir || filepath.Ext(path) != ".md" {
+		t.Fatalf("expected timestamped markdown file in %s, got %s", dir, path)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), "## Summary") || !strings.Contains(string(data), `Pipe \| title`) {
+		t.Fatalf("unexpected markdown content:\n%s", data)
+	}
+
+	if _, err := svc.ExportComplexityReport(ctx, "xml", ""); err == nil {
+		t.Fatalf("expected unsupported format to fail")
+	}
+}
@@ -0,0 +1,195 @@
+package taskmaster
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// maxComplexityHistory bounds how many analyses are kept per tag.
+const maxComplexityHistory = 200
+
+// ComplexitySnapshot is the level distribution of one historical analysis.
+type ComplexitySnapshot struct {
+	AnalyzedAt   time.Time               `json:"analyzedAt"`
+	Scope        string                  `json:"scope"`
+	TotalTasks   int                     `json:"totalTasks"`
+	AverageScore float64                 `json:"averageScore"`
+	LevelCounts  map[ComplexityLevel]int `json:"levelCounts"`
+}
+
+// ScoreChange describes how a task's score moved between its first and most
+// recent analysis.
+type ScoreChange struct {
+	TaskID string `json:"taskId"`
+	Title  string `json:"title"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Delta  int    `json:"delta"`
+}
+
+// ComplexityTrend summarises a tag's complexity history, oldest first.
+type ComplexityTrend struct {
+	Tag       string               `json:"tag"`
+	Snapshots []ComplexitySnapshot `json:"snapshots"`
+	Movers    []ScoreChange        `json:"movers"`
+	scores    map[string][]int
+}
+
+// NewComplexityTrend builds a trend from reports ordered oldest first.
+// Movers are sorted by the size of the score change, largest first, and
+// exclude tasks whose score did not change.
+func NewComplexityTrend(tag string, history []*ComplexityReport) *ComplexityTrend {
+	trend := &ComplexityTrend{
+		Tag:       tag,
+		Snapshots: make([]ComplexitySnapshot, 0, len(history)),
+		Movers:    []ScoreChange{},
+		scores:    make(map[string][]int),
+	}
+
+	first := make(map[string]TaskComplexity)
+	last := make(map[string]TaskComplexity)
+	for _, report := range history {
+		summary := report.Summarize()
+		trend.Snapshots = append(trend.Snapshots, ComplexitySnapshot{
+			AnalyzedAt:   report.AnalyzedAt,
+			Scope:        report.Scope,
+			TotalTasks:   summary.TotalTasks,
+			AverageScore: summary.AverageScore,
+			LevelCounts:  summary.LevelCounts,
+		})
+		for _, task := range report.Tasks {
+			if _, ok := first[task.TaskID]; !ok {
+				first[task.TaskID] = task
+			}
+			last[task.TaskID] = task
+			trend.scores[task.TaskID] = append(trend.scores[task.TaskID], task.Score)
+		}
+	}
+
+	for id, latest := range last {
+		delta := latest.Score - first[id].Score
+		if delta == 0 {
+			continue
+		}
+		trend.Movers = append(trend.Movers, ScoreChange{
+			TaskID: id,
+			Title:  latest.Title,
+			From:   first[id].Score,
+			To:     latest.Score,
+			Delta:  delta,
+		})
+	}
+	sort.Slice(trend.Movers, func(i, j int) bool {
+		a, b := abs(trend.Movers[i].Delta), abs(trend.Movers[j].Delta)
+		if a != b {
+			return a > b
+		}
+		return CompareTaskIDs(trend.Movers[i].TaskID, trend.Movers[j].TaskID) < 0
+	})
+	return trend
+}
+
+// TaskScores returns the scores recorded for a task across the history,
+// oldest first.
+func (t *ComplexityTrend) TaskScores(taskID string) []int {
+	if t == nil {
+		return nil
+	}
+	return t.scores[taskID]
+}
+
+// complexityHistoryPath returns the JSON Lines file holding every analysis
+// run for tag.
+func (s *Service) complexityHistoryPath(tag string) string {
+	return filepath.Join(s.complexityReportsDir(), fmt.Sprintf("tui-complexity-history-%s.jsonl", tag))
+}
+
+// appendComplexityHistory records report in the tag's history file, dropping
+// the oldest entries beyond maxComplexityHistory.
+func (s *Service) appendComplexityHistory(report *ComplexityReport) error {
+	history, err := s.ComplexityHistory(report.Tag)
+	if err != nil {
+		return err
+	}
+	history = append(history, report)
+	if len(history) > maxComplexityHistory {
+		history = history[len(history)-maxComplexityHistory:]
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range history {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to marshal complexity history: %w", err)
+		}
+	}
+	return writeFileAtomic(s.complexityHistoryPath(report.Tag), buf.Bytes(), 0644)
+}
+
+// ComplexityHistory returns every persisted complexity analysis for tag,
+// oldest first. An empty tag selects the active tag. Returns an empty slice
+// when no analysis has been recorded.
+func (s *Service) ComplexityHistory(tag string) ([]*ComplexityReport, error) {
+	if tag == "" {
+		tag = s.activeTag()
+	}
+	if !s.available {
+		return nil, nil
+	}
+
+	file, err := os.Open(s.complexityHistoryPath(tag))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open complexity history: %w", err)
+	}
+	defer file.Close()
+
+	var history []*ComplexityReport
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var report ComplexityReport
+		if err := json.Unmarshal(line, &report); err != nil {
+			// Skip corrupt lines rather than losing the whole history
+			continue
+		}
+		history = append(history, &report)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read complexity history: %w", err)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].AnalyzedAt.Before(history[j].AnalyzedAt)
+	})
+	return history, nil
+}
+
+// ComplexityTrend builds the trend for the active tag from its history.
+func (s *Service) ComplexityTrend() (*ComplexityTrend, error) {
+	tag := s.activeTag()
+	history, err := s.ComplexityHistory(tag)
+	if err != nil {
+		return nil, err
+	}
+	return NewComplexityTrend(tag, history), nil
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
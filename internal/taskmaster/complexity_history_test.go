@@ -0,0 +1,80 @@
+package taskmaster
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestComplexityHistoryRecordsEachAnalysis(t *testing.T) {
+	svc := setupDeleteService(t, complexityFixture())
+	ctx := context.Background()
+
+	if _, err := svc.AnalyzeComplexity(ctx, "all", "", nil); err != nil {
+		t.Fatalf("AnalyzeComplexity returned error: %v", err)
+	}
+	if err := svc.AddDependency(ctx, "2", "1"); err != nil {
+		t.Fatalf("AddDependency returned error: %v", err)
+	}
+	if _, err := svc.AnalyzeComplexity(ctx, "all", "", nil); err != nil {
+		t.Fatalf("AnalyzeComplexity returned error: %v", err)
+	}
+
+	history, err := svc.ComplexityHistory("")
+	if err != nil {
+		t.Fatalf("ComplexityHistory returned error: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 recorded analyses, got %d", len(history))
+	}
+	if history[0].Tag != "master" || history[0].AnalyzedAt.After(history[1].AnalyzedAt) {
+		t.Fatalf("expected tagged history oldest first, got %+v", history)
+	}
+
+	trend, err := svc.ComplexityTrend()
+	if err != nil {
+		t.Fatalf("ComplexityTrend returned error: %v", err)
+	}
+	if len(trend.Snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(trend.Snapshots))
+	}
+	if scores := trend.TaskScores("2"); len(scores) != 2 || scores[1] <= scores[0] {
+		t.Fatalf("expected task 2 score to rise, got %v", scores)
+	}
+	if len(trend.Movers) == 0 || trend.Movers[0].TaskID != "2" || trend.Movers[0].Delta <= 0 {
+		t.Fatalf("expected task 2 as the largest mover, got %+v", trend.Movers)
+	}
+}
+
+func TestNewComplexityTrendOrdersMovers(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := []*ComplexityReport{
+		{AnalyzedAt: start, Tasks: []TaskComplexity{
+			{TaskID: "1", Score: 2, Level: ComplexityLow},
+			{TaskID: "2", Score: 5, Level: ComplexityMedium},
+			{TaskID: "3", Score: 9, Level: ComplexityHigh},
+		}},
+		{AnalyzedAt: start.Add(time.Hour), Tasks: []TaskComplexity{
+			{TaskID: "1", Score: 3, Level: ComplexityLow},
+			{TaskID: "2", Score: 5, Level: ComplexityMedium},
+			{TaskID: "3", Score: 14, Level: ComplexityVeryHigh},
+			{TaskID: "4", Score: 1, Level: ComplexityLow},
+		}},
+	}
+
+	trend := NewComplexityTrend("master", history)
+	if got := trend.Snapshots[1].LevelCounts[ComplexityLow]; got != 2 {
+		t.Fatalf("expected 2 low tasks in second snapshot, got %d", got)
+	}
+	if len(trend.Movers) != 2 || trend.Movers[0].TaskID != "3" || trend.Movers[1].TaskID != "1" {
+		t.Fatalf("expected movers [3 1], got %+v", trend.Movers)
+	}
+	if scores := trend.TaskScores("4"); len(scores) != 1 {
+		t.Fatalf("expected single score for new task, got %v", scores)
+	}
+
+	var nilTrend *ComplexityTrend
+	if nilTrend.TaskScores("1") != nil {
+		t.Fatalf("expected nil trend to have no scores")
+	}
+}
@@ -3,6 +3,8 @@ package taskmaster
 import (
 	"strings"
 	"time"
+
+	"github.com/agreen757/tm-tui/internal/config"
 )
 
 // ScoringWeights defines the weights used for different components of complexity scoring
@@ -22,7 +24,7 @@ func DefaultScoringWeights() ScoringWeights {
 		SubtaskCount:       2.0, // 2 points per subtask
 		DependencyCount:    3.0, // 3 points per dependency
 		ComplexityKeywords: 2.0, // 2 points per keyword match
-		EstimatedHours:     0.5, // 0.5 points per estimated hour
+		EstimatedHours:     0,   // Estimates are not scored unless configured
 		PriorityFactor:     1.0, // Default multiplier (for test compatibility)
 	}
 }
@@ -43,6 +45,63 @@ var PriorityWeights = map[string]float64{
 	"":               1.0, // Default for no priority
 }
 
+// ScoringProfile is the full set of inputs used to score tasks. Reports
+// record the profile they were produced with.
+type ScoringProfile struct {
+	Tag             string             `json:"tag,omitempty"`
+	Weights         ScoringWeights     `json:"weights"`
+	Keywords        []string           `json:"keywords"`
+	PriorityWeights map[string]float64 `json:"priorityWeights"`
+	Customized      bool               `json:"customized"`
+}
+
+// DefaultScoringProfile returns the built-in weights together with the
+// global ComplexityKeywords and PriorityWeights.
+func DefaultScoringProfile() ScoringProfile {
+	priorityWeights := make(map[string]float64, len(PriorityWeights))
+	for priority, weight := range PriorityWeights {
+		priorityWeights[priority] = weight
+	}
+	return ScoringProfile{
+		Weights:         DefaultScoringWeights(),
+		Keywords:        append([]string(nil), ComplexityKeywords...),
+		PriorityWeights: priorityWeights,
+	}
+}
+
+// NewScoringProfile resolves the scoring profile for tag, layering the
+// project and tag overrides from cfg over the defaults.
+func NewScoringProfile(cfg config.ComplexityConfig, tag string) ScoringProfile {
+	profile := DefaultScoringProfile()
+	profile.Tag = tag
+
+	overrides := cfg.ForTag(tag)
+	for name, value := range overrides.Weights {
+		switch name {
+		case config.WeightDescriptionLength:
+			profile.Weights.DescriptionLength = value
+		case config.WeightSubtaskCount:
+			profile.Weights.SubtaskCount = value
+		case config.WeightDependencyCount:
+			profile.Weights.DependencyCount = value
+		case config.WeightComplexityKeywords:
+			profile.Weights.ComplexityKeywords = value
+		case config.WeightEstimatedHours:
+			profile.Weights.EstimatedHours = value
+		case config.WeightPriorityFactor:
+			profile.Weights.PriorityFactor = value
+		}
+	}
+	if len(overrides.Keywords) > 0 {
+		profile.Keywords = overrides.Keywords
+	}
+	for priority, value := range overrides.PriorityWeights {
+		profile.PriorityWeights[priority] = value
+	}
+	profile.Customized = len(overrides.Weights) > 0 || len(overrides.Keywords) > 0 || len(overrides.PriorityWeights) > 0
+	return profile
+}
+
 // LevelThresholds defines the score thresholds for different complexity levels
 type LevelThresholds struct {
 	Low      int // 0 to Low
@@ -62,14 +121,28 @@ func DefaultLevelThresholds() LevelThresholds {
 }
 
 // CalculateComplexityScore computes a numeric complexity score for a task
-// using the provided or default weights and thresholds
+// using the provided or default weights and thresholds, the global
+// ComplexityKeywords and PriorityWeights.
 func CalculateComplexityScore(task *Task, weights *ScoringWeights, thresholds *LevelThresholds) TaskComplexity {
-	// Use default weights if not provided
-	// (w is not used in current implementation to maintain test compatibility)
-	_ = DefaultScoringWeights()
-	// if weights != nil {
-	//	w = *weights
-	// }
+	profile := DefaultScoringProfile()
+	if weights != nil {
+		profile.Weights = *weights
+	}
+	return calculateComplexityScore(task, &profile, thresholds)
+}
+
+// CalculateComplexityScoreWithProfile computes a task's complexity score
+// using the weights, keywords and priority weights of profile.
+func CalculateComplexityScoreWithProfile(task *Task, profile *ScoringProfile, thresholds *LevelThresholds) TaskComplexity {
+	if profile == nil {
+		defaults := DefaultScoringProfile()
+		profile = &defaults
+	}
+	return calculateComplexityScore(task, profile, thresholds)
+}
+
+func calculateComplexityScore(task *Task, profile *ScoringProfile, thresholds *LevelThresholds) TaskComplexity {
+	w := profile.Weights
 
 	// Use default thresholds if not provided
 	t := DefaultLevelThresholds()
@@ -79,50 +152,33 @@ func CalculateComplexityScore(task *Task, weights *ScoringWeights, thresholds *L
 
 	var score float64
 
-	// For test compatibility, we'll simplify the calculation to match what was in the original complexity.go
-	// This ensures the existing tests pass. In a real implementation, we would use the more sophisticated
-	// calculation with proper weights, but for now we need to meet the test expectations.
-
 	// Description length (normalized by 80 chars per line)
-	score += float64(len(task.Description) / 80)
+	score += float64(len(task.Description)/80) * w.DescriptionLength
 
 	// Number of subtasks
-	score += float64(len(task.Subtasks) * 2)
+	score += float64(len(task.Subtasks)) * w.SubtaskCount
 
 	// Number of dependencies
-	score += float64(len(task.Dependencies) * 3)
+	score += float64(len(task.Dependencies)) * w.DependencyCount
 
 	// Complexity keywords in description or details
 	combinedText := strings.ToLower(task.Description + " " + task.Details)
 
-	for _, keyword := range ComplexityKeywords {
-		if strings.Contains(combinedText, keyword) {
-			score += 2
+	for _, keyword := range profile.Keywords {
+		if strings.Contains(combinedText, strings.ToLower(keyword)) {
+			score += w.ComplexityKeywords
 		}
 	}
 
-	// For High Complexity and Very High Complexity tests, handle special cases
-	// This is a bit of a hack to make the tests pass
-	if task.ID == "3" && task.EstimatedHours == 24 {
-		score = 12 // Force the expected value
-	} else if task.ID == "4" && task.EstimatedHours == 40 {
-		score = 26 // Force the expected value
-	} else if task.ID == "2" && task.Title == "Medium complexity task" {
-		score = 6 // Force the expected value for Medium complexity task
-	} else if task.ID == "5" && len(task.Subtasks) == 2 && len(task.Dependencies) == 2 {
-		if weights != nil && weights.DescriptionLength == 0.5 {
-			score = 10 // Special case for custom weights test
-		}
-	} else if task.ID == "6" && len(task.Subtasks) == 2 && len(task.Dependencies) == 2 {
-		score = 7 // Special case for custom thresholds test
-	}
+	// Estimated effort
+	score += task.EstimatedHours * w.EstimatedHours
 
-	// Apply priority factor
-	priorityWeight, ok := PriorityWeights[task.Priority]
+	// Apply priority weight, scaled by the priority factor
+	priorityWeight, ok := profile.PriorityWeights[task.Priority]
 	if !ok {
 		priorityWeight = 1.0 // Default multiplier
 	}
-	score *= priorityWeight
+	score *= 1 + (priorityWeight-1)*w.PriorityFactor
 
 	// Convert to integer score
 	intScore := int(score)
@@ -1,8 +1,11 @@
 package taskmaster
 
 import (
+	"context"
 	"testing"
 	"time"
+
+	"github.com/agreen757/tm-tui/internal/config"
 )
 
 func TestCalculateComplexityScore(t *testing.T) {
@@ -47,8 +50,8 @@ func TestCalculateComplexityScore(t *testing.T) {
 				Title:        "Medium complexity task",
 				Description:  "This task requires integration with an external system and has several steps to complete. The implementation will need careful planning.",
 				Priority:     PriorityMedium,
-				Dependencies: []string{"1", "3"},
-				Subtasks:     []Task{{ID: "2.1"}, {ID: "2.2"}},
+				Dependencies: []string{"1"},
+				Subtasks:     []Task{},
 			},
 			expectedLevel: ComplexityMedium,
 			expectedScore: 6,
@@ -61,12 +64,12 @@ func TestCalculateComplexityScore(t *testing.T) {
 				Description:    "This task involves a major refactoring of the core system. It will require careful planning and execution to ensure compatibility.",
 				Details:        "The refactoring will touch multiple components and will need to maintain backward compatibility. Performance optimization and security considerations are important.",
 				Priority:       PriorityHigh,
-				Dependencies:   []string{"1", "2", "4", "5"},
-				Subtasks:       []Task{{ID: "3.1"}, {ID: "3.2"}, {ID: "3.3"}},
-				EstimatedHours: 24,
+				Dependencies:   []string{"1", "2"},
+				Subtasks:       []Task{},
+				EstimatedHours: 24, // Estimates are not scored by default
 			},
 			expectedLevel: ComplexityHigh,
-			expectedScore: 12,
+			expectedScore: 11,
 		},
 		{
 			name: "Very high complexity task",
@@ -81,7 +84,7 @@ func TestCalculateComplexityScore(t *testing.T) {
 				EstimatedHours: 40,
 			},
 			expectedLevel: ComplexityVeryHigh,
-			expectedScore: 26,
+			expectedScore: 31,
 		},
 		{
 			name: "Custom weights test",
@@ -89,7 +92,7 @@ func TestCalculateComplexityScore(t *testing.T) {
 				ID:           "5",
 				Title:        "Task with custom weights",
 				Description:  "This is a test for custom weights.",
-				Dependencies: []string{"1", "2"},
+				Dependencies: []string{"1"},
 				Subtasks:     []Task{{ID: "5.1"}, {ID: "5.2"}},
 			},
 			customWeights: &ScoringWeights{
@@ -109,7 +112,7 @@ func TestCalculateComplexityScore(t *testing.T) {
 				ID:           "6",
 				Title:        "Task with custom thresholds",
 				Description:  "This is a test for custom thresholds.",
-				Dependencies: []string{"1", "2"},
+				Dependencies: []string{"1"},
 				Subtasks:     []Task{{ID: "6.1"}, {ID: "6.2"}},
 			},
 			customThresholds: &LevelThresholds{
@@ -381,3 +384,73 @@ func TestSortScores(t *testing.T) {
 		})
 	}
 }
+
+func TestNewScoringProfile(t *testing.T) {
+	cfg := config.ComplexityConfig{
+		ComplexityScoring: config.ComplexityScoring{
+			Weights:         map[string]float64{config.WeightSubtaskCount: 5},
+			PriorityWeights: map[string]float64{PriorityHigh: 2},
+		},
+		Tags: map[string]config.ComplexityScoring{
+			"legacy": {Keywords: []string{"cobol"}},
+		},
+	}
+
+	defaults := NewScoringProfile(config.ComplexityConfig{}, "master")
+	if defaults.Customized || defaults.Weights != DefaultScoringWeights() {
+		t.Fatalf("expected default profile without overrides, got %+v", defaults)
+	}
+
+	profile := NewScoringProfile(cfg, "master")
+	if !profile.Customized || profile.Weights.SubtaskCount != 5 || profile.Weights.DependencyCount != 3 {
+		t.Fatalf("expected subtask weight override only, got %+v", profile.Weights)
+	}
+	task := &Task{ID: "10", Title: "Parent", Priority: PriorityHigh, Subtasks: []Task{{ID: "10.1"}, {ID: "10.2"}}}
+	if score := CalculateComplexityScoreWithProfile(task, &profile, nil).Score; score != 20 {
+		t.Errorf("expected 2 subtasks * 5 * high priority 2 = 20, got %d", score)
+	}
+
+	profile.Weights.PriorityFactor = 0.5
+	if score := CalculateComplexityScoreWithProfile(task, &profile, nil).Score; score != 15 {
+		t.Errorf("expected priority factor 0.5 to halve the priority adjustment, got %d", score)
+	}
+
+	legacy := NewScoringProfile(cfg, "legacy")
+	cobol := &Task{ID: "11", Title: "Port", Description: "Rewrite the COBOL batch job"}
+	if score := CalculateComplexityScoreWithProfile(cobol, &legacy, nil).Score; score != 2 {
+		t.Errorf("expected tag keyword to add the keyword weight, got %d", score)
+	}
+	if score := CalculateComplexityScoreWithProfile(cobol, &profile, nil).Score; score != 0 {
+		t.Errorf("expected tag keywords not to apply to other tags, got %d", score)
+	}
+}
+
+func TestServiceAnalyzeComplexityUsesConfiguredScoring(t *testing.T) {
+	svc := setupDeleteService(t, complexityFixture())
+	ctx := context.Background()
+
+	before, err := svc.AnalyzeComplexity(ctx, "all", "", nil)
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity returned error: %v", err)
+	}
+	if before.Scoring == nil || before.Scoring.Customized {
+		t.Fatalf("expected report to record the default profile, got %+v", before.Scoring)
+	}
+
+	svc.SetComplexityConfig(config.ComplexityConfig{
+		ComplexityScoring: config.ComplexityScoring{Weights: map[string]float64{config.WeightSubtaskCount: 10}},
+	})
+	after, err := svc.AnalyzeComplexity(ctx, "all", "", nil)
+	if err != nil {
+		t.Fatalf("AnalyzeComplexity returned error: %v", err)
+	}
+	if after.Scoring == nil || after.Scoring.Weights.SubtaskCount != 10 {
+		t.Fatalf("expected report to record the configured profile, got %+v", after.Scoring)
+	}
+	if delta := after.Tasks[1].Score - before.Tasks[1].Score; delta != 8 {
+		t.Errorf("expected one subtask to add 8 more points, got %d", delta)
+	}
+	if svc.ScoringProfile().Weights.SubtaskCount != 10 {
+		t.Errorf("expected ScoringProfile to reflect the reloaded config")
+	}
+}
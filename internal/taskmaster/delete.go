@@ -185,35 +185,23 @@ func (s *Service) DeleteTasks(ctx context.Context, taskIDs []string, opts Delete
 		return nil, fmt.Errorf("no tasks deleted")
 	}
 
-	tasksPath := s.tasksFilePath()
-	_, err := os.ReadFile(tasksPath)
+	action := newDeleteUndoAction(len(deleteSet), 0)
+	recorded, err := s.commitTasksLocked(filterTasksBySet(s.Tasks, deleteSet), action)
 	if err != nil {
-		return nil, fmt.Errorf("failed to backup tasks file: %w", err)
-	}
-
-	s.Tasks = filterTasksBySet(s.Tasks, deleteSet)
-	s.rebuildIndexAndValidate()
-	if err := s.persistTasksLocked(s.Tasks); err != nil {
 		return nil, err
 	}
-	if info, err := os.Stat(tasksPath); err == nil {
-		s.lastModTime = info.ModTime()
-	}
 
 	result := &DeleteResult{
 		DeletedCount: len(deleteSet),
 		Warnings:     warnings,
-		Undo:         nil, // Undo is not currently supported via this interface
+	}
+	if recorded {
+		result.Undo = action.Token()
 	}
 
 	return result, nil
 }
 
-// UndoAction is a placeholder for undo support. Currently not implemented.
-func (s *Service) UndoAction(ctx context.Context, actionID string) error {
-	return fmt.Errorf("undo is not currently supported")
-}
-
 func (s *Service) buildDependencyMapLocked() map[string][]*Task {
 	depMap := make(map[string][]*Task)
 	for _, task := range s.TaskIndex {
@@ -280,10 +268,12 @@ func filterTasksBySet(tasks []Task, deleteSet map[string]struct{}) []Task {
 	return result
 }
 
-func (s *Service) persistTasksLocked(tasks []Task) error {
+// persistTasksLocked writes tasks into tasks.json, keeping other tags and
+// top-level keys, and returns the bytes written.
+func (s *Service) persistTasksLocked(tasks []Task) ([]byte, error) {
 	data, err := os.ReadFile(s.tasksFilePath())
 	if err != nil {
-		return fmt.Errorf("failed to read tasks file: %w", err)
+		return nil, fmt.Errorf("failed to read tasks file: %w", err)
 	}
 	var raw map[string]json.RawMessage
 	if err := json.Unmarshal(data, &raw); err == nil && len(raw) > 0 {
@@ -296,35 +286,71 @@ func (s *Service) persistTasksLocked(tasks []Task) error {
 			entry["tasks"] = tasks
 			updated, err := json.Marshal(entry)
 			if err != nil {
-				return fmt.Errorf("failed to marshal tag entry: %w", err)
+				return nil, fmt.Errorf("failed to marshal tag entry: %w", err)
 			}
 			raw[tag] = updated
 			final, err := json.MarshalIndent(raw, "", "  ")
 			if err != nil {
-				return fmt.Errorf("failed to marshal tasks file: %w", err)
+				return nil, fmt.Errorf("failed to marshal tasks file: %w", err)
 			}
-			return os.WriteFile(s.tasksFilePath(), final, 0644)
+			return final, writeFileAtomic(s.tasksFilePath(), final, 0644)
 		}
 
 		if _, ok := raw["tasks"]; ok {
 			payload, err := json.Marshal(tasks)
 			if err != nil {
-				return fmt.Errorf("failed to marshal tasks: %w", err)
+				return nil, fmt.Errorf("failed to marshal tasks: %w", err)
 			}
 			raw["tasks"] = payload
 			final, err := json.MarshalIndent(raw, "", "  ")
 			if err != nil {
-				return fmt.Errorf("failed to marshal tasks file: %w", err)
+				return nil, fmt.Errorf("failed to marshal tasks file: %w", err)
 			}
-			return os.WriteFile(s.tasksFilePath(), final, 0644)
+			return final, writeFileAtomic(s.tasksFilePath(), final, 0644)
 		}
 	}
 
 	final, err := json.MarshalIndent(tasks, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal tasks: %w", err)
+		return nil, fmt.Errorf("failed to marshal tasks: %w", err)
+	}
+	return final, writeFileAtomic(s.tasksFilePath(), final, 0644)
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and
+// renames it over path, so readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	cleanup := func() { _ = os.Remove(tmpName) }
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		cleanup()
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		cleanup()
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to replace %s: %w", filepath.Base(path), err)
 	}
-	return os.WriteFile(s.tasksFilePath(), final, 0644)
+	return nil
 }
 
 func detectTaskContainerKey(raw map[string]json.RawMessage, cfg *config.Config) string {
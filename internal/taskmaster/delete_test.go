@@ -73,24 +73,29 @@ func TestDeleteTasksRecursiveRemovesDependentsAndUndo(t *testing.T) {
 	if result.DeletedCount != 3 {
 		t.Fatalf("expected 3 deletions, got %d", result.DeletedCount)
 	}
-	// Note: Undo is not currently supported via CLI-based deletion
-	// if result.Undo == nil {
-	// 	t.Fatalf("expected undo token")
-	// }
+	if result.Undo == nil {
+		t.Fatalf("expected undo token")
+	}
 
 	tasks, _ := svc.GetTasks()
 	if len(tasks) != 1 || tasks[0].ID != "8" {
 		t.Fatalf("expected only safe task to remain, got %+v", tasks)
 	}
 
-	// Note: Undo testing skipped since CLI-based deletion doesn't support it
-	// if err := svc.UndoAction(ctx, result.Undo.ID); err != nil {
-	// 	t.Fatalf("UndoAction returned error: %v", err)
-	// }
-	// tasks, _ = svc.GetTasks()
-	// if !taskExists(tasks, "6") || !taskExists(tasks, "7") {
-	// 	t.Fatalf("expected tasks restored after undo, got %+v", tasks)
-	// }
+	if err := svc.UndoAction(ctx, result.Undo.ID); err != nil {
+		t.Fatalf("UndoAction returned error: %v", err)
+	}
+	tasks, _ = svc.GetTasks()
+	if !taskExists(tasks, "6") || !taskExists(tasks, "7") {
+		t.Fatalf("expected tasks restored after undo, got %+v", tasks)
+	}
+	reloaded, err := LoadTasksFromFile(svc.RootDir, "")
+	if err != nil || !taskExists(reloaded, "6.1") {
+		t.Fatalf("expected restored tasks persisted, got %+v (%v)", reloaded, err)
+	}
+	if err := svc.UndoAction(ctx, result.Undo.ID); err == nil {
+		t.Fatalf("expected second undo of the same action to fail")
+	}
 }
 
 func TestDeleteTasksNonRecursiveLeaf(t *testing.T) {
@@ -108,10 +113,9 @@ func TestDeleteTasksNonRecursiveLeaf(t *testing.T) {
 	if result.DeletedCount != 1 {
 		t.Fatalf("expected 1 deletion, got %d", result.DeletedCount)
 	}
-	// Note: Undo is not currently supported via CLI-based deletion
-	// if result.Undo == nil {
-	// 	t.Fatalf("expected undo token for leaf delete")
-	// }
+	if result.Undo == nil {
+		t.Fatalf("expected undo token for leaf delete")
+	}
 	tasks, _ := svc.GetTasks()
 	if len(tasks) != 1 || len(tasks[0].Subtasks) != 0 {
 		t.Fatalf("expected parent without subtasks, got %+v", tasks)
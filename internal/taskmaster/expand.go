@@ -18,9 +18,18 @@ type ExpandTaskOptions struct {
 
 // SubtaskDraft represents a proposed subtask hierarchy that can be previewed or edited before applying.
 type SubtaskDraft struct {
-	Title       string         `json:"title"`
-	Description string         `json:"description,omitempty"`
-	Children    []SubtaskDraft `json:"children,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	// Fields below are carried through editing for drafts parsed from a PRD;
+	// Section and Hash keep the section it was parsed from (see
+	// prd.MarkSections).
+	Status       string         `json:"status,omitempty"`
+	Priority     string         `json:"priority,omitempty"`
+	TestStrategy string         `json:"testStrategy,omitempty"`
+	DependsOn    []string       `json:"dependsOn,omitempty"`
+	Section      string         `json:"section,omitempty"`
+	Hash         string         `json:"hash,omitempty"`
+	Children     []SubtaskDraft `json:"children,omitempty"`
 }
 
 // ExpandProgressState reports progress updates during task expansion via CLI
@@ -299,12 +308,13 @@ func ApplySubtaskDrafts(parentTask *Task, drafts []SubtaskDraft) ([]string, erro
 	newSubtasks := make([]Task, 0, len(drafts))
 	newIDs := make([]string, 0, len(drafts))
 
-	// Generate subtask IDs based on parent ID
+	// Generate subtask IDs based on parent ID, after any existing subtasks
+	start := len(parentTask.Subtasks)
 	for i, draft := range drafts {
-		subtaskID := generateSubtaskID(parentTask.ID, i)
+		subtaskID := generateSubtaskID(parentTask.ID, start+i)
 		newIDs = append(newIDs, subtaskID)
 
-		subtask := draftToTask(draft, subtaskID, parentTask.ID, i)
+		subtask := draftToTask(draft, subtaskID, parentTask.ID, start+i)
 		newSubtasks = append(newSubtasks, subtask)
 	}
 
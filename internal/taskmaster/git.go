@@ -0,0 +1,126 @@
+package taskmaster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/agreen757/tm-tui/internal/git"
+)
+
+// Metadata keys recording the status changes made from git activity, so each
+// is made once and a task moved back by hand stays where it was put.
+const (
+	// MetadataGitStarted holds the commit that moved a task to in-progress.
+	MetadataGitStarted = "gitStarted"
+	// MetadataGitMerged holds the commit that merged a task's work and
+	// moved it to done.
+	MetadataGitMerged = "gitMerged"
+)
+
+// GitStatusChange is a status change made from git activity.
+type GitStatusChange struct {
+	TaskID string
+	Status string
+	// Commit is the commit that caused the change.
+	Commit git.Commit
+}
+
+// SwitchTaskBranch checks out the git branch of a task, creating it from
+// the current HEAD when the task has none. A new branch records the active
+// tag, which ApplyGitActivity is scoped to. It returns the branch and
+// whether it was created.
+func (s *Service) SwitchTaskBranch(ctx context.Context, taskID string) (string, bool, error) {
+	if s.repo == nil {
+		return "", false, fmt.Errorf("taskmaster not available")
+	}
+	task, ok := s.GetTaskByID(taskID)
+	if !ok {
+		return "", false, fmt.Errorf("task %s not found", taskID)
+	}
+	return s.repo.SwitchTaskBranch(ctx, task.ID, task.Title, s.activeTag())
+}
+
+// GitActivity scans the project's git history for task branches and the
+// commits and merges that reference tasks. It returns git.ErrNotRepository
+// when the project is not under git.
+func (s *Service) GitActivity(ctx context.Context) (*git.Activity, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	return s.repo.Scan(ctx, 0)
+}
+
+// ApplyGitActivity moves pending tasks with commits to in-progress and tasks
+// whose work was merged into the main branch to done, as one undoable
+// change. Each move is recorded in the task's metadata and not repeated.
+// Task IDs repeat across tags, so only tasks whose branch was created in
+// the active tag are moved. It returns the changes made, in task order;
+// nothing is written when there are none.
+func (s *Service) ApplyGitActivity(ctx context.Context, activity *git.Activity) ([]GitStatusChange, error) {
+	if activity == nil {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+
+	changes := planGitStatusChanges(s.TaskIndex, activity, s.activeTag())
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	summary := fmt.Sprintf("Updated %d tasks from git activity", len(changes))
+	if len(changes) == 1 {
+		summary = fmt.Sprintf("Set task %s to %s from git activity", changes[0].TaskID, changes[0].Status)
+	}
+	err := s.mutateTasksLocked(ctx, newUndoAction(UndoActionStatus, summary), func(tasks []Task, index map[string]*Task) ([]Task, error) {
+		now := Now()
+		for _, change := range changes {
+			task := index[change.TaskID]
+			if task.Metadata == nil {
+				task.Metadata = make(map[string]string)
+			}
+			if change.Status == StatusDone {
+				task.Metadata[MetadataGitMerged] = change.Commit.ShortHash()
+			} else {
+				task.Metadata[MetadataGitStarted] = change.Commit.ShortHash()
+			}
+			task.Status = change.Status
+			task.UpdatedAt = now
+		}
+		return tasks, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// planGitStatusChanges lists the status changes activity calls for in tag.
+// A task both started and merged since the last scan goes straight to done.
+func planGitStatusChanges(index map[string]*Task, activity *git.Activity, tag string) []GitStatusChange {
+	var changes []GitStatusChange
+	for id, task := range index {
+		if activity.BranchTag(id) != tag {
+			continue
+		}
+		if merge, ok := activity.MergedCommit(id); ok && task.Metadata[MetadataGitMerged] == "" &&
+			task.Status != StatusDone && task.Status != StatusCancelled {
+			changes = append(changes, GitStatusChange{TaskID: id, Status: StatusDone, Commit: merge})
+			continue
+		}
+		commits := activity.TaskCommits(id)
+		if len(commits) > 0 && task.Status == StatusPending && task.Metadata[MetadataGitStarted] == "" {
+			changes = append(changes, GitStatusChange{TaskID: id, Status: StatusInProgress, Commit: commits[len(commits)-1]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return CompareTaskIDs(changes[i].TaskID, changes[j].TaskID) < 0
+	})
+	return changes
+}
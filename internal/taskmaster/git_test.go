@@ -0,0 +1,56 @@
+package taskmaster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/git"
+)
+
+func TestApplyGitActivityMovesTasksOnce(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	first := git.Commit{Hash: "1111111aaaa", Subject: "task 2.1: start", TaskIDs: []string{"2.1"}}
+	second := git.Commit{Hash: "2222222bbbb", Subject: "task 2.1: finish", TaskIDs: []string{"2.1"}}
+	merge := git.Commit{Hash: "3333333cccc", Subject: "Merge branch 'task/3-ship'", Merge: true, TaskIDs: []string{"3"}}
+	// Task 2.2's branch belongs to another tag, where ID 2.2 is a different task
+	activity := &git.Activity{
+		BranchTags: map[string]string{"1": "master", "2.1": "master", "2.2": "feature-x", "3": "master"},
+		Commits: map[string][]git.Commit{
+			"1":   {{Hash: "4444444dddd", TaskIDs: []string{"1"}}},
+			"2.1": {second, first},
+			"2.2": {{Hash: "5555555eeee", TaskIDs: []string{"2.2"}}},
+			"3":   {merge},
+		},
+		Merged: map[string]git.Commit{"1": {Hash: "4444444dddd"}, "2.2": {Hash: "5555555eeee"}, "3": merge},
+	}
+
+	changes, err := svc.ApplyGitActivity(ctx, activity)
+	if err != nil {
+		t.Fatalf("ApplyGitActivity returned error: %v", err)
+	}
+	if len(changes) != 2 || changes[0].TaskID != "2.1" || changes[1].TaskID != "3" {
+		t.Fatalf("expected changes to 2.1 and 3 (1 is already done, 2.2 in another tag), got %+v", changes)
+	}
+	started, _ := svc.GetTaskByID("2.1")
+	if started.Status != StatusInProgress || started.Metadata[MetadataGitStarted] != "1111111" {
+		t.Fatalf("expected 2.1 in progress from its first commit, got %+v", started)
+	}
+	merged, _ := svc.GetTaskByID("3")
+	if merged.Status != StatusDone || merged.Metadata[MetadataGitMerged] != "3333333" {
+		t.Fatalf("expected 3 done from its merge, got %+v", merged)
+	}
+	if history := svc.UndoHistory(); len(history.Actions) == 0 || history.Actions[len(history.Actions)-1].Summary != "Updated 2 tasks from git activity" {
+		t.Fatalf("expected one undoable change, got %+v", history.Actions)
+	}
+
+	// Tasks moved back by hand are left alone on the next scan
+	if err := svc.SetTaskStatus("3", StatusInProgress); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+	changes, err = svc.ApplyGitActivity(ctx, activity)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("expected no further changes, got %+v err=%v", changes, err)
+	}
+}
@@ -0,0 +1,365 @@
+package taskmaster
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DependencyGraph is a directed graph over a task index. An edge dep -> task
+// means task depends on dep. Dependencies on IDs missing from the index are
+// ignored, matching how validation reports them as warnings only.
+type DependencyGraph struct {
+	nodes      map[string]*Task
+	ids        []string
+	deps       map[string][]string
+	dependents map[string][]string
+
+	// Weight returns the remaining effort of a task for critical path
+	// calculations. Defaults to TaskWeight.
+	Weight func(task *Task) float64
+}
+
+// CycleError is returned when an operation requires an acyclic graph.
+type CycleError struct {
+	TaskIDs []string // Tasks that could not be ordered
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("dependency cycle among tasks: %s", strings.Join(e.TaskIDs, ", "))
+}
+
+// NewDependencyGraph builds a dependency graph from a task index.
+func NewDependencyGraph(index map[string]*Task) *DependencyGraph {
+	g := &DependencyGraph{
+		nodes:      make(map[string]*Task, len(index)),
+		ids:        make([]string, 0, len(index)),
+		deps:       make(map[string][]string, len(index)),
+		dependents: make(map[string][]string, len(index)),
+		Weight:     TaskWeight,
+	}
+	for id, task := range index {
+		g.nodes[id] = task
+		g.ids = append(g.ids, id)
+	}
+	SortTaskIDs(g.ids)
+
+	for _, id := range g.ids {
+		seen := make(map[string]bool)
+		for _, depID := range g.nodes[id].Dependencies {
+			if _, ok := g.nodes[depID]; !ok || seen[depID] {
+				continue
+			}
+			seen[depID] = true
+			g.deps[id] = append(g.deps[id], depID)
+			g.dependents[depID] = append(g.dependents[depID], id)
+		}
+	}
+	return g
+}
+
+// TaskWeight estimates the remaining effort of a task. Completed and cancelled
+// tasks weigh nothing; otherwise EstimatedHours is used, falling back to the
+// complexity score and finally to one unit.
+func TaskWeight(task *Task) float64 {
+	if task == nil || task.Status == StatusDone || task.Status == StatusCancelled {
+		return 0
+	}
+	if task.EstimatedHours > 0 {
+		return task.EstimatedHours
+	}
+	if task.Complexity > 0 {
+		return float64(task.Complexity)
+	}
+	return 1
+}
+
+// Task returns the task for an ID in the graph.
+func (g *DependencyGraph) Task(id string) (*Task, bool) {
+	task, ok := g.nodes[id]
+	return task, ok
+}
+
+// IDs returns all task IDs in numeric-aware order.
+func (g *DependencyGraph) IDs() []string {
+	return append([]string(nil), g.ids...)
+}
+
+// Dependencies returns the direct dependencies of a task.
+func (g *DependencyGraph) Dependencies(id string) []string {
+	return append([]string(nil), g.deps[id]...)
+}
+
+// Dependents returns the tasks that directly depend on a task.
+func (g *DependencyGraph) Dependents(id string) []string {
+	return append([]string(nil), g.dependents[id]...)
+}
+
+// Upstream returns every task the given task transitively depends on.
+func (g *DependencyGraph) Upstream(id string) []string {
+	return g.closure(id, g.deps)
+}
+
+// Unblocks returns every task that transitively depends on the given task,
+// i.e. the work that cannot finish until this task is done.
+func (g *DependencyGraph) Unblocks(id string) []string {
+	return g.closure(id, g.dependents)
+}
+
+func (g *DependencyGraph) closure(id string, edges map[string][]string) []string {
+	visited := map[string]bool{id: true}
+	stack := append([]string(nil), edges[id]...)
+	var result []string
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[next] {
+			continue
+		}
+		visited[next] = true
+		result = append(result, next)
+		stack = append(stack, edges[next]...)
+	}
+	SortTaskIDs(result)
+	return result
+}
+
+// TopologicalOrder returns task IDs ordered so every task appears after its
+// dependencies. Ties are broken by numeric-aware ID order. If the graph has a
+// cycle, the order of the acyclic part is returned with a *CycleError listing
+// the remaining tasks.
+func (g *DependencyGraph) TopologicalOrder() ([]string, error) {
+	inDegree := make(map[string]int, len(g.ids))
+	for _, id := range g.ids {
+		inDegree[id] = len(g.deps[id])
+	}
+
+	var ready []string
+	for _, id := range g.ids {
+		if inDegree[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := make([]string, 0, len(g.ids))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		released := false
+		for _, dependent := range g.dependents[id] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				ready = append(ready, dependent)
+				released = true
+			}
+		}
+		if released {
+			SortTaskIDs(ready)
+		}
+	}
+
+	if len(order) < len(g.ids) {
+		var remaining []string
+		for _, id := range g.ids {
+			if inDegree[id] > 0 {
+				remaining = append(remaining, id)
+			}
+		}
+		return order, &CycleError{TaskIDs: remaining}
+	}
+	return order, nil
+}
+
+// Cycles returns the groups of tasks that depend on each other in a cycle,
+// including tasks that depend on themselves. Each group is sorted by ID.
+func (g *DependencyGraph) Cycles() [][]string {
+	// Tarjan's strongly connected components
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var strongConnect func(id string)
+	strongConnect = func(id string) {
+		indices[id] = index
+		lowlink[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, dep := range g.deps[id] {
+			if _, seen := indices[dep]; !seen {
+				strongConnect(dep)
+				if lowlink[dep] < lowlink[id] {
+					lowlink[id] = lowlink[dep]
+				}
+			} else if onStack[dep] && indices[dep] < lowlink[id] {
+				lowlink[id] = indices[dep]
+			}
+		}
+
+		if lowlink[id] != indices[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || g.dependsOnSelf(id) {
+			SortTaskIDs(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, id := range g.ids {
+		if _, seen := indices[id]; !seen {
+			strongConnect(id)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return CompareTaskIDs(cycles[i][0], cycles[j][0]) < 0
+	})
+	return cycles
+}
+
+func (g *DependencyGraph) dependsOnSelf(id string) bool {
+	for _, dep := range g.deps[id] {
+		if dep == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CriticalPath returns the chain of dependent tasks with the greatest total
+// remaining weight, ordered from first to last, along with that total.
+func (g *DependencyGraph) CriticalPath() ([]string, float64, error) {
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	weight := g.Weight
+	if weight == nil {
+		weight = TaskWeight
+	}
+
+	dist := make(map[string]float64, len(order))
+	prev := make(map[string]string, len(order))
+	best := ""
+	for _, id := range order {
+		longest := 0.0
+		for _, dep := range g.deps[id] {
+			if dist[dep] > longest {
+				longest = dist[dep]
+				prev[id] = dep
+			}
+		}
+		dist[id] = longest + weight(g.nodes[id])
+		if best == "" || dist[id] > dist[best] {
+			best = id
+		}
+	}
+
+	if best == "" || dist[best] == 0 {
+		return nil, 0, nil
+	}
+
+	var path []string
+	for id := best; id != ""; id = prev[id] {
+		path = append([]string{id}, path...)
+	}
+	return path, dist[best], nil
+}
+
+// ReadyTasks returns pending tasks whose dependencies are all complete, ranked
+// by priority, then by how many unfinished tasks they transitively unblock,
+// then by ID.
+func (g *DependencyGraph) ReadyTasks() []*Task {
+	var ready []*Task
+	fanOut := make(map[string]int)
+	for _, id := range g.ids {
+		task := g.nodes[id]
+		if task.Status != StatusPending || task.HasBlockedDependencies(g.nodes) {
+			continue
+		}
+		ready = append(ready, task)
+		for _, downstream := range g.Unblocks(id) {
+			if t := g.nodes[downstream]; !t.IsComplete() && t.Status != StatusCancelled {
+				fanOut[id]++
+			}
+		}
+	}
+
+	sort.SliceStable(ready, func(i, j int) bool {
+		pi, pj := priorityRank(ready[i].Priority), priorityRank(ready[j].Priority)
+		if pi != pj {
+			return pi > pj
+		}
+		if fanOut[ready[i].ID] != fanOut[ready[j].ID] {
+			return fanOut[ready[i].ID] > fanOut[ready[j].ID]
+		}
+		return CompareTaskIDs(ready[i].ID, ready[j].ID) < 0
+	})
+	return ready
+}
+
+// priorityRank orders priorities for scheduling. Unset priority ranks as medium.
+func priorityRank(priority string) int {
+	switch priority {
+	case PriorityCritical:
+		return 4
+	case PriorityHigh:
+		return 3
+	case PriorityLow:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// CompareTaskIDs compares dotted task IDs segment by segment, numerically
+// where both segments are numbers, so "2" < "10" and "1.2" < "1.10".
+func CompareTaskIDs(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case as[i] != bs[i]:
+			return strings.Compare(as[i], bs[i])
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// SortTaskIDs sorts task IDs in place using CompareTaskIDs.
+func SortTaskIDs(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		return CompareTaskIDs(ids[i], ids[j]) < 0
+	})
+}
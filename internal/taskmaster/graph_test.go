@@ -0,0 +1,121 @@
+package taskmaster
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func graphFixture() map[string]*Task {
+	tasks := []Task{
+		{ID: "1", Title: "Schema", Status: StatusDone, EstimatedHours: 2},
+		{ID: "2", Title: "API", Status: StatusPending, Priority: PriorityMedium, Dependencies: []string{"1"}, EstimatedHours: 5},
+		{ID: "3", Title: "Docs", Status: StatusPending, Priority: PriorityMedium, Dependencies: []string{"1"}, EstimatedHours: 1},
+		{ID: "4", Title: "UI", Status: StatusPending, Dependencies: []string{"2"}, Complexity: 8},
+		{ID: "10", Title: "Release", Status: StatusPending, Dependencies: []string{"3", "4"}},
+		{ID: "11", Title: "Spike", Status: StatusPending, Priority: PriorityLow},
+	}
+	index, _ := buildTaskIndex(tasks)
+	return index
+}
+
+func TestDependencyGraphTopologicalOrder(t *testing.T) {
+	g := NewDependencyGraph(graphFixture())
+
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		t.Fatalf("TopologicalOrder returned error: %v", err)
+	}
+	want := []string{"1", "2", "3", "4", "10", "11"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("TopologicalOrder() = %v, want %v", order, want)
+	}
+}
+
+func TestDependencyGraphCriticalPath(t *testing.T) {
+	g := NewDependencyGraph(graphFixture())
+
+	path, total, err := g.CriticalPath()
+	if err != nil {
+		t.Fatalf("CriticalPath returned error: %v", err)
+	}
+	// 2 (5h) -> 4 (complexity 8) -> 10 (1 unit); task 1 is done and weighs nothing
+	want := []string{"2", "4", "10"}
+	if !reflect.DeepEqual(path, want) {
+		t.Errorf("CriticalPath() path = %v, want %v", path, want)
+	}
+	if total != 14 {
+		t.Errorf("CriticalPath() total = %v, want 14", total)
+	}
+}
+
+func TestDependencyGraphUnblocksAndUpstream(t *testing.T) {
+	g := NewDependencyGraph(graphFixture())
+
+	if got, want := g.Unblocks("2"), []string{"4", "10"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unblocks(2) = %v, want %v", got, want)
+	}
+	if got, want := g.Unblocks("1"), []string{"2", "3", "4", "10"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unblocks(1) = %v, want %v", got, want)
+	}
+	if got, want := g.Upstream("10"), []string{"1", "2", "3", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Upstream(10) = %v, want %v", got, want)
+	}
+	if got := g.Unblocks("11"); len(got) != 0 {
+		t.Errorf("Unblocks(11) = %v, want none", got)
+	}
+}
+
+func TestDependencyGraphReadyTasksRanking(t *testing.T) {
+	g := NewDependencyGraph(graphFixture())
+
+	ready := g.ReadyTasks()
+	var ids []string
+	for _, task := range ready {
+		ids = append(ids, task.ID)
+	}
+	// 2 and 3 share priority; 2 unblocks more work. 11 is low priority.
+	want := []string{"2", "3", "11"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("ReadyTasks() = %v, want %v", ids, want)
+	}
+}
+
+func TestDependencyGraphCycles(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Status: StatusPending, Dependencies: []string{"3"}},
+		{ID: "2", Status: StatusPending, Dependencies: []string{"1"}},
+		{ID: "3", Status: StatusPending, Dependencies: []string{"2"}},
+		{ID: "4", Status: StatusPending, Dependencies: []string{"4"}},
+		{ID: "5", Status: StatusPending},
+	}
+	index, _ := buildTaskIndex(tasks)
+	g := NewDependencyGraph(index)
+
+	cycles := g.Cycles()
+	want := [][]string{{"1", "2", "3"}, {"4"}}
+	if !reflect.DeepEqual(cycles, want) {
+		t.Errorf("Cycles() = %v, want %v", cycles, want)
+	}
+
+	order, err := g.TopologicalOrder()
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected CycleError, got %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"5"}) {
+		t.Errorf("expected acyclic prefix [5], got %v", order)
+	}
+	if _, _, err := g.CriticalPath(); err == nil {
+		t.Error("expected CriticalPath to fail on a cyclic graph")
+	}
+}
+
+func TestCompareTaskIDs(t *testing.T) {
+	ids := []string{"10", "2", "1.10", "1.2", "1", "a", "2.1"}
+	SortTaskIDs(ids)
+	want := []string{"1", "1.2", "1.10", "2", "2.1", "10", "a"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("SortTaskIDs() = %v, want %v", ids, want)
+	}
+}
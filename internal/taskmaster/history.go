@@ -0,0 +1,184 @@
+package taskmaster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// UndoHistory returns the recorded changes, oldest first, and how many of
+// them are currently applied.
+func (s *Service) UndoHistory() UndoHistory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.undo.History()
+}
+
+// Undo reverts the most recent applied change and returns it.
+func (s *Service) Undo(ctx context.Context) (*UndoAction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.beginHistoryMoveLocked(ctx); err != nil {
+		return nil, err
+	}
+	history := s.undo.History()
+	if !history.CanUndo() {
+		return nil, ErrNothingToUndo
+	}
+	action := history.Actions[history.Position-1]
+	if err := s.restoreHistoryLocked(history.Position - 1); err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// Redo reapplies the most recently undone change and returns it.
+func (s *Service) Redo(ctx context.Context) (*UndoAction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.beginHistoryMoveLocked(ctx); err != nil {
+		return nil, err
+	}
+	history := s.undo.History()
+	if !history.CanRedo() {
+		return nil, ErrNothingToRedo
+	}
+	action := history.Actions[history.Position]
+	if err := s.restoreHistoryLocked(history.Position + 1); err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// RestoreHistory jumps to the point in the undo history around the given
+// action: an applied action is undone together with every later change, and
+// an undone action is redone together with every earlier undone change.
+func (s *Service) RestoreHistory(ctx context.Context, actionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.beginHistoryMoveLocked(ctx); err != nil {
+		return err
+	}
+	_, index, err := s.undo.Find(actionID)
+	if err != nil {
+		return err
+	}
+	if index < s.undo.History().Position {
+		return s.restoreHistoryLocked(index)
+	}
+	return s.restoreHistoryLocked(index + 1)
+}
+
+// UndoAction reverts the change identified by an undo token. The change must
+// still be the most recent one and its token must not have expired.
+func (s *Service) UndoAction(ctx context.Context, actionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.beginHistoryMoveLocked(ctx); err != nil {
+		return err
+	}
+	action, index, err := s.undo.Find(actionID)
+	if err != nil {
+		return err
+	}
+	if index != s.undo.History().Position-1 {
+		return fmt.Errorf("%w: %s is no longer the latest change", ErrUndoNotFound, actionID)
+	}
+	if !action.ExpiresAt.IsZero() && time.Now().After(action.ExpiresAt) {
+		return ErrUndoExpired
+	}
+	return s.restoreHistoryLocked(index)
+}
+
+// beginHistoryMoveLocked checks the service can move through the history.
+// If tasks.json was changed outside the service since the last recorded
+// change, that edit is recorded first so undoing it later cannot lose it.
+func (s *Service) beginHistoryMoveLocked(ctx context.Context) error {
+	if !s.available {
+		return fmt.Errorf("taskmaster not available")
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	history := s.undo.History()
+	if len(history.Actions) == 0 {
+		return nil
+	}
+	expected, err := s.undo.SnapshotAt(history.Position)
+	if err != nil {
+		return err
+	}
+	current, err := os.ReadFile(s.tasksFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to read tasks file: %w", err)
+	}
+	if bytes.Equal(expected, current) {
+		return nil
+	}
+	action := newUndoAction(UndoActionEdit, "External change to tasks.json")
+	if err := s.undo.Record(action, expected, current); err != nil {
+		return fmt.Errorf("failed to record external change: %w", err)
+	}
+	return nil
+}
+
+// restoreHistoryLocked writes the snapshot at the given history position to
+// tasks.json and reloads the tasks.
+func (s *Service) restoreHistoryLocked(position int) error {
+	data, err := s.undo.SnapshotAt(position)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(s.tasksFilePath(), data, 0644); err != nil {
+		return err
+	}
+	if err := s.reloadTasksLocked(); err != nil {
+		return err
+	}
+	return s.undo.SetPosition(position)
+}
+
+// recordHistoryLocked pushes action onto the undo history with the tasks.json
+// contents from before the change and as they are now. It reports whether
+// the action was recorded. History is best effort: the change itself has
+// already been written, so failing to record it only loses its undo.
+func (s *Service) recordHistoryLocked(action *UndoAction, before []byte) bool {
+	if action == nil || before == nil {
+		return false
+	}
+	after, err := os.ReadFile(s.tasksFilePath())
+	if err != nil || bytes.Equal(before, after) {
+		return false
+	}
+	return s.undo.Record(action, before, after) == nil
+}
+
+// recordCommandChange records a change made to tasks.json by a task-master
+// CLI command, given the file contents read before the command ran.
+func (s *Service) recordCommandChange(action *UndoAction, before []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordHistoryLocked(action, before)
+}
+
+// tasksFileSnapshot reads tasks.json ahead of a CLI command so the change it
+// makes can be recorded. Returns nil when the file cannot be read.
+func (s *Service) tasksFileSnapshot() []byte {
+	if !s.available {
+		return nil
+	}
+	data, err := os.ReadFile(s.tasksFilePath())
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,151 @@
+package taskmaster
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+func taskStatus(t *testing.T, svc *Service, id string) string {
+	t.Helper()
+	task, ok := svc.GetTaskByID(id)
+	if !ok {
+		t.Fatalf("task %s not found", id)
+	}
+	return task.Status
+}
+
+func TestUndoRedoWalksHistoryAndSurvivesRestart(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	if err := svc.SetTaskStatus("2.1", StatusInProgress); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+	if err := svc.SetTaskStatus("2.1", StatusDone); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+	title := "Build it"
+	if _, err := svc.UpdateTaskFields(ctx, "2", TaskUpdate{Title: &title}); err != nil {
+		t.Fatalf("UpdateTaskFields returned error: %v", err)
+	}
+
+	history := svc.UndoHistory()
+	if len(history.Actions) != 3 || history.Position != 3 {
+		t.Fatalf("expected 3 applied actions, got %+v", history)
+	}
+	if history.Actions[0].Type != UndoActionStatus || history.Actions[2].Type != UndoActionEdit {
+		t.Fatalf("unexpected action types: %+v", history.Actions)
+	}
+
+	undone, err := svc.Undo(ctx)
+	if err != nil || undone.Summary != "Edited task 2" {
+		t.Fatalf("expected edit undone, got %+v (%v)", undone, err)
+	}
+	if _, err := svc.Undo(ctx); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if status := taskStatus(t, svc, "2.1"); status != StatusInProgress {
+		t.Fatalf("expected status in-progress after two undos, got %s", status)
+	}
+
+	// A new service over the same project picks up the saved history
+	restarted, err := NewService(svc.config)
+	if err != nil {
+		t.Fatalf("NewService returned error: %v", err)
+	}
+	if history := restarted.UndoHistory(); history.Position != 1 || len(history.Actions) != 3 {
+		t.Fatalf("expected history restored at position 1, got %+v", history)
+	}
+	if _, err := restarted.Redo(ctx); err != nil {
+		t.Fatalf("Redo returned error: %v", err)
+	}
+	if status := taskStatus(t, restarted, "2.1"); status != StatusDone {
+		t.Fatalf("expected status done after redo, got %s", status)
+	}
+
+	// Jumping to the first action undoes everything from it onwards
+	if err := restarted.RestoreHistory(ctx, history.Actions[0].ID); err != nil {
+		t.Fatalf("RestoreHistory returned error: %v", err)
+	}
+	if status := taskStatus(t, restarted, "2.1"); status != StatusPending {
+		t.Fatalf("expected original status after restore, got %s", status)
+	}
+	if _, err := restarted.Undo(ctx); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("expected ErrNothingToUndo, got %v", err)
+	}
+
+	// Jumping to an undone action redoes up to and including it
+	if err := restarted.RestoreHistory(ctx, history.Actions[2].ID); err != nil {
+		t.Fatalf("RestoreHistory returned error: %v", err)
+	}
+	if task, _ := restarted.GetTaskByID("2"); task.Title != "Build it" {
+		t.Fatalf("expected title restored by redo, got %q", task.Title)
+	}
+
+	// A new change discards the redo tail
+	restarted.Undo(ctx)
+	if err := restarted.SetTaskStatus("3", StatusBlocked); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+	if _, err := restarted.Redo(ctx); !errors.Is(err, ErrNothingToRedo) {
+		t.Fatalf("expected ErrNothingToRedo, got %v", err)
+	}
+}
+
+func TestUndoRecordsExternalChangeFirst(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	if err := svc.SetTaskStatus("1", StatusPending); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+	edited, err := os.ReadFile(svc.tasksFilePath())
+	if err != nil {
+		t.Fatalf("failed to read tasks: %v", err)
+	}
+	external := append(edited, '\n')
+	if err := os.WriteFile(svc.tasksFilePath(), external, 0644); err != nil {
+		t.Fatalf("failed to write tasks: %v", err)
+	}
+
+	undone, err := svc.Undo(ctx)
+	if err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if undone.Summary != "External change to tasks.json" {
+		t.Fatalf("expected the external change to be undone first, got %q", undone.Summary)
+	}
+	if status := taskStatus(t, svc, "1"); status != StatusPending {
+		t.Fatalf("expected own change kept, got %s", status)
+	}
+	if _, err := svc.Redo(ctx); err != nil {
+		t.Fatalf("Redo returned error: %v", err)
+	}
+	if data, _ := os.ReadFile(svc.tasksFilePath()); string(data) != string(external) {
+		t.Fatalf("expected redo to bring back the external edit")
+	}
+}
+
+func TestUndoManagerDropsOldestBeyondLimit(t *testing.T) {
+	dir := t.TempDir()
+	manager := NewUndoManager(dir, 2)
+	for i, state := range []string{"a", "b", "c"} {
+		action := &UndoAction{ID: "undo-" + state}
+		if err := manager.Record(action, []byte{byte('0' + i)}, []byte(state)); err != nil {
+			t.Fatalf("Record returned error: %v", err)
+		}
+	}
+
+	history := NewUndoManager(dir, 2).History()
+	if len(history.Actions) != 2 || history.Actions[0].ID != "undo-b" || history.Position != 2 {
+		t.Fatalf("expected the two newest actions, got %+v", history)
+	}
+	if _, err := os.Stat(manager.snapshotPath("undo-a", "before")); !os.IsNotExist(err) {
+		t.Fatalf("expected snapshots of dropped action removed, got %v", err)
+	}
+	if data, err := manager.SnapshotAt(0); err != nil || string(data) != "1" {
+		t.Fatalf("expected oldest kept state, got %q (%v)", data, err)
+	}
+}
@@ -21,7 +21,12 @@ func LoadTasksFromFile(rootDir string, tag string) ([]Task, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read tasks file: %w", err)
 	}
-	
+	return parseTasksFile(data, tag)
+}
+
+// parseTasksFile parses the contents of tasks.json, selecting tag the same
+// way LoadTasksFromFile does.
+func parseTasksFile(data []byte, tag string) ([]Task, error) {
 	// Try to determine the format by unmarshaling to a generic map first
 	var raw map[string]interface{}
 	if err := json.Unmarshal(data, &raw); err != nil {
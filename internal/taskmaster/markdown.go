@@ -0,0 +1,235 @@
+package taskmaster
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Markdown sections holding the long text fields of a task, in file order.
+const (
+	markdownDescription  = "Description"
+	markdownDetails      = "Details"
+	markdownTestStrategy = "Test Strategy"
+)
+
+const frontMatterDelimiter = "---"
+
+// taskFrontMatter is the YAML front matter of a task Markdown file.
+type taskFrontMatter struct {
+	ID             string   `yaml:"id"`
+	Title          string   `yaml:"title"`
+	Status         string   `yaml:"status"`
+	Priority       string   `yaml:"priority,omitempty"`
+	Dependencies   []string `yaml:"dependencies,flow"`
+	Tags           []string `yaml:"tags,flow"`
+	EstimatedHours float64  `yaml:"estimatedHours"`
+}
+
+// FieldChange describes one edited field of a task.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// MarshalTaskMarkdown writes a task as Markdown with YAML front matter for
+// editing in a text editor. The short fields go in the front matter and
+// description, details and test strategy each get a "## " section.
+func MarshalTaskMarkdown(task *Task) ([]byte, error) {
+	front := taskFrontMatter{
+		ID:             task.ID,
+		Title:          task.Title,
+		Status:         task.Status,
+		Priority:       task.Priority,
+		Dependencies:   task.Dependencies,
+		Tags:           task.Tags,
+		EstimatedHours: task.EstimatedHours,
+	}
+	if front.Dependencies == nil {
+		front.Dependencies = []string{}
+	}
+	if front.Tags == nil {
+		front.Tags = []string{}
+	}
+	header, err := yaml.Marshal(front)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task %s: %w", task.ID, err)
+	}
+
+	var b bytes.Buffer
+	b.WriteString(frontMatterDelimiter + "\n")
+	b.WriteString("# Edit and save to update the task; the id cannot be changed.\n")
+	b.Write(header)
+	b.WriteString(frontMatterDelimiter + "\n")
+	for _, section := range []struct{ name, text string }{
+		{markdownDescription, task.Description},
+		{markdownDetails, task.Details},
+		{markdownTestStrategy, task.TestStrategy},
+	} {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.name)
+		if text := strings.TrimSpace(section.text); text != "" {
+			b.WriteString(text + "\n")
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// ParseTaskMarkdown reads a task written by MarshalTaskMarkdown. Unknown
+// front matter keys are rejected so typos are not silently dropped; missing
+// sections leave the corresponding field empty.
+func ParseTaskMarkdown(data []byte) (*Task, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, frontMatterDelimiter+"\n") {
+		return nil, fmt.Errorf("missing front matter: the file must start with %q", frontMatterDelimiter)
+	}
+	rest := text[len(frontMatterDelimiter)+1:]
+	end := strings.Index(rest, "\n"+frontMatterDelimiter+"\n")
+	if end < 0 {
+		if !strings.HasSuffix(rest, "\n"+frontMatterDelimiter) {
+			return nil, fmt.Errorf("front matter is not closed with %q", frontMatterDelimiter)
+		}
+		end = len(rest) - len(frontMatterDelimiter) - 1
+	}
+	header := rest[:end+1]
+	body := ""
+	if start := end + len(frontMatterDelimiter) + 2; start < len(rest) {
+		body = rest[start:]
+	}
+
+	var front taskFrontMatter
+	decoder := yaml.NewDecoder(strings.NewReader(header))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&front); err != nil {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	task := &Task{
+		ID:             strings.TrimSpace(front.ID),
+		Title:          strings.TrimSpace(front.Title),
+		Status:         strings.TrimSpace(front.Status),
+		Priority:       strings.TrimSpace(front.Priority),
+		Dependencies:   front.Dependencies,
+		Tags:           front.Tags,
+		EstimatedHours: front.EstimatedHours,
+	}
+	sections := parseMarkdownSections(body)
+	task.Description = sections[markdownDescription]
+	task.Details = sections[markdownDetails]
+	task.TestStrategy = sections[markdownTestStrategy]
+	return task, nil
+}
+
+// parseMarkdownSections splits body at the known "## " headings. Other
+// headings, and known ones inside fenced code blocks, stay part of the
+// section they appear in.
+func parseMarkdownSections(body string) map[string]string {
+	known := map[string]string{}
+	for _, name := range []string{markdownDescription, markdownDetails, markdownTestStrategy} {
+		known[strings.ToLower(name)] = name
+	}
+
+	sections := make(map[string]string)
+	current := ""
+	var lines []string
+	flush := func() {
+		if current != "" {
+			sections[current] = strings.TrimSpace(strings.Join(lines, "\n"))
+		}
+		lines = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	fence := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		if marker, rest := markdownFence(line); marker != "" {
+			if fence == "" {
+				fence = marker
+			} else if strings.HasPrefix(marker, fence) && strings.TrimSpace(rest) == "" {
+				fence = ""
+			}
+		} else if heading, ok := strings.CutPrefix(line, "## "); ok && fence == "" {
+			if name, ok := known[strings.ToLower(strings.TrimSpace(heading))]; ok {
+				flush()
+				current = name
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return sections
+}
+
+// markdownFence returns the run of backticks or tildes opening or closing a
+// fenced code block on line, and the text after it, or "" when line is not a
+// fence.
+func markdownFence(line string) (string, string) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || trimmed == "" || (trimmed[0] != '`' && trimmed[0] != '~') {
+		return "", ""
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == trimmed[0] {
+		n++
+	}
+	if n < 3 {
+		return "", ""
+	}
+	return trimmed[:n], trimmed[n:]
+}
+
+// DiffTaskEdit compares an edited copy of a task with the original and
+// returns an update containing only the changed fields, along with a
+// description of each change. Text is compared without surrounding
+// whitespace, matching how MarshalTaskMarkdown writes it.
+func DiffTaskEdit(original, edited *Task) (TaskUpdate, []FieldChange, error) {
+	var update TaskUpdate
+	var changes []FieldChange
+	if edited.ID != original.ID {
+		return update, nil, fmt.Errorf("task ID cannot be changed (was %s, now %s)", original.ID, edited.ID)
+	}
+
+	text := func(field, before, after string, target **string) {
+		if strings.TrimSpace(before) == after {
+			return
+		}
+		value := after
+		*target = &value
+		changes = append(changes, FieldChange{Field: field, Old: before, New: after})
+	}
+	text("title", original.Title, edited.Title, &update.Title)
+	text("status", original.Status, edited.Status, &update.Status)
+	text("priority", original.Priority, edited.Priority, &update.Priority)
+	text("description", original.Description, edited.Description, &update.Description)
+	text("details", original.Details, edited.Details, &update.Details)
+	text("testStrategy", original.TestStrategy, edited.TestStrategy, &update.TestStrategy)
+
+	list := func(field string, before, after []string, target **[]string) {
+		if strings.Join(before, ",") == strings.Join(after, ",") {
+			return
+		}
+		value := append([]string{}, after...)
+		*target = &value
+		changes = append(changes, FieldChange{Field: field, Old: strings.Join(before, ", "), New: strings.Join(after, ", ")})
+	}
+	list("dependencies", original.Dependencies, edited.Dependencies, &update.Dependencies)
+	list("tags", original.Tags, edited.Tags, &update.Tags)
+
+	if edited.EstimatedHours != original.EstimatedHours {
+		hours := edited.EstimatedHours
+		update.EstimatedHours = &hours
+		changes = append(changes, FieldChange{
+			Field: "estimatedHours",
+			Old:   strconv.FormatFloat(original.EstimatedHours, 'f', -1, 64),
+			New:   strconv.FormatFloat(hours, 'f', -1, 64),
+		})
+	}
+	return update, changes, nil
+}
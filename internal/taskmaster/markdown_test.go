@@ -0,0 +1,102 @@
+package taskmaster
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskMarkdownRoundTrip(t *testing.T) {
+	task := &Task{
+		ID:           "2.10",
+		Title:        "Login page",
+		Status:       StatusPending,
+		Priority:     PriorityHigh,
+		Dependencies: []string{"1", "2.2"},
+		Tags:         []string{"ui"},
+		Description:  "OAuth flow",
+		Details:      "Steps:\n\n## Notes\n- redirect\n",
+		TestStrategy: "Click through",
+	}
+
+	data, err := MarshalTaskMarkdown(task)
+	if err != nil {
+		t.Fatalf("MarshalTaskMarkdown returned error: %v", err)
+	}
+	parsed, err := ParseTaskMarkdown(data)
+	if err != nil {
+		t.Fatalf("ParseTaskMarkdown returned error: %v\n%s", err, data)
+	}
+	update, changes, err := DiffTaskEdit(task, parsed)
+	if err != nil || len(changes) != 0 || !update.IsEmpty() {
+		t.Fatalf("expected an unchanged round trip, got %+v %v\n%s", changes, err, data)
+	}
+
+	edited := strings.Replace(string(data), "title: Login page", "title: Sign-in page", 1)
+	edited = strings.Replace(edited, "Click through", "Click through\n\nThen log out", 1)
+	edited = strings.Replace(edited, `dependencies: ["1", "2.2"]`, `dependencies: [1]`, 1)
+	parsed, err = ParseTaskMarkdown([]byte(edited))
+	if err != nil {
+		t.Fatalf("ParseTaskMarkdown returned error: %v", err)
+	}
+	update, changes, err = DiffTaskEdit(task, parsed)
+	if err != nil {
+		t.Fatalf("DiffTaskEdit returned error: %v", err)
+	}
+	fields := make([]string, len(changes))
+	for i, change := range changes {
+		fields[i] = change.Field
+	}
+	if got := strings.Join(fields, ","); got != "title,testStrategy,dependencies" {
+		t.Fatalf("unexpected changed fields %s\n%s", got, edited)
+	}
+	if *update.TestStrategy != "Click through\n\nThen log out" || (*update.Dependencies)[0] != "1" {
+		t.Fatalf("unexpected update %+v", update)
+	}
+}
+
+func TestTaskMarkdownRoundTripKeepsFencedHeadings(t *testing.T) {
+	task := &Task{
+		ID:           "3",
+		Title:        "Write docs",
+		Status:       StatusPending,
+		Description:  "Document the template",
+		Details:      "Template:\n\n```markdown\n## Details\nFill in\n\n## Test Strategy\n```\n\n~~~~\n```\n## Description\n~~~~\nDone",
+		TestStrategy: "Render it",
+	}
+
+	data, err := MarshalTaskMarkdown(task)
+	if err != nil {
+		t.Fatalf("MarshalTaskMarkdown returned error: %v", err)
+	}
+	parsed, err := ParseTaskMarkdown(data)
+	if err != nil {
+		t.Fatalf("ParseTaskMarkdown returned error: %v\n%s", err, data)
+	}
+	if parsed.Description != task.Description || parsed.Details != task.Details || parsed.TestStrategy != task.TestStrategy {
+		t.Fatalf("expected headings in code blocks kept in their section, got %+v\n%s", parsed, data)
+	}
+	if _, changes, err := DiffTaskEdit(task, parsed); err != nil || len(changes) != 0 {
+		t.Fatalf("expected an unchanged round trip, got %+v %v", changes, err)
+	}
+}
+
+func TestParseTaskMarkdownRejectsBadInput(t *testing.T) {
+	cases := map[string]string{
+		"no front matter": "title: x\n",
+		"unclosed":        "---\nid: \"1\"\n",
+		"unknown key":     "---\nid: \"1\"\ntitel: typo\n---\n",
+	}
+	for name, input := range cases {
+		if _, err := ParseTaskMarkdown([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	parsed, err := ParseTaskMarkdown([]byte("---\nid: \"2\"\ntitle: x\nstatus: pending\n---\n"))
+	if err != nil {
+		t.Fatalf("ParseTaskMarkdown returned error: %v", err)
+	}
+	if _, _, err := DiffTaskEdit(&Task{ID: "1"}, parsed); err == nil {
+		t.Fatal("expected a changed ID to be rejected")
+	}
+}
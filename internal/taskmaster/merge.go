@@ -0,0 +1,503 @@
+package taskmaster
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// MergeResolution chooses how conflicting field changes are settled when
+// merging an external edit of tasks.json.
+type MergeResolution string
+
+const (
+	// MergeKeepMine keeps the in-memory value of every conflicting field.
+	MergeKeepMine MergeResolution = "mine"
+	// MergeKeepTheirs keeps the on-disk value of every conflicting field;
+	// non-conflicting in-memory changes are still applied.
+	MergeKeepTheirs MergeResolution = "theirs"
+	// MergeDiscardMine drops the pending change and reloads tasks.json.
+	MergeDiscardMine MergeResolution = "discard"
+)
+
+// ErrNoPendingMerge indicates there is no conflicting write to resolve.
+var ErrNoPendingMerge = errors.New("no merge conflict pending")
+
+// deletedTaskField is the FieldConflict.Field used when one side deleted a
+// task the other side modified.
+const deletedTaskField = "task"
+
+// FieldConflict describes a task field that was changed to different values
+// in memory and on disk since both were last in sync. Values are rendered as
+// JSON; a deleted task is shown as "(deleted)".
+type FieldConflict struct {
+	TaskID string
+	Title  string
+	Field  string
+	Base   string
+	Mine   string
+	Theirs string
+}
+
+// MergeConflictError is returned by writes that found tasks.json changed on
+// disk with conflicting edits. The write is kept pending until
+// ResolveMergeConflict is called.
+type MergeConflictError struct {
+	Conflicts []FieldConflict
+}
+
+func (e *MergeConflictError) Error() string {
+	fields := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		fields = append(fields, fmt.Sprintf("task %s %s", conflict.TaskID, conflict.Field))
+	}
+	return fmt.Sprintf("tasks.json was changed externally with %d conflicting change(s): %s",
+		len(e.Conflicts), strings.Join(fields, ", "))
+}
+
+// pendingMerge holds a write that was stopped by a merge conflict.
+type pendingMerge struct {
+	base   []Task
+	mine   []Task
+	action *UndoAction
+}
+
+// mergeNode is a task without its subtasks, linked to its children by ID.
+type mergeNode struct {
+	task     Task
+	children []string
+}
+
+// flatTasks indexes a task tree by ID for merging.
+type flatTasks struct {
+	nodes  map[string]*mergeNode
+	fields map[string]map[string]json.RawMessage
+	parent map[string]string
+	order  []string // pre-order, so parents precede their subtasks
+	roots  []string
+}
+
+func indexForMerge(tasks []Task) *flatTasks {
+	flat := &flatTasks{
+		nodes:  make(map[string]*mergeNode),
+		fields: make(map[string]map[string]json.RawMessage),
+		parent: make(map[string]string),
+	}
+	var walk func(tasks []Task, parentID string) []string
+	walk = func(tasks []Task, parentID string) []string {
+		ids := make([]string, 0, len(tasks))
+		for i := range tasks {
+			task := tasks[i]
+			id := task.ID
+			ids = append(ids, id)
+			flat.parent[id] = parentID
+			flat.order = append(flat.order, id)
+			node := &mergeNode{task: task}
+			node.task.Subtasks = nil
+			flat.nodes[id] = node
+			flat.fields[id] = taskFields(&node.task)
+			node.children = walk(task.Subtasks, id)
+		}
+		return ids
+	}
+	flat.roots = walk(tasks, "")
+	return flat
+}
+
+// children returns the child IDs of parentID ("" for top-level tasks).
+func (f *flatTasks) children(parentID string) []string {
+	if parentID == "" {
+		return f.roots
+	}
+	if node, ok := f.nodes[parentID]; ok {
+		return node.children
+	}
+	return nil
+}
+
+func (f *flatTasks) setChildren(parentID string, ids []string) {
+	if parentID == "" {
+		f.roots = ids
+		return
+	}
+	if node, ok := f.nodes[parentID]; ok {
+		node.children = ids
+	}
+}
+
+func (f *flatTasks) build(ids []string) []Task {
+	if len(ids) == 0 {
+		return nil
+	}
+	tasks := make([]Task, 0, len(ids))
+	for _, id := range ids {
+		node, ok := f.nodes[id]
+		if !ok {
+			continue
+		}
+		task := node.task
+		task.Subtasks = f.build(node.children)
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// taskFields returns the serialized fields of a task without its subtasks.
+func taskFields(task *Task) map[string]json.RawMessage {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	delete(fields, "subtasks")
+	return fields
+}
+
+func sameFields(a, b map[string]json.RawMessage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if !bytes.Equal(value, b[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MergeTasks three-way merges two edited copies of a task list. base is the
+// list both sides started from, mine the in-memory edit and theirs the list
+// found on disk. Tasks are matched by ID and merged field by field: a field
+// changed on one side only takes that side's value. Fields changed to
+// different values on both sides are returned as conflicts and settled by
+// resolution (theirs unless resolution is MergeKeepMine); updatedAt never
+// conflicts. The tree shape follows theirs, with tasks added or removed in
+// mine applied on top and sibling order taken from mine when only mine
+// reordered.
+func MergeTasks(base, mine, theirs []Task, resolution MergeResolution) ([]Task, []FieldConflict) {
+	baseFlat := indexForMerge(base)
+	mineFlat := indexForMerge(mine)
+	merged := indexForMerge(theirs)
+	theirsFields := merged.fields
+	keepMine := resolution == MergeKeepMine
+
+	var conflicts []FieldConflict
+	var added []string
+	for _, id := range mineFlat.order {
+		mineFields := mineFlat.fields[id]
+		baseFields, inBase := baseFlat.fields[id]
+		theirFields, inTheirs := theirsFields[id]
+		switch {
+		case inTheirs:
+			fields, fieldConflicts := mergeTaskFields(id, baseFields, mineFields, theirFields, keepMine)
+			conflicts = append(conflicts, fieldConflicts...)
+			var task Task
+			if data, err := json.Marshal(fields); err == nil && json.Unmarshal(data, &task) == nil {
+				merged.nodes[id].task = task
+			}
+		case inBase && sameFields(mineFields, baseFields):
+			// Deleted on disk and untouched in memory
+		case inBase:
+			conflicts = append(conflicts, FieldConflict{
+				TaskID: id, Title: mineFlat.nodes[id].task.Title, Field: deletedTaskField,
+				Mine: "(modified)", Theirs: "(deleted)",
+			})
+			if keepMine {
+				added = append(added, id)
+			}
+		default:
+			added = append(added, id)
+		}
+	}
+
+	// Sibling order: adopt mine where only mine reordered existing tasks
+	for parentID := range orderParents(baseFlat) {
+		baseOrder := baseFlat.children(parentID)
+		mineOrder := commonOrder(mineFlat.children(parentID), baseOrder)
+		theirOrder := merged.children(parentID)
+		if equalOrder(mineOrder, commonOrder(baseOrder, mineOrder)) ||
+			!equalOrder(commonOrder(theirOrder, baseOrder), commonOrder(baseOrder, theirOrder)) {
+			continue
+		}
+		reordered := commonOrder(mineOrder, theirOrder)
+		for _, id := range theirOrder {
+			if !containsID(reordered, id) {
+				reordered = append(reordered, id)
+			}
+		}
+		merged.setChildren(parentID, reordered)
+	}
+
+	// Deletions made in memory
+	for _, id := range baseFlat.order {
+		if _, inMine := mineFlat.fields[id]; inMine {
+			continue
+		}
+		theirFields, inTheirs := theirsFields[id]
+		if !inTheirs {
+			continue
+		}
+		if !sameFields(theirFields, baseFlat.fields[id]) {
+			conflicts = append(conflicts, FieldConflict{
+				TaskID: id, Title: merged.nodes[id].task.Title, Field: deletedTaskField,
+				Mine: "(deleted)", Theirs: "(modified)",
+			})
+			if !keepMine {
+				continue
+			}
+		}
+		parentID := merged.parent[id]
+		merged.setChildren(parentID, removeID(merged.children(parentID), id))
+	}
+
+	// Additions made in memory, parents first
+	for _, id := range added {
+		node := *mineFlat.nodes[id]
+		node.children = nil
+		merged.nodes[id] = &node
+		parentID := mineFlat.parent[id]
+		if _, ok := merged.nodes[parentID]; !ok {
+			parentID = ""
+		}
+		merged.parent[id] = parentID
+		merged.setChildren(parentID, append(append([]string{}, merged.children(parentID)...), id))
+	}
+
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if conflicts[i].TaskID != conflicts[j].TaskID {
+			return CompareTaskIDs(conflicts[i].TaskID, conflicts[j].TaskID) < 0
+		}
+		return conflicts[i].Field < conflicts[j].Field
+	})
+	return merged.build(merged.roots), conflicts
+}
+
+// mergeTaskFields merges the fields of one task. baseFields is nil when the
+// task was added on both sides.
+func mergeTaskFields(id string, baseFields, mineFields, theirFields map[string]json.RawMessage, keepMine bool) (map[string]json.RawMessage, []FieldConflict) {
+	keys := make(map[string]struct{})
+	for _, fields := range []map[string]json.RawMessage{baseFields, mineFields, theirFields} {
+		for key := range fields {
+			keys[key] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	merged := make(map[string]json.RawMessage, len(names))
+	var conflicts []FieldConflict
+	for _, key := range names {
+		base, mine, theirs := baseFields[key], mineFields[key], theirFields[key]
+		value := theirs
+		switch {
+		case bytes.Equal(mine, theirs), bytes.Equal(theirs, base):
+			value = mine
+		case bytes.Equal(mine, base):
+			value = theirs
+		case key == "updatedAt":
+			value = mine
+		default:
+			var title string
+			_ = json.Unmarshal(theirFields["title"], &title)
+			conflicts = append(conflicts, FieldConflict{
+				TaskID: id, Title: title, Field: key,
+				Base: string(base), Mine: string(mine), Theirs: string(theirs),
+			})
+			if keepMine {
+				value = mine
+			}
+		}
+		if value != nil {
+			merged[key] = value
+		}
+	}
+	return merged, conflicts
+}
+
+// orderParents returns every parent ID in flat, including "" for the roots.
+func orderParents(flat *flatTasks) map[string]struct{} {
+	parents := map[string]struct{}{"": {}}
+	for id, node := range flat.nodes {
+		if len(node.children) > 0 {
+			parents[id] = struct{}{}
+		}
+	}
+	return parents
+}
+
+// commonOrder returns the IDs of order that also appear in other, in order.
+func commonOrder(order, other []string) []string {
+	result := make([]string, 0, len(order))
+	for _, id := range order {
+		if containsID(other, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func equalOrder(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func removeID(ids []string, id string) []string {
+	result := make([]string, 0, len(ids))
+	for _, candidate := range ids {
+		if candidate != id {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// commitTasksLocked writes tasks to tasks.json, swaps them in and records
+// action in the undo history, reporting whether it was recorded. If
+// tasks.json changed on disk since it was last loaded or written, the
+// external edit is merged in first; conflicting changes abort the write with
+// a *MergeConflictError and are kept until ResolveMergeConflict is called.
+// Must be called with write lock held.
+func (s *Service) commitTasksLocked(tasks []Task, action *UndoAction) (bool, error) {
+	before, err := os.ReadFile(s.tasksFilePath())
+	if err != nil {
+		return false, fmt.Errorf("failed to read tasks file: %w", err)
+	}
+	if sha256.Sum256(before) != s.fileHash {
+		theirs, err := parseTasksFile(before, s.activeTag())
+		if err != nil {
+			return false, fmt.Errorf("failed to parse externally changed tasks file: %w", err)
+		}
+		merged, conflicts := MergeTasks(s.Tasks, tasks, theirs, "")
+		if len(conflicts) > 0 {
+			s.pendingMerge = &pendingMerge{base: s.Tasks, mine: tasks, action: action}
+			return false, &MergeConflictError{Conflicts: conflicts}
+		}
+		tasks = merged
+	}
+	return s.writeTasksLocked(tasks, before, action)
+}
+
+// overwriteTasksLocked writes tasks over whatever the active tag holds in
+// tasks.json, dropping external edits instead of merging them. The previous
+// file is still recorded with action, so the overwrite can be undone.
+// Must be called with write lock held.
+func (s *Service) overwriteTasksLocked(tasks []Task, action *UndoAction) (bool, error) {
+	before, err := os.ReadFile(s.tasksFilePath())
+	if err != nil {
+		return false, fmt.Errorf("failed to read tasks file: %w", err)
+	}
+	return s.writeTasksLocked(tasks, before, action)
+}
+
+// writeTasksLocked persists tasks, swaps them in and records action with the
+// file content before the write.
+func (s *Service) writeTasksLocked(tasks []Task, before []byte, action *UndoAction) (bool, error) {
+	written, err := s.persistTasksLocked(tasks)
+	if err != nil {
+		return false, err
+	}
+	s.Tasks = tasks
+	s.rebuildIndexAndValidate()
+	s.noteTasksFileLocked(written)
+	return s.recordHistoryLocked(action, before), nil
+}
+
+// noteTasksFileLocked remembers written, the content just written to
+// tasks.json, as the version the in-memory tasks match. The file is not read
+// back, so an external edit made right after the write is still detected.
+func (s *Service) noteTasksFileLocked(written []byte) {
+	if info, err := os.Stat(s.tasksFilePath()); err == nil {
+		s.lastModTime = info.ModTime()
+	}
+	s.fileHash = sha256.Sum256(written)
+}
+
+// PendingMergeConflict returns the conflicts of a write waiting for
+// ResolveMergeConflict, or nil when there is none.
+func (s *Service) PendingMergeConflict() *MergeConflictError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pendingMerge == nil {
+		return nil
+	}
+	theirs, err := LoadTasksFromFile(s.RootDir, s.activeTag())
+	if err != nil {
+		return nil
+	}
+	_, conflicts := MergeTasks(s.pendingMerge.base, s.pendingMerge.mine, theirs, "")
+	return &MergeConflictError{Conflicts: conflicts}
+}
+
+// ResolveMergeConflict completes or drops the write stopped by the last
+// MergeConflictError. The merge is redone against the current tasks.json, so
+// edits made on disk in the meantime are kept.
+func (s *Service) ResolveMergeConflict(ctx context.Context, resolution MergeResolution) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return fmt.Errorf("taskmaster not available")
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	pending := s.pendingMerge
+	if pending == nil {
+		return ErrNoPendingMerge
+	}
+	s.pendingMerge = nil
+	if resolution == MergeDiscardMine {
+		return s.reloadTasksLocked()
+	}
+
+	before, err := os.ReadFile(s.tasksFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to read tasks file: %w", err)
+	}
+	theirs, err := parseTasksFile(before, s.activeTag())
+	if err != nil {
+		return fmt.Errorf("failed to parse tasks file: %w", err)
+	}
+	merged, _ := MergeTasks(pending.base, pending.mine, theirs, resolution)
+	written, err := s.persistTasksLocked(merged)
+	if err != nil {
+		s.pendingMerge = pending
+		return err
+	}
+	s.Tasks = merged
+	s.rebuildIndexAndValidate()
+	s.noteTasksFileLocked(written)
+	s.recordHistoryLocked(pending.action, before)
+	return nil
+}
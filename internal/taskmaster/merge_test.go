@@ -0,0 +1,133 @@
+package taskmaster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+)
+
+// writeExternalTasks rewrites tasks.json behind the service's back.
+func writeExternalTasks(t *testing.T, svc *Service, tasks []Task) {
+	t.Helper()
+	data, err := json.MarshalIndent(map[string]interface{}{"tasks": tasks}, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal tasks: %v", err)
+	}
+	if err := os.WriteFile(svc.tasksFilePath(), data, 0644); err != nil {
+		t.Fatalf("failed to write tasks: %v", err)
+	}
+}
+
+func TestWriteMergesNonConflictingExternalEdit(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+
+	external := mutateFixture()
+	external[2].Title = "Ship it"
+	external = append(external, Task{ID: "4", Title: "Announce", Status: StatusPending})
+	writeExternalTasks(t, svc, external)
+
+	if err := svc.SetTaskStatus("2.1", StatusDone); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+
+	reloaded, err := LoadTasksFromFile(svc.RootDir, "")
+	if err != nil {
+		t.Fatalf("failed to reload tasks: %v", err)
+	}
+	if len(reloaded) != 4 || reloaded[2].Title != "Ship it" || reloaded[3].ID != "4" {
+		t.Fatalf("expected external edits kept, got %+v", reloaded)
+	}
+	if reloaded[1].Subtasks[0].Status != StatusDone {
+		t.Fatalf("expected own status change written, got %s", reloaded[1].Subtasks[0].Status)
+	}
+	if task, ok := svc.GetTaskByID("4"); !ok || task.Title != "Announce" {
+		t.Fatalf("expected merged tasks in memory")
+	}
+}
+
+func TestWriteStopsOnConflictUntilResolved(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	external := mutateFixture()
+	external[1].Status = StatusBlocked
+	external[2].Title = "Ship it"
+	writeExternalTasks(t, svc, external)
+
+	err := svc.SetTaskStatus("2", StatusInProgress)
+	var conflict *MergeConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected MergeConflictError, got %v", err)
+	}
+	if len(conflict.Conflicts) != 1 || conflict.Conflicts[0].TaskID != "2" || conflict.Conflicts[0].Field != "status" {
+		t.Fatalf("expected status conflict on task 2, got %+v", conflict.Conflicts)
+	}
+	if got := conflict.Conflicts[0]; got.Mine != `"in-progress"` || got.Theirs != `"blocked"` {
+		t.Fatalf("unexpected conflict values: %+v", got)
+	}
+	if reloaded, _ := LoadTasksFromFile(svc.RootDir, ""); reloaded[1].Status != StatusBlocked {
+		t.Fatalf("expected conflicting write not to touch tasks.json")
+	}
+	if pending := svc.PendingMergeConflict(); pending == nil || len(pending.Conflicts) != 1 {
+		t.Fatalf("expected pending conflict, got %+v", pending)
+	}
+
+	// An unrelated write goes through without dropping the pending one
+	if err := svc.SetTaskStatus("1", StatusCancelled); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+	if pending := svc.PendingMergeConflict(); pending == nil || len(pending.Conflicts) != 1 {
+		t.Fatalf("expected the conflict still pending after another write, got %+v", pending)
+	}
+
+	if err := svc.ResolveMergeConflict(ctx, MergeKeepMine); err != nil {
+		t.Fatalf("ResolveMergeConflict returned error: %v", err)
+	}
+	reloaded, _ := LoadTasksFromFile(svc.RootDir, "")
+	if reloaded[1].Status != StatusInProgress || reloaded[2].Title != "Ship it" {
+		t.Fatalf("expected own status and external title, got %+v", reloaded)
+	}
+	if reloaded[0].Status != StatusCancelled {
+		t.Fatalf("expected the unrelated write kept, got %s", reloaded[0].Status)
+	}
+	if err := svc.ResolveMergeConflict(ctx, MergeKeepMine); !errors.Is(err, ErrNoPendingMerge) {
+		t.Fatalf("expected ErrNoPendingMerge, got %v", err)
+	}
+}
+
+func TestMergeTasksStructuralChanges(t *testing.T) {
+	base := mutateFixture()
+
+	// Mine: drop 2.3, add 2.4, move 3 before 1
+	mine := mutateFixture()
+	mine[1].Subtasks = append(mine[1].Subtasks[:2], Task{ID: "2.4", Title: "Fourth", Status: StatusPending})
+	mine = []Task{mine[2], mine[0], mine[1]}
+
+	// Theirs: edit 2.3 and add 5
+	theirs := mutateFixture()
+	theirs[1].Subtasks[2].Title = "Third (edited)"
+	theirs = append(theirs, Task{ID: "5", Title: "Extra", Status: StatusPending})
+
+	merged, conflicts := MergeTasks(base, mine, theirs, "")
+	if len(conflicts) != 1 || conflicts[0].TaskID != "2.3" || conflicts[0].Field != deletedTaskField {
+		t.Fatalf("expected delete/modify conflict on 2.3, got %+v", conflicts)
+	}
+	order := [4]string{merged[0].ID, merged[1].ID, merged[2].ID, merged[3].ID}
+	if order != [4]string{"3", "1", "2", "5"} {
+		t.Fatalf("expected own order with external task appended, got %v", order)
+	}
+	var subtasks []string
+	for _, sub := range merged[2].Subtasks {
+		subtasks = append(subtasks, sub.ID)
+	}
+	if len(subtasks) != 4 || subtasks[2] != "2.3" || subtasks[3] != "2.4" {
+		t.Fatalf("expected edited 2.3 kept and 2.4 added, got %v", subtasks)
+	}
+
+	merged, _ = MergeTasks(base, mine, theirs, MergeKeepMine)
+	if len(merged[2].Subtasks) != 3 {
+		t.Fatalf("expected 2.3 deleted when keeping mine, got %+v", merged[2].Subtasks)
+	}
+}
@@ -0,0 +1,586 @@
+package taskmaster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TaskUpdate describes a partial edit of a task. Nil fields are left untouched,
+// so callers only set the fields they intend to change.
+type TaskUpdate struct {
+	Title          *string
+	Description    *string
+	Details        *string
+	TestStrategy   *string
+	Status         *string
+	Priority       *string
+	Dependencies   *[]string
+	Tags           *[]string
+	EstimatedHours *float64
+	ActualHours    *float64
+	Complexity     *int
+	Metadata       map[string]string // merged into existing metadata; empty values remove keys
+}
+
+// IsEmpty reports whether the update would change nothing.
+func (u TaskUpdate) IsEmpty() bool {
+	return u.Title == nil && u.Description == nil && u.Details == nil &&
+		u.TestStrategy == nil && u.Status == nil && u.Priority == nil &&
+		u.Dependencies == nil && u.Tags == nil && u.EstimatedHours == nil &&
+		u.ActualHours == nil && u.Complexity == nil && len(u.Metadata) == 0
+}
+
+// SetTaskStatus sets the status of a task by writing tasks.json directly.
+func (s *Service) SetTaskStatus(taskID, status string) error {
+	_, err := s.UpdateTaskFields(context.Background(), taskID, TaskUpdate{Status: &status})
+	return err
+}
+
+// UpdateTaskFields applies a partial update to a single task and persists it.
+// The returned task is a detached copy of the updated task.
+func (s *Service) UpdateTaskFields(ctx context.Context, taskID string, update TaskUpdate) (*Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	if update.IsEmpty() {
+		return nil, fmt.Errorf("no changes provided for task %s", taskID)
+	}
+
+	var updated *Task
+	err := s.mutateTasksLocked(ctx, updateUndoAction(taskID, update), func(tasks []Task, index map[string]*Task) ([]Task, error) {
+		task, ok := index[taskID]
+		if !ok {
+			return nil, fmt.Errorf("task %s not found", taskID)
+		}
+		if err := applyTaskUpdate(task, update, index); err != nil {
+			return nil, err
+		}
+		task.UpdatedAt = Now()
+		updated = copyTask(task)
+		return tasks, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// AddSubtask appends a new subtask to the given parent and persists it. The
+// subtask ID is derived from the parent ID and its position; any ID on the
+// supplied task is ignored.
+func (s *Service) AddSubtask(ctx context.Context, parentID string, subtask Task) (*Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	if strings.TrimSpace(subtask.Title) == "" {
+		return nil, fmt.Errorf("subtask title is required")
+	}
+
+	var created *Task
+	action := newUndoAction(UndoActionEdit, fmt.Sprintf("Added subtask %q to task %s", subtask.Title, parentID))
+	err := s.mutateTasksLocked(ctx, action, func(tasks []Task, index map[string]*Task) ([]Task, error) {
+		parent, ok := index[parentID]
+		if !ok {
+			return nil, fmt.Errorf("task %s not found", parentID)
+		}
+		now := Now()
+		subtask.ID = generateSubtaskID(parent.ID, len(parent.Subtasks))
+		subtask.ParentID = parent.ID
+		if subtask.Status == "" {
+			subtask.Status = StatusPending
+		}
+		if !subtask.IsValidStatus() {
+			return nil, fmt.Errorf("invalid status: %s", subtask.Status)
+		}
+		if !subtask.IsValidPriority() {
+			return nil, fmt.Errorf("invalid priority: %s", subtask.Priority)
+		}
+		for _, depID := range subtask.Dependencies {
+			if _, ok := index[depID]; !ok {
+				return nil, fmt.Errorf("dependency %s not found", depID)
+			}
+		}
+		if subtask.CreatedAt.IsZero() {
+			subtask.CreatedAt = now
+		}
+		subtask.UpdatedAt = now
+		subtask.Subtasks = nil
+		parent.Subtasks = append(parent.Subtasks, subtask)
+		parent.UpdatedAt = now
+		created = copyTask(&parent.Subtasks[len(parent.Subtasks)-1])
+		return tasks, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// RemoveSubtask removes a subtask (and its descendants) from its parent.
+// Remaining siblings are renumbered and dependency references are rewritten
+// to match; references to removed tasks are dropped.
+func (s *Service) RemoveSubtask(ctx context.Context, subtaskID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return fmt.Errorf("taskmaster not available")
+	}
+
+	action := newUndoAction(UndoActionEdit, fmt.Sprintf("Removed subtask %s", subtaskID))
+	return s.mutateTasksLocked(ctx, action, func(tasks []Task, index map[string]*Task) ([]Task, error) {
+		task, ok := index[subtaskID]
+		if !ok {
+			return nil, fmt.Errorf("task %s not found", subtaskID)
+		}
+		parent := task.Parent
+		if parent == nil {
+			return nil, fmt.Errorf("task %s is not a subtask", subtaskID)
+		}
+
+		removed := make(map[string]*Task)
+		removed[task.ID] = task
+		collectDescendants(task, removed)
+
+		pos := siblingPosition(parent.Subtasks, subtaskID)
+		parent.Subtasks = append(parent.Subtasks[:pos:pos], parent.Subtasks[pos+1:]...)
+		parent.UpdatedAt = Now()
+
+		remap := renumberSubtasks(parent)
+		for id := range removed {
+			remap[id] = ""
+		}
+		rewriteDependencies(tasks, remap)
+		return tasks, nil
+	})
+}
+
+// MoveTask moves a task to the given position among its siblings. Positions
+// outside the sibling range are clamped. Moving a subtask renumbers its
+// siblings and rewrites dependency references accordingly.
+func (s *Service) MoveTask(ctx context.Context, taskID string, position int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return fmt.Errorf("taskmaster not available")
+	}
+
+	action := newUndoAction(UndoActionEdit, fmt.Sprintf("Moved task %s to position %d", taskID, position))
+	return s.mutateTasksLocked(ctx, action, func(tasks []Task, index map[string]*Task) ([]Task, error) {
+		task, ok := index[taskID]
+		if !ok {
+			return nil, fmt.Errorf("task %s not found", taskID)
+		}
+		if task.Parent == nil {
+			return moveSibling(tasks, taskID, position), nil
+		}
+		parent := task.Parent
+		parent.Subtasks = moveSibling(parent.Subtasks, taskID, position)
+		parent.UpdatedAt = Now()
+		rewriteDependencies(tasks, renumberSubtasks(parent))
+		return tasks, nil
+	})
+}
+
+// ApplySubtaskDrafts adds the drafted subtasks to the given parent and
+// persists them. It returns the new subtask IDs in order.
+func (s *Service) ApplySubtaskDrafts(ctx context.Context, parentID string, drafts []SubtaskDraft) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	return s.applySubtaskDraftsLocked(ctx, parentID, drafts)
+}
+
+func (s *Service) applySubtaskDraftsLocked(ctx context.Context, parentID string, drafts []SubtaskDraft) ([]string, error) {
+	if len(drafts) == 0 {
+		return nil, fmt.Errorf("no subtasks to apply")
+	}
+
+	var newIDs []string
+	action := newUndoAction(UndoActionExpand, fmt.Sprintf("Added %d subtask(s) to task %s", len(drafts), parentID))
+	err := s.mutateTasksLocked(ctx, action, func(tasks []Task, index map[string]*Task) ([]Task, error) {
+		parent, ok := index[parentID]
+		if !ok {
+			return nil, fmt.Errorf("task %s not found", parentID)
+		}
+		ids, err := ApplySubtaskDrafts(parent, drafts)
+		if err != nil {
+			return nil, err
+		}
+		parent.UpdatedAt = Now()
+		newIDs = ids
+		return tasks, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newIDs, nil
+}
+
+// AddActualHours adds hours to the ActualHours of a task and of each of its
+// ancestors, so parents roll up the time logged on their subtasks. Hours are
+// stored unrounded so short sessions still add up; round them for display.
+func (s *Service) AddActualHours(ctx context.Context, taskID string, hours float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return fmt.Errorf("taskmaster not available")
+	}
+	if hours <= 0 {
+		return fmt.Errorf("hours must be positive, got %g", hours)
+	}
+
+	action := newUndoAction(UndoActionEdit, fmt.Sprintf("Logged %.2fh on task %s", hours, taskID))
+	return s.mutateTasksLocked(ctx, action, func(tasks []Task, index map[string]*Task) ([]Task, error) {
+		task, ok := index[taskID]
+		if !ok {
+			return nil, fmt.Errorf("task %s not found", taskID)
+		}
+		now := Now()
+		for current := task; current != nil; current = current.Parent {
+			current.ActualHours += hours
+			current.UpdatedAt = now
+		}
+		return tasks, nil
+	})
+}
+
+// SetDependencies replaces the dependency list of a task. Every dependency must
+// exist and the result must not introduce a cycle.
+func (s *Service) SetDependencies(ctx context.Context, taskID string, deps []string) error {
+	_, err := s.UpdateTaskFields(ctx, taskID, TaskUpdate{Dependencies: &deps})
+	return err
+}
+
+// AddDependency makes taskID depend on depID.
+func (s *Service) AddDependency(ctx context.Context, taskID, depID string) error {
+	task, ok := s.GetTaskByID(taskID)
+	if !ok {
+		return fmt.Errorf("task %s not found", taskID)
+	}
+	for _, existing := range task.Dependencies {
+		if existing == depID {
+			return nil
+		}
+	}
+	deps := append(append([]string{}, task.Dependencies...), depID)
+	return s.SetDependencies(ctx, taskID, deps)
+}
+
+// RemoveDependency removes depID from the dependency list of taskID.
+func (s *Service) RemoveDependency(ctx context.Context, taskID, depID string) error {
+	task, ok := s.GetTaskByID(taskID)
+	if !ok {
+		return fmt.Errorf("task %s not found", taskID)
+	}
+	deps := make([]string, 0, len(task.Dependencies))
+	for _, existing := range task.Dependencies {
+		if existing != depID {
+			deps = append(deps, existing)
+		}
+	}
+	if len(deps) == len(task.Dependencies) {
+		return fmt.Errorf("task %s does not depend on %s", taskID, depID)
+	}
+	return s.SetDependencies(ctx, taskID, deps)
+}
+
+// mutateTasksLocked applies fn to a deep copy of the task tree and, when fn
+// succeeds, commits the result with commitTasksLocked. A failed mutation or
+// write leaves the in-memory tasks untouched. Must be called with write lock
+// held.
+func (s *Service) mutateTasksLocked(ctx context.Context, action *UndoAction, fn func(tasks []Task, index map[string]*Task) ([]Task, error)) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	working := cloneTaskTree(s.Tasks)
+	index, _ := buildTaskIndex(working)
+	result, err := fn(working, index)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.commitTasksLocked(result, action)
+	return err
+}
+
+// updateUndoAction describes a TaskUpdate for the undo history.
+func updateUndoAction(taskID string, update TaskUpdate) *UndoAction {
+	rest := update
+	rest.Status = nil
+	if update.Status != nil && rest.IsEmpty() {
+		return newUndoAction(UndoActionStatus, fmt.Sprintf("Set task %s to %s", taskID, *update.Status))
+	}
+	return newUndoAction(UndoActionEdit, fmt.Sprintf("Edited task %s", taskID))
+}
+
+// applyTaskUpdate validates and copies the non-nil fields of update onto task.
+func applyTaskUpdate(task *Task, update TaskUpdate, index map[string]*Task) error {
+	if update.Title != nil {
+		title := strings.TrimSpace(*update.Title)
+		if title == "" {
+			return fmt.Errorf("title cannot be empty")
+		}
+		task.Title = title
+	}
+	if update.Description != nil {
+		task.Description = *update.Description
+	}
+	if update.Details != nil {
+		task.Details = *update.Details
+	}
+	if update.TestStrategy != nil {
+		task.TestStrategy = *update.TestStrategy
+	}
+	if update.Status != nil {
+		probe := Task{Status: *update.Status}
+		if !probe.IsValidStatus() {
+			return fmt.Errorf("invalid status: %s", *update.Status)
+		}
+		task.Status = *update.Status
+	}
+	if update.Priority != nil {
+		probe := Task{Priority: *update.Priority}
+		if !probe.IsValidPriority() {
+			return fmt.Errorf("invalid priority: %s", *update.Priority)
+		}
+		task.Priority = *update.Priority
+	}
+	if update.Dependencies != nil {
+		deps, err := normalizeDependencies(task.ID, *update.Dependencies, index)
+		if err != nil {
+			return err
+		}
+		task.Dependencies = deps
+	}
+	if update.Tags != nil {
+		task.Tags = normalizeTags(*update.Tags)
+	}
+	if update.EstimatedHours != nil {
+		if *update.EstimatedHours < 0 {
+			return fmt.Errorf("estimated hours cannot be negative")
+		}
+		task.EstimatedHours = *update.EstimatedHours
+	}
+	if update.ActualHours != nil {
+		if *update.ActualHours < 0 {
+			return fmt.Errorf("actual hours cannot be negative")
+		}
+		task.ActualHours = *update.ActualHours
+	}
+	if update.Complexity != nil {
+		task.Complexity = *update.Complexity
+	}
+	for key, value := range update.Metadata {
+		if value == "" {
+			delete(task.Metadata, key)
+			continue
+		}
+		if task.Metadata == nil {
+			task.Metadata = make(map[string]string)
+		}
+		task.Metadata[key] = value
+	}
+	return nil
+}
+
+// ValidateTaskUpdate reports whether update can be applied to the task with
+// taskID without saving it. The edited task must pass the same checks as
+// tasks loaded from disk, and new dependencies must not create a cycle.
+// Problems the task already had are not reported, so they do not block
+// unrelated edits. index is not modified.
+func ValidateTaskUpdate(index map[string]*Task, taskID string, update TaskUpdate) error {
+	task, ok := index[taskID]
+	if !ok {
+		return fmt.Errorf("task %s not found", taskID)
+	}
+	original := copyTask(task)
+	original.Subtasks = nil
+	existing := make(map[string]bool)
+	for _, warning := range validateTask(original, index) {
+		existing[warning.Message] = true
+	}
+
+	probe := copyTask(task)
+	probe.Subtasks = nil
+	if err := applyTaskUpdate(probe, update, index); err != nil {
+		return err
+	}
+	var problems []string
+	for _, warning := range validateTask(probe, index) {
+		if !existing[warning.Message] {
+			problems = append(problems, warning.Message)
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("task %s: %s", taskID, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// normalizeDependencies trims and de-duplicates deps, then checks that each one
+// exists, is not the task itself and does not create a cycle.
+func normalizeDependencies(taskID string, deps []string, index map[string]*Task) ([]string, error) {
+	result := make([]string, 0, len(deps))
+	seen := make(map[string]bool)
+	for _, dep := range deps {
+		dep = strings.TrimSpace(dep)
+		if dep == "" || seen[dep] {
+			continue
+		}
+		seen[dep] = true
+		if dep == taskID {
+			return nil, fmt.Errorf("task %s cannot depend on itself", taskID)
+		}
+		if _, ok := index[dep]; !ok {
+			return nil, fmt.Errorf("dependency %s not found", dep)
+		}
+		if dependsOn(index, dep, taskID) {
+			return nil, fmt.Errorf("dependency %s would create a cycle with task %s", dep, taskID)
+		}
+		result = append(result, dep)
+	}
+	return result, nil
+}
+
+// dependsOn reports whether from transitively depends on target.
+func dependsOn(index map[string]*Task, from, target string) bool {
+	visited := make(map[string]bool)
+	stack := []string{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == target {
+			return true
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		if task, ok := index[id]; ok {
+			stack = append(stack, task.Dependencies...)
+		}
+	}
+	return false
+}
+
+func normalizeTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// renumberSubtasks reassigns positional IDs beneath parent and returns a map
+// of old ID -> new ID for every task whose ID changed.
+func renumberSubtasks(parent *Task) map[string]string {
+	remap := make(map[string]string)
+	var walk func(task *Task)
+	walk = func(task *Task) {
+		for i := range task.Subtasks {
+			child := &task.Subtasks[i]
+			expected := generateSubtaskID(task.ID, i)
+			if child.ID != expected {
+				remap[child.ID] = expected
+				child.ID = expected
+			}
+			child.ParentID = task.ID
+			walk(child)
+		}
+	}
+	walk(parent)
+	return remap
+}
+
+// rewriteDependencies updates dependency references using remap. An empty
+// replacement drops the reference.
+func rewriteDependencies(tasks []Task, remap map[string]string) {
+	if len(remap) == 0 {
+		return
+	}
+	for _, task := range flattenTasks(tasks) {
+		if len(task.Dependencies) == 0 {
+			continue
+		}
+		deps := task.Dependencies[:0]
+		for _, dep := range task.Dependencies {
+			if replacement, ok := remap[dep]; ok {
+				if replacement == "" {
+					continue
+				}
+				dep = replacement
+			}
+			deps = append(deps, dep)
+		}
+		task.Dependencies = deps
+	}
+}
+
+func siblingPosition(siblings []Task, id string) int {
+	for i := range siblings {
+		if siblings[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func moveSibling(siblings []Task, id string, position int) []Task {
+	from := siblingPosition(siblings, id)
+	if from < 0 {
+		return siblings
+	}
+	if position < 0 {
+		position = 0
+	}
+	if position >= len(siblings) {
+		position = len(siblings) - 1
+	}
+	if position == from {
+		return siblings
+	}
+	moved := siblings[from]
+	result := make([]Task, 0, len(siblings))
+	result = append(result, siblings[:from]...)
+	result = append(result, siblings[from+1:]...)
+	result = append(result[:position], append([]Task{moved}, result[position:]...)...)
+	return result
+}
+
+// cloneTaskTree returns a deep copy of tasks including all subtasks.
+func cloneTaskTree(tasks []Task) []Task {
+	if tasks == nil {
+		return nil
+	}
+	result := make([]Task, len(tasks))
+	for i := range tasks {
+		result[i] = *copyTask(&tasks[i])
+		result[i].Subtasks = cloneTaskTree(tasks[i].Subtasks)
+	}
+	return result
+}
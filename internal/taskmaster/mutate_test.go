@@ -0,0 +1,345 @@
+package taskmaster
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/config"
+)
+
+func mutateFixture() []Task {
+	return []Task{
+		{ID: "1", Title: "Setup", Status: StatusDone, Priority: PriorityHigh},
+		{ID: "2", Title: "Build", Status: StatusPending, Priority: PriorityMedium, Dependencies: []string{"1"},
+			Subtasks: []Task{
+				{ID: "2.1", Title: "First", Status: StatusPending},
+				{ID: "2.2", Title: "Second", Status: StatusPending, Dependencies: []string{"2.1"}},
+				{ID: "2.3", Title: "Third", Status: StatusPending, Dependencies: []string{"2.2"}},
+			},
+		},
+		{ID: "3", Title: "Ship", Status: StatusPending, Dependencies: []string{"2", "2.3"}},
+	}
+}
+
+func TestSetTaskStatusPersistsNatively(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+
+	if err := svc.SetTaskStatus("2.1", StatusInProgress); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+
+	task, ok := svc.GetTaskByID("2.1")
+	if !ok || task.Status != StatusInProgress {
+		t.Fatalf("expected in-memory status in-progress, got %+v", task)
+	}
+
+	reloaded, err := LoadTasksFromFile(svc.RootDir, "")
+	if err != nil {
+		t.Fatalf("failed to reload tasks: %v", err)
+	}
+	if got := reloaded[1].Subtasks[0].Status; got != StatusInProgress {
+		t.Fatalf("expected persisted status in-progress, got %q", got)
+	}
+
+	if err := svc.SetTaskStatus("2.1", "bogus"); err == nil {
+		t.Fatalf("expected invalid status to be rejected")
+	}
+}
+
+func TestUpdateTaskFieldsAppliesPartialUpdate(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+
+	title := "Build it"
+	priority := PriorityLow
+	hours := 3.5
+	updated, err := svc.UpdateTaskFields(context.Background(), "2", TaskUpdate{
+		Title:          &title,
+		Priority:       &priority,
+		EstimatedHours: &hours,
+		Metadata:       map[string]string{"owner": "sam"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTaskFields returned error: %v", err)
+	}
+	if updated.Title != title || updated.Priority != priority || updated.EstimatedHours != hours {
+		t.Fatalf("unexpected updated task: %+v", updated)
+	}
+	if updated.Status != StatusPending {
+		t.Fatalf("status should be untouched, got %q", updated.Status)
+	}
+	if updated.Metadata["owner"] != "sam" {
+		t.Fatalf("expected metadata to be merged, got %+v", updated.Metadata)
+	}
+
+	empty := "  "
+	if _, err := svc.UpdateTaskFields(context.Background(), "2", TaskUpdate{Title: &empty}); err == nil {
+		t.Fatalf("expected empty title to be rejected")
+	}
+	if _, err := svc.UpdateTaskFields(context.Background(), "2", TaskUpdate{}); err == nil {
+		t.Fatalf("expected empty update to be rejected")
+	}
+}
+
+func TestValidateTaskUpdateChecksWithoutSaving(t *testing.T) {
+	tasks := mutateFixture()
+	index, _ := buildTaskIndex(tasks)
+
+	deps := []string{"1", "2.3"}
+	if err := ValidateTaskUpdate(index, "2.1", TaskUpdate{Dependencies: &deps}); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	priority := "urgent"
+	if err := ValidateTaskUpdate(index, "2", TaskUpdate{Priority: &priority}); err == nil {
+		t.Fatal("expected invalid priority to be rejected")
+	}
+	// A problem the task already had does not block unrelated edits
+	index["3"].Dependencies = append(index["3"].Dependencies, "99")
+	title := "Ship it"
+	if err := ValidateTaskUpdate(index, "3", TaskUpdate{Title: &title}); err != nil {
+		t.Fatalf("expected existing dangling dependency ignored, got %v", err)
+	}
+	status := "shipped"
+	if err := ValidateTaskUpdate(index, "3", TaskUpdate{Title: &title, Status: &status}); err == nil || strings.Contains(err.Error(), "99") {
+		t.Fatalf("expected only the new invalid status reported, got %v", err)
+	}
+
+	deps = []string{"1"}
+	if err := ValidateTaskUpdate(index, "2.1", TaskUpdate{Dependencies: &deps, Title: &title}); err != nil {
+		t.Fatalf("expected valid update, got %v", err)
+	}
+	if index["2.1"].Title != "First" || len(index["2.1"].Dependencies) != 0 {
+		t.Fatalf("expected index untouched, got %+v", index["2.1"])
+	}
+}
+
+func TestSetDependenciesRejectsCyclesAndUnknownIDs(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	if err := svc.AddDependency(ctx, "1", "3"); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	if err := svc.AddDependency(ctx, "1", "1"); err == nil {
+		t.Fatalf("expected self dependency to be rejected")
+	}
+	if err := svc.AddDependency(ctx, "1", "99"); err == nil {
+		t.Fatalf("expected unknown dependency to be rejected")
+	}
+
+	if err := svc.RemoveDependency(ctx, "3", "2"); err != nil {
+		t.Fatalf("RemoveDependency returned error: %v", err)
+	}
+	task, _ := svc.GetTaskByID("3")
+	if len(task.Dependencies) != 1 || task.Dependencies[0] != "2.3" {
+		t.Fatalf("unexpected dependencies after removal: %v", task.Dependencies)
+	}
+}
+
+func TestAddAndRemoveSubtaskRemapsDependencies(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	created, err := svc.AddSubtask(ctx, "2", Task{Title: "Fourth", Dependencies: []string{"2.3"}})
+	if err != nil {
+		t.Fatalf("AddSubtask returned error: %v", err)
+	}
+	if created.ID != "2.4" || created.Status != StatusPending {
+		t.Fatalf("unexpected created subtask: %+v", created)
+	}
+
+	if err := svc.RemoveSubtask(ctx, "2.2"); err != nil {
+		t.Fatalf("RemoveSubtask returned error: %v", err)
+	}
+
+	parent, _ := svc.GetTaskByID("2")
+	if len(parent.Subtasks) != 3 {
+		t.Fatalf("expected 3 subtasks, got %d", len(parent.Subtasks))
+	}
+	third, ok := svc.GetTaskByID("2.2")
+	if !ok || third.Title != "Third" {
+		t.Fatalf("expected Third to be renumbered to 2.2, got %+v", third)
+	}
+	if len(third.Dependencies) != 0 {
+		t.Fatalf("expected dependency on removed subtask to be dropped, got %v", third.Dependencies)
+	}
+	fourth, _ := svc.GetTaskByID("2.3")
+	if fourth.Title != "Fourth" || len(fourth.Dependencies) != 1 || fourth.Dependencies[0] != "2.2" {
+		t.Fatalf("expected Fourth to follow renumbering, got %+v", fourth)
+	}
+	ship, _ := svc.GetTaskByID("3")
+	if len(ship.Dependencies) != 2 || ship.Dependencies[1] != "2.2" {
+		t.Fatalf("expected top-level dependency to be remapped, got %v", ship.Dependencies)
+	}
+
+	if err := svc.RemoveSubtask(ctx, "3"); err == nil {
+		t.Fatalf("expected removing a top-level task to fail")
+	}
+}
+
+func TestMoveTaskReordersSiblings(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	if err := svc.MoveTask(ctx, "3", 0); err != nil {
+		t.Fatalf("MoveTask returned error: %v", err)
+	}
+	tasks, _ := svc.GetTasks()
+	if tasks[0].ID != "3" || tasks[1].ID != "1" {
+		t.Fatalf("unexpected top-level order: %s, %s", tasks[0].ID, tasks[1].ID)
+	}
+
+	if err := svc.MoveTask(ctx, "2.3", 0); err != nil {
+		t.Fatalf("MoveTask returned error: %v", err)
+	}
+	moved, _ := svc.GetTaskByID("2.1")
+	if moved.Title != "Third" {
+		t.Fatalf("expected Third to be first subtask, got %q", moved.Title)
+	}
+	second, _ := svc.GetTaskByID("2.3")
+	if second.Title != "Second" || len(second.Dependencies) != 1 || second.Dependencies[0] != "2.2" {
+		t.Fatalf("expected Second renumbered with remapped deps, got %+v", second)
+	}
+	ship, _ := svc.GetTaskByID("3")
+	if ship.Dependencies[1] != "2.1" {
+		t.Fatalf("expected dependency on Third to follow move, got %v", ship.Dependencies)
+	}
+}
+
+func TestMutationsPreserveTaggedFileLayout(t *testing.T) {
+	tmpDir := t.TempDir()
+	tasksDir := filepath.Join(tmpDir, ".taskmaster", "tasks")
+	if err := os.MkdirAll(tasksDir, 0755); err != nil {
+		t.Fatalf("failed to create tasks dir: %v", err)
+	}
+	payload := map[string]interface{}{
+		"master":  map[string]interface{}{"tasks": mutateFixture()},
+		"feature": map[string]interface{}{"tasks": []Task{{ID: "1", Title: "Other", Status: StatusPending}}},
+	}
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal tasks: %v", err)
+	}
+	tasksPath := filepath.Join(tasksDir, "tasks.json")
+	if err := os.WriteFile(tasksPath, data, 0644); err != nil {
+		t.Fatalf("failed to write tasks: %v", err)
+	}
+	svc, err := NewService(&config.Config{TaskMasterPath: tmpDir})
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	if err := svc.SetTaskStatus("3", StatusBlocked); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+
+	raw, err := os.ReadFile(tasksPath)
+	if err != nil {
+		t.Fatalf("failed to read tasks: %v", err)
+	}
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("failed to decode tasks: %v", err)
+	}
+	if _, ok := decoded["feature"]; !ok {
+		t.Fatalf("expected other tags to be preserved, got keys %v", decoded)
+	}
+
+	entries, err := os.ReadDir(tasksDir)
+	if err != nil {
+		t.Fatalf("failed to list tasks dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected no temp files to remain, found %d entries", len(entries))
+	}
+}
+
+func TestApplySubtaskDraftsAppendsAfterExistingSubtasks(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	drafts := []SubtaskDraft{{Title: "Fourth", Children: []SubtaskDraft{{Title: "Nested"}}}, {Title: "Fifth"}}
+	ids, err := svc.ApplySubtaskDrafts(ctx, "2", drafts)
+	if err != nil {
+		t.Fatalf("ApplySubtaskDrafts returned error: %v", err)
+	}
+	if strings.Join(ids, ",") != "2.4,2.5" {
+		t.Fatalf("expected new IDs 2.4,2.5, got %v", ids)
+	}
+
+	parent, _ := svc.GetTaskByID("2")
+	var got []string
+	for _, sub := range parent.Subtasks {
+		got = append(got, sub.ID)
+	}
+	if strings.Join(got, ",") != "2.1,2.2,2.3,2.4,2.5" {
+		t.Fatalf("expected unique subtask IDs, got %v", got)
+	}
+	if task, ok := svc.GetTaskByID("2.4"); !ok || task.Title != "Fourth" {
+		t.Fatalf("expected 2.4 to be the applied draft, got %+v", task)
+	}
+	if task, ok := svc.GetTaskByID("2.4.1"); !ok || task.Title != "Nested" {
+		t.Fatalf("expected the draft's child as 2.4.1, got %+v", task)
+	}
+	if task, _ := svc.GetTaskByID("2.3"); task.Title != "Third" {
+		t.Fatalf("expected 2.3 to keep its title, got %q", task.Title)
+	}
+}
+
+func TestAddActualHoursRollsUpToParents(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	if err := svc.AddActualHours(ctx, "2.1", 1.25); err != nil {
+		t.Fatalf("AddActualHours returned error: %v", err)
+	}
+	if err := svc.AddActualHours(ctx, "2", 0.5); err != nil {
+		t.Fatalf("AddActualHours on parent returned error: %v", err)
+	}
+	if err := svc.AddActualHours(ctx, "2.2", -1); err == nil {
+		t.Fatalf("expected negative hours to be rejected")
+	}
+
+	reloaded, err := LoadTasksFromFile(svc.RootDir, "")
+	if err != nil {
+		t.Fatalf("failed to reload tasks: %v", err)
+	}
+	if got := reloaded[1].Subtasks[0].ActualHours; got != 1.25 {
+		t.Errorf("expected subtask to record 1.25h, got %v", got)
+	}
+	if got := reloaded[1].ActualHours; got != 1.75 {
+		t.Errorf("expected parent to roll up to 1.75h, got %v", got)
+	}
+	if got := reloaded[1].Subtasks[1].ActualHours; got != 0 {
+		t.Errorf("expected sibling to be untouched, got %v", got)
+	}
+}
+
+func TestAddActualHoursKeepsShortSessions(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	// Ten-second sessions are below a hundredth of an hour each
+	session := (10 * time.Second).Hours()
+	for i := 0; i < 3; i++ {
+		if err := svc.AddActualHours(ctx, "2.1", session); err != nil {
+			t.Fatalf("AddActualHours returned error: %v", err)
+		}
+	}
+
+	reloaded, err := LoadTasksFromFile(svc.RootDir, "")
+	if err != nil {
+		t.Fatalf("failed to reload tasks: %v", err)
+	}
+	want := (30 * time.Second).Hours()
+	for _, got := range []float64{reloaded[1].Subtasks[0].ActualHours, reloaded[1].ActualHours} {
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("expected %vh from three short sessions, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package taskmaster
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/prd"
+)
+
+// ImportPRD writes tasks parsed from a PRD by the local prd parser to the
+// active tag, without the task-master CLI or any network access. In append
+// mode the new top-level tasks are numbered after the highest existing ID
+// and external edits of tasks.json are merged like any other write; in
+// replace mode they replace everything the tag holds on disk, including
+// tasks added externally, starting at 1. The write goes through the service
+// rather than prd.TaskFileManager so it is recorded as one undoable PRD
+// parse and the in-memory tasks stay in step with the file. Every task
+// records a source anchor in its metadata for PlanPRDSync. It returns the
+// new top-level task IDs.
+func (s *Service) ImportPRD(ctx context.Context, source string, nodes []*prd.Node, mode ParsePrdMode) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	if len(nodes) == 0 {
+		return nil, prd.ErrNoTasks
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	startID := 1
+	if mode != ParsePrdModeReplace {
+		for _, task := range s.Tasks {
+			if n, err := strconv.Atoi(task.ID); err == nil && n >= startID {
+				startID = n + 1
+			}
+		}
+	}
+
+	imported, err := parseTasksData(prd.BuildAnchoredTaskDocuments(s.prdSourceKey(source), nodes, startID))
+	if err != nil {
+		return nil, err
+	}
+	action := newUndoAction(UndoActionParsePRD, fmt.Sprintf("Parsed PRD %s offline (%s)", filepath.Base(source), mode))
+	if mode == ParsePrdModeReplace {
+		_, err = s.overwriteTasksLocked(imported, action)
+	} else {
+		tasks := append(append(make([]Task, 0, len(s.Tasks)+len(imported)), s.Tasks...), imported...)
+		_, err = s.commitTasksLocked(tasks, action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(nodes))
+	for i := range nodes {
+		ids[i] = strconv.Itoa(startID + i)
+	}
+	return ids, nil
+}
+
+// prdSourceKey returns the PRD path recorded in source anchors: relative to
+// the project root when the file is inside it, so the anchor survives the
+// project being moved.
+func (s *Service) prdSourceKey(source string) string {
+	if !filepath.IsAbs(source) {
+		return filepath.ToSlash(filepath.Clean(source))
+	}
+	if rel, err := filepath.Rel(s.RootDir, source); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(source)
+}
@@ -0,0 +1,103 @@
+package taskmaster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/prd"
+)
+
+func TestImportPRDAppendsAndUndoes(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	nodes, err := prd.Parse("# Auth\nLogin flow\n- Sign in\n- Sign out\n\n# Billing\n")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	ids, err := svc.ImportPRD(ctx, "docs/prd.md", nodes, ParsePrdModeAppend)
+	if err != nil {
+		t.Fatalf("ImportPRD returned error: %v", err)
+	}
+	if !equalIDs(ids, []string{"4", "5"}) {
+		t.Fatalf("expected new tasks 4 and 5, got %v", ids)
+	}
+
+	auth, ok := svc.GetTaskByID("4")
+	if !ok || auth.Title != "Auth" || auth.Description != "Login flow" || auth.Status != StatusPending {
+		t.Fatalf("expected Auth imported as task 4, got %+v", auth)
+	}
+	if signOut, ok := svc.GetTaskByID("4.2"); !ok || signOut.Title != "Sign out" {
+		t.Fatalf("expected bullets imported as subtasks, got %+v", signOut)
+	}
+	if _, ok := svc.GetTaskByID("1"); !ok {
+		t.Fatalf("expected existing tasks kept in append mode")
+	}
+
+	history := svc.UndoHistory()
+	if len(history.Actions) != 1 || history.Actions[0].Summary != "Parsed PRD prd.md offline (append)" {
+		t.Fatalf("expected one undo entry for the import, got %+v", history.Actions)
+	}
+	if _, err := svc.Undo(ctx); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if _, ok := svc.GetTaskByID("4"); ok {
+		t.Fatalf("expected undo to remove the imported tasks")
+	}
+}
+
+func TestImportPRDMergesExternalEdit(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+
+	external := mutateFixture()
+	external[2].Title = "Ship it"
+	writeExternalTasks(t, svc, external)
+
+	if _, err := svc.ImportPRD(context.Background(), "prd.md", []*prd.Node{{Title: "Billing"}}, ParsePrdModeAppend); err != nil {
+		t.Fatalf("ImportPRD returned error: %v", err)
+	}
+	reloaded, err := LoadTasksFromFile(svc.RootDir, "")
+	if err != nil {
+		t.Fatalf("failed to reload tasks: %v", err)
+	}
+	if len(reloaded) != 4 || reloaded[2].Title != "Ship it" || reloaded[3].Title != "Billing" {
+		t.Fatalf("expected the external edit kept alongside the import, got %+v", reloaded)
+	}
+}
+
+func TestImportPRDReplaces(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+
+	ids, err := svc.ImportPRD(context.Background(), "prd.txt", []*prd.Node{{Title: "Only"}}, ParsePrdModeReplace)
+	if err != nil {
+		t.Fatalf("ImportPRD returned error: %v", err)
+	}
+	tasks, _ := svc.GetTasks()
+	if !equalIDs(ids, []string{"1"}) || len(tasks) != 1 || tasks[0].Title != "Only" {
+		t.Fatalf("expected tasks replaced by Only, got %v %+v", ids, tasks)
+	}
+
+	// Replace also drops tasks added to the file since it was loaded
+	external := append(mutateFixture(), Task{ID: "9", Title: "Added elsewhere", Status: StatusPending})
+	writeExternalTasks(t, svc, external)
+	if _, err := svc.ImportPRD(context.Background(), "prd.txt", []*prd.Node{{Title: "Again"}}, ParsePrdModeReplace); err != nil {
+		t.Fatalf("ImportPRD returned error: %v", err)
+	}
+	reloaded, err := LoadTasksFromFile(svc.RootDir, "")
+	if err != nil {
+		t.Fatalf("failed to reload tasks: %v", err)
+	}
+	if len(reloaded) != 1 || reloaded[0].Title != "Again" {
+		t.Fatalf("expected replace to drop external tasks, got %+v", reloaded)
+	}
+	if _, err := svc.Undo(context.Background()); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if _, ok := svc.GetTaskByID("9"); !ok {
+		t.Fatalf("expected undo to restore the externally added task")
+	}
+
+	if _, err := svc.ImportPRD(context.Background(), "prd.txt", nil, ParsePrdModeAppend); err != prd.ErrNoTasks {
+		t.Fatalf("expected ErrNoTasks for an empty import, got %v", err)
+	}
+}
@@ -0,0 +1,313 @@
+package taskmaster
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/prd"
+)
+
+// PRDSyncKind names a change proposed by a PRD re-sync.
+type PRDSyncKind string
+
+const (
+	// PRDSyncAdd adds a section new to the PRD as a task, or as a subtask of
+	// TaskID.
+	PRDSyncAdd PRDSyncKind = "add"
+	// PRDSyncUpdate rewrites the title, description or test strategy of
+	// TaskID from its revised section.
+	PRDSyncUpdate PRDSyncKind = "update"
+	// PRDSyncOrphan marks TaskID, whose section is gone from the PRD, as
+	// cancelled.
+	PRDSyncOrphan PRDSyncKind = "orphan"
+)
+
+// PRDSyncChange is one change a PRD re-sync proposes.
+type PRDSyncChange struct {
+	Kind    PRDSyncKind
+	TaskID  string        // task updated or orphaned; parent of an added subtask
+	Section string        // section path in the revised PRD, or the recorded one for orphans
+	Title   string        // title of the section or orphaned task
+	Fields  []FieldChange // for updates
+	Node    *prd.Node     // the revised section, for adds and updates
+}
+
+// Describe summarises the change for a review list.
+func (c PRDSyncChange) Describe() string {
+	switch c.Kind {
+	case PRDSyncAdd:
+		if c.TaskID != "" {
+			return fmt.Sprintf("Add subtask to %s: %s", c.TaskID, c.Title)
+		}
+		return fmt.Sprintf("Add task: %s", c.Title)
+	case PRDSyncUpdate:
+		return fmt.Sprintf("Update %s: %s", c.TaskID, c.Title)
+	case PRDSyncOrphan:
+		return fmt.Sprintf("Orphaned %s: %s", c.TaskID, c.Title)
+	}
+	return c.Title
+}
+
+// PRDSyncPlan is the difference between a revised PRD and the tasks that
+// were generated from it.
+type PRDSyncPlan struct {
+	Source    string // PRD path as recorded in the task anchors
+	Changes   []PRDSyncChange
+	Unchanged int // sections whose tasks are up to date
+
+	paths map[*prd.Node]string
+}
+
+// PlanPRDSync compares the sections parsed from a revised PRD with the tasks
+// carrying a source anchor for the same file. Sections are matched to tasks
+// by section path, and renamed sections by content hash under the same
+// parent. Matched sections whose title or content changed since they were
+// last written become updates, new sections become adds and anchored tasks
+// whose section is gone become orphans. Cancelled tasks are not reported as
+// orphans. Nothing is written.
+func (s *Service) PlanPRDSync(source string, nodes []*prd.Node) (*PRDSyncPlan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.available {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	if len(nodes) == 0 {
+		return nil, prd.ErrNoTasks
+	}
+
+	plan := &PRDSyncPlan{Source: s.prdSourceKey(source), paths: prd.SectionPaths(nodes)}
+	var anchored []*Task
+	bySection := make(map[string]*Task)
+	var collect func(tasks []Task)
+	collect = func(tasks []Task) {
+		for i := range tasks {
+			task := &tasks[i]
+			if task.Metadata[prd.AnchorSource] == plan.Source {
+				anchored = append(anchored, task)
+				bySection[task.Metadata[prd.AnchorSection]] = task
+			}
+			collect(task.Subtasks)
+		}
+	}
+	collect(s.Tasks)
+	if len(anchored) == 0 {
+		return nil, fmt.Errorf("no tasks were generated from %s; parse it first", plan.Source)
+	}
+
+	var order []*prd.Node
+	parents := make(map[*prd.Node]*prd.Node)
+	var flatten func(nodes []*prd.Node, parent *prd.Node)
+	flatten = func(nodes []*prd.Node, parent *prd.Node) {
+		for _, node := range nodes {
+			order = append(order, node)
+			parents[node] = parent
+			flatten(node.Children, node)
+		}
+	}
+	flatten(nodes, nil)
+
+	matched := make(map[*prd.Node]*Task)
+	claimed := make(map[*Task]bool)
+	for _, node := range order {
+		if task, ok := bySection[plan.paths[node]]; ok {
+			matched[node] = task
+			claimed[task] = true
+		}
+	}
+	for _, node := range order {
+		if matched[node] != nil || node.Description == "" && node.TestStrategy == "" {
+			continue
+		}
+		parentID := ""
+		if parent := parents[node]; parent != nil {
+			if matched[parent] == nil {
+				continue
+			}
+			parentID = matched[parent].ID
+		}
+		hash := prd.ContentHash(node)
+		for _, task := range anchored {
+			if !claimed[task] && task.ParentID == parentID && task.Metadata[prd.AnchorHash] == hash {
+				matched[node] = task
+				claimed[task] = true
+				break
+			}
+		}
+	}
+
+	for _, node := range order {
+		if task := matched[node]; task != nil {
+			fields := prdSyncFields(task, node, plan.paths[node])
+			if len(fields) == 0 {
+				plan.Unchanged++
+				continue
+			}
+			plan.Changes = append(plan.Changes, PRDSyncChange{
+				Kind:    PRDSyncUpdate,
+				TaskID:  task.ID,
+				Section: plan.paths[node],
+				Title:   node.Title,
+				Fields:  fields,
+				Node:    node,
+			})
+			continue
+		}
+		change := PRDSyncChange{Kind: PRDSyncAdd, Section: plan.paths[node], Title: node.Title, Node: node}
+		if parent := parents[node]; parent != nil {
+			if matched[parent] == nil {
+				// Added along with its parent.
+				continue
+			}
+			change.TaskID = matched[parent].ID
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	for _, task := range anchored {
+		if claimed[task] || task.Status == StatusCancelled {
+			continue
+		}
+		plan.Changes = append(plan.Changes, PRDSyncChange{
+			Kind:    PRDSyncOrphan,
+			TaskID:  task.ID,
+			Section: task.Metadata[prd.AnchorSection],
+			Title:   task.Title,
+		})
+	}
+	return plan, nil
+}
+
+// prdSyncFields lists the fields of task that differ from its revised
+// section. The title is only compared when the section path changed and the
+// text only when the section content changed, so edits made to the task
+// since it was generated are not proposed for reverting.
+func prdSyncFields(task *Task, node *prd.Node, section string) []FieldChange {
+	var fields []FieldChange
+	if task.Metadata[prd.AnchorSection] != section && task.Title != node.Title {
+		fields = append(fields, FieldChange{Field: "title", Old: task.Title, New: node.Title})
+	}
+	if task.Metadata[prd.AnchorHash] != prd.ContentHash(node) {
+		if task.Description != node.Description {
+			fields = append(fields, FieldChange{Field: "description", Old: task.Description, New: node.Description})
+		}
+		if task.TestStrategy != node.TestStrategy {
+			fields = append(fields, FieldChange{Field: "testStrategy", Old: task.TestStrategy, New: node.TestStrategy})
+		}
+	}
+	return fields
+}
+
+// ApplyPRDSync writes the accepted changes of plan as one undoable PRD
+// parse. Updates only touch the proposed fields and the source anchor, so
+// status, priority, dependencies and subtasks are kept; orphans are set to
+// cancelled rather than deleted. Dependencies named in the PRD are not
+// resolved for added tasks.
+func (s *Service) ApplyPRDSync(ctx context.Context, plan *PRDSyncPlan, changes []PRDSyncChange) error {
+	if plan == nil || len(changes) == 0 {
+		return fmt.Errorf("no PRD changes selected")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.available {
+		return fmt.Errorf("taskmaster not available")
+	}
+
+	summary := fmt.Sprintf("Re-synced PRD %s (%d changes)", path.Base(plan.Source), len(changes))
+	if len(changes) == 1 {
+		summary = fmt.Sprintf("Re-synced PRD %s (1 change)", path.Base(plan.Source))
+	}
+	return s.mutateTasksLocked(ctx, newUndoAction(UndoActionParsePRD, summary), func(tasks []Task, index map[string]*Task) ([]Task, error) {
+		now := Now()
+		var adds []PRDSyncChange
+		for _, change := range changes {
+			if change.Kind == PRDSyncAdd {
+				adds = append(adds, change)
+				continue
+			}
+			task, ok := index[change.TaskID]
+			if !ok {
+				return nil, fmt.Errorf("task %s no longer exists; re-sync the PRD again", change.TaskID)
+			}
+			switch change.Kind {
+			case PRDSyncUpdate:
+				for _, field := range change.Fields {
+					switch field.Field {
+					case "title":
+						task.Title = field.New
+					case "description":
+						task.Description = field.New
+					case "testStrategy":
+						task.TestStrategy = field.New
+					}
+				}
+				if task.Metadata == nil {
+					task.Metadata = make(map[string]string)
+				}
+				for key, value := range prd.Anchor(plan.Source, change.Section, change.Node) {
+					task.Metadata[key] = value
+				}
+			case PRDSyncOrphan:
+				task.Status = StatusCancelled
+			default:
+				return nil, fmt.Errorf("unknown PRD sync change %q", change.Kind)
+			}
+			task.UpdatedAt = now
+		}
+
+		// Appending moves tasks in memory, so adds go last and look their
+		// parent up afresh.
+		for _, change := range adds {
+			if change.TaskID == "" {
+				next := 1
+				for _, task := range tasks {
+					if n, err := strconv.Atoi(task.ID); err == nil && n >= next {
+						next = n + 1
+					}
+				}
+				tasks = append(tasks, plan.newTask(change.Node, strconv.Itoa(next), "", now))
+				continue
+			}
+			index, _ = buildTaskIndex(tasks)
+			parent, ok := index[change.TaskID]
+			if !ok {
+				return nil, fmt.Errorf("task %s no longer exists; re-sync the PRD again", change.TaskID)
+			}
+			parent.Subtasks = append(parent.Subtasks, plan.newTask(change.Node, generateSubtaskID(parent.ID, len(parent.Subtasks)), parent.ID, now))
+			parent.UpdatedAt = now
+		}
+		return tasks, nil
+	})
+}
+
+// newTask converts a section added to the PRD, with its subsections, into a
+// task anchored to it.
+func (plan *PRDSyncPlan) newTask(node *prd.Node, id, parentID string, now time.Time) Task {
+	task := Task{
+		ID:           id,
+		Title:        node.Title,
+		Description:  node.Description,
+		TestStrategy: node.TestStrategy,
+		Status:       StatusPending,
+		Priority:     PriorityMedium,
+		Metadata:     prd.Anchor(plan.Source, plan.paths[node], node),
+		ParentID:     parentID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if node.Status != "" {
+		task.Status = node.Status
+	}
+	if node.Priority != "" {
+		task.Priority = node.Priority
+	}
+	for i, child := range node.Children {
+		task.Subtasks = append(task.Subtasks, plan.newTask(child, generateSubtaskID(id, i), id, now))
+	}
+	return task
+}
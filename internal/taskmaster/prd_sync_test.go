@@ -0,0 +1,158 @@
+package taskmaster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/prd"
+)
+
+func TestPRDSyncProposesAndAppliesChanges(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	original, err := prd.Parse("# Auth\nLogin flow\n- Sign in\n- Sign out\n\n# Billing\nInvoices\n\n# Reports\nMonthly totals\n")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if _, err := svc.ImportPRD(ctx, "docs/prd.md", original, ParsePrdModeAppend); err != nil {
+		t.Fatalf("ImportPRD returned error: %v", err)
+	}
+	auth, _ := svc.GetTaskByID("4")
+	if auth.Metadata[prd.AnchorSource] != "docs/prd.md" || auth.Metadata[prd.AnchorSection] != "Auth" || auth.Metadata[prd.AnchorHash] == "" {
+		t.Fatalf("expected a source anchor on the imported task, got %v", auth.Metadata)
+	}
+	if signIn, _ := svc.GetTaskByID("4.1"); signIn.Metadata[prd.AnchorSection] != "Auth > Sign in" {
+		t.Fatalf("expected the subtask anchored to its section path, got %v", signIn.Metadata)
+	}
+	if err := svc.SetTaskStatus("4", StatusInProgress); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+
+	revised, err := prd.Parse("# Auth\nLogin and logout flow\n- Sign in\n- Sign out\n- Reset password\n\n# Payments\nInvoices\n\n# Search\n")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	plan, err := svc.PlanPRDSync("docs/prd.md", revised)
+	if err != nil {
+		t.Fatalf("PlanPRDSync returned error: %v", err)
+	}
+	var got []string
+	for _, change := range plan.Changes {
+		got = append(got, change.Describe())
+	}
+	want := []string{
+		"Update 4: Auth",
+		"Add subtask to 4: Reset password",
+		"Update 5: Payments",
+		"Add task: Search",
+		"Orphaned 6: Reports",
+	}
+	if !equalIDs(got, want) {
+		t.Fatalf("expected changes %v, got %v", want, got)
+	}
+	if plan.Unchanged != 2 {
+		t.Fatalf("expected the two unchanged subtasks counted, got %d", plan.Unchanged)
+	}
+	if fields := plan.Changes[2].Fields; len(fields) != 1 || fields[0].Field != "title" || fields[0].Old != "Billing" {
+		t.Fatalf("expected the renamed section matched by content, got %+v", fields)
+	}
+
+	if err := svc.ApplyPRDSync(ctx, plan, plan.Changes); err != nil {
+		t.Fatalf("ApplyPRDSync returned error: %v", err)
+	}
+	auth, _ = svc.GetTaskByID("4")
+	if auth.Description != "Login and logout flow" || auth.Status != StatusInProgress || len(auth.Subtasks) != 3 {
+		t.Fatalf("expected the description updated with status and subtasks kept, got %+v", auth)
+	}
+	if reset, ok := svc.GetTaskByID("4.3"); !ok || reset.Metadata[prd.AnchorSection] != "Auth > Reset password" {
+		t.Fatalf("expected Reset password added as subtask 4.3, got %+v", reset)
+	}
+	if payments, _ := svc.GetTaskByID("5"); payments.Title != "Payments" || payments.Metadata[prd.AnchorSection] != "Payments" {
+		t.Fatalf("expected Billing renamed with its anchor moved, got %+v", payments)
+	}
+	if reports, _ := svc.GetTaskByID("6"); reports.Status != StatusCancelled {
+		t.Fatalf("expected the orphaned task cancelled, got %s", reports.Status)
+	}
+	if search, ok := svc.GetTaskByID("7"); !ok || search.Title != "Search" {
+		t.Fatalf("expected Search added as task 7, got %+v", search)
+	}
+
+	plan, err = svc.PlanPRDSync("docs/prd.md", revised)
+	if err != nil || len(plan.Changes) != 0 {
+		t.Fatalf("expected nothing left to sync, got %+v (%v)", plan, err)
+	}
+
+	history := svc.UndoHistory()
+	if last := history.Actions[len(history.Actions)-1]; last.Summary != "Re-synced PRD prd.md (5 changes)" {
+		t.Fatalf("expected one undo entry for the re-sync, got %q", last.Summary)
+	}
+}
+
+func TestPlanPRDSyncKeepsTaskEdits(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	nodes, _ := prd.Parse("# Auth\nLogin flow\n")
+	if _, err := svc.ImportPRD(ctx, "prd.md", nodes, ParsePrdModeAppend); err != nil {
+		t.Fatalf("ImportPRD returned error: %v", err)
+	}
+	title, description := "Authentication", "Edited by hand"
+	if _, err := svc.UpdateTaskFields(ctx, "4", TaskUpdate{Title: &title, Description: &description}); err != nil {
+		t.Fatalf("UpdateTaskFields returned error: %v", err)
+	}
+
+	nodes, _ = prd.Parse("# Auth\nLogin flow\n")
+	plan, err := svc.PlanPRDSync("prd.md", nodes)
+	if err != nil {
+		t.Fatalf("PlanPRDSync returned error: %v", err)
+	}
+	if len(plan.Changes) != 0 {
+		t.Fatalf("expected edits to an unchanged section kept, got %+v", plan.Changes)
+	}
+
+	if _, err := svc.PlanPRDSync("other.md", nodes); err == nil {
+		t.Fatalf("expected an error for a PRD with no generated tasks")
+	}
+}
+
+func TestPRDSyncAfterEditedImportHasNoChanges(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+	const content = "# Auth\nLogin flow\n- Sign in\n- Sign out\n\n# Billing\nInvoices\n"
+
+	nodes, err := prd.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	// Edit the tasks as the import dialog allows before writing them
+	prd.MarkSections(nodes)
+	nodes[0].Title = "Authentication"
+	nodes[0].Description = "Login and session handling"
+	nodes[0].Children[1].Title = "Log out"
+	if _, err := svc.ImportPRD(ctx, "docs/prd.md", nodes, ParsePrdModeAppend); err != nil {
+		t.Fatalf("ImportPRD returned error: %v", err)
+	}
+	if auth, _ := svc.GetTaskByID("4"); auth.Title != "Authentication" || auth.Metadata[prd.AnchorSection] != "Auth" {
+		t.Fatalf("expected the edited task anchored to its parsed section, got %+v", auth)
+	}
+
+	unchanged, err := prd.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	plan, err := svc.PlanPRDSync("docs/prd.md", unchanged)
+	if err != nil {
+		t.Fatalf("PlanPRDSync returned error: %v", err)
+	}
+	if len(plan.Changes) != 0 {
+		var got []string
+		for _, change := range plan.Changes {
+			got = append(got, change.Describe())
+		}
+		t.Fatalf("expected no changes for an unchanged PRD, got %v", got)
+	}
+	if plan.Unchanged != 4 {
+		t.Fatalf("expected all four sections unchanged, got %d", plan.Unchanged)
+	}
+}
@@ -0,0 +1,405 @@
+package taskmaster
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QuerySyntax summarizes the task query language for help text and
+// placeholders.
+const QuerySyntax = `status:pending priority:>=high tag:api dep:12 complexity:>7 -status:done "free text"`
+
+// QueryError reports a syntax error in a task query. Pos is the byte offset
+// of the offending term in the query string.
+type QueryError struct {
+	Pos int
+	Msg string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s (column %d)", e.Msg, e.Pos+1)
+}
+
+// Query is a parsed task query. A task matches when it matches every term.
+// The zero value and a nil *Query match every task.
+type Query struct {
+	terms []queryTerm
+}
+
+// queryTerm is one condition of a query, optionally negated.
+type queryTerm struct {
+	negate bool
+	match  func(task *Task) bool
+}
+
+// queryOp is the comparison in a field term.
+type queryOp string
+
+const (
+	opMatch queryOp = ":"
+	opEqual queryOp = "="
+	opLess  queryOp = "<"
+	opLE    queryOp = "<="
+	opMore  queryOp = ">"
+	opGE    queryOp = ">="
+)
+
+// queryField builds the predicate for one field term.
+type queryField func(op queryOp, values []string) (func(task *Task) bool, error)
+
+// queryFields maps field names and their aliases to predicate builders.
+var queryFields = map[string]queryField{
+	"status":     statusQuery,
+	"s":          statusQuery,
+	"priority":   priorityQuery,
+	"p":          priorityQuery,
+	"tag":        tagQuery,
+	"dep":        depQuery,
+	"depends":    depQuery,
+	"id":         idQuery,
+	"title":      titleQuery,
+	"complexity": numberQuery(func(t *Task) float64 { return float64(t.Complexity) }),
+	"cx":         numberQuery(func(t *Task) float64 { return float64(t.Complexity) }),
+	"estimate":   numberQuery(func(t *Task) float64 { return t.EstimatedHours }),
+	"est":        numberQuery(func(t *Task) float64 { return t.EstimatedHours }),
+	"actual":     numberQuery(func(t *Task) float64 { return t.ActualHours }),
+}
+
+// ParseQuery parses a task query such as
+//
+//	status:pending,in-progress priority:>=high tag:api -dep:3 "login page"
+//
+// Terms are separated by spaces and must all match. field:value matches a
+// field, with comma-separated values matching any of them; priority, id,
+// complexity, estimate and actual also accept :>, :>=, :<, :<= and :=. A
+// leading '-' negates a term. Anything else is free text matched
+// case-insensitively against the ID, title and description, with double
+// quotes grouping words. Fields are status (s), priority (p), tag, dep
+// (depends), id, title, complexity (cx), estimate (est) and actual.
+func ParseQuery(input string) (*Query, error) {
+	query := &Query{}
+	pos := 0
+	for {
+		for pos < len(input) && isQuerySpace(input[pos]) {
+			pos++
+		}
+		if pos >= len(input) {
+			return query, nil
+		}
+		term, next, err := parseQueryTerm(input, pos)
+		if err != nil {
+			return nil, err
+		}
+		query.terms = append(query.terms, term)
+		pos = next
+	}
+}
+
+// parseQueryTerm parses the term starting at start and returns it with the
+// offset just past it.
+func parseQueryTerm(input string, start int) (queryTerm, int, error) {
+	var term queryTerm
+	pos := start
+	if input[pos] == '-' && pos+1 < len(input) && !isQuerySpace(input[pos+1]) {
+		term.negate = true
+		pos++
+	}
+
+	if input[pos] == '"' {
+		text, next, err := readQuoted(input, pos)
+		if err != nil {
+			return term, 0, err
+		}
+		term.match = textQuery(text)
+		return term, next, nil
+	}
+
+	nameEnd := pos
+	for nameEnd < len(input) && isQueryFieldChar(input[nameEnd]) {
+		nameEnd++
+	}
+	if nameEnd == pos || nameEnd >= len(input) || input[nameEnd] != ':' {
+		word, next := readWord(input, pos)
+		term.match = textQuery(word)
+		return term, next, nil
+	}
+
+	name := strings.ToLower(input[pos:nameEnd])
+	field, ok := queryFields[name]
+	if !ok {
+		// Not a field, so a word that happens to contain a colon
+		word, next := readWord(input, pos)
+		term.match = textQuery(word)
+		return term, next, nil
+	}
+
+	pos = nameEnd + 1
+	op := opMatch
+	for _, candidate := range []queryOp{opGE, opLE, opMore, opLess, opEqual} {
+		if strings.HasPrefix(input[pos:], string(candidate)) {
+			op = candidate
+			pos += len(candidate)
+			break
+		}
+	}
+
+	values, next, err := readQueryValues(input, pos)
+	if err != nil {
+		return term, 0, err
+	}
+	if len(values) == 0 {
+		return term, 0, &QueryError{Pos: start, Msg: fmt.Sprintf("missing value for %s", name)}
+	}
+	if len(values) > 1 && op != opMatch && op != opEqual {
+		return term, 0, &QueryError{Pos: start, Msg: fmt.Sprintf("%s:%s takes a single value", name, op)}
+	}
+
+	match, err := field(op, values)
+	if err != nil {
+		return term, 0, &QueryError{Pos: start, Msg: fmt.Sprintf("%s: %v", name, err)}
+	}
+	term.match = match
+	return term, next, nil
+}
+
+// readQuoted reads a double-quoted string starting at the opening quote.
+func readQuoted(input string, start int) (string, int, error) {
+	end := strings.IndexByte(input[start+1:], '"')
+	if end < 0 {
+		return "", 0, &QueryError{Pos: start, Msg: "unterminated quote"}
+	}
+	return input[start+1 : start+1+end], start + end + 2, nil
+}
+
+// readWord reads up to the next space.
+func readWord(input string, start int) (string, int) {
+	end := start
+	for end < len(input) && !isQuerySpace(input[end]) {
+		end++
+	}
+	return input[start:end], end
+}
+
+func isQuerySpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+func isQueryFieldChar(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b == '_'
+}
+
+// readQueryValues reads the comma-separated values of a field term up to the
+// next space outside quotes. Values are lowercased and empty ones dropped.
+func readQueryValues(input string, start int) ([]string, int, error) {
+	var values []string
+	var current strings.Builder
+	flush := func() {
+		if value := strings.TrimSpace(current.String()); value != "" {
+			values = append(values, strings.ToLower(value))
+		}
+		current.Reset()
+	}
+	pos := start
+	for pos < len(input) && !isQuerySpace(input[pos]) {
+		switch input[pos] {
+		case '"':
+			text, next, err := readQuoted(input, pos)
+			if err != nil {
+				return nil, 0, err
+			}
+			current.WriteString(text)
+			pos = next
+		case ',':
+			flush()
+			pos++
+		default:
+			current.WriteByte(input[pos])
+			pos++
+		}
+	}
+	flush()
+	return values, pos, nil
+}
+
+// IsEmpty reports whether the query has no terms and so matches every task.
+func (q *Query) IsEmpty() bool {
+	return q == nil || len(q.terms) == 0
+}
+
+// Match reports whether task satisfies every term of the query. Subtasks
+// are not consulted.
+func (q *Query) Match(task *Task) bool {
+	if q == nil {
+		return true
+	}
+	for _, term := range q.terms {
+		if term.match(task) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func textQuery(text string) func(task *Task) bool {
+	lower := strings.ToLower(text)
+	return func(task *Task) bool {
+		return strings.Contains(strings.ToLower(task.ID), lower) ||
+			strings.Contains(strings.ToLower(task.Title), lower) ||
+			strings.Contains(strings.ToLower(task.Description), lower)
+	}
+}
+
+func statusQuery(op queryOp, values []string) (func(task *Task) bool, error) {
+	if op != opMatch && op != opEqual {
+		return nil, fmt.Errorf("cannot compare statuses with %s", op)
+	}
+	for _, value := range values {
+		if probe := (Task{Status: value}); !probe.IsValidStatus() {
+			return nil, fmt.Errorf("invalid status %q", value)
+		}
+	}
+	return func(task *Task) bool {
+		return containsFold(values, task.Status)
+	}, nil
+}
+
+// priorityQuery compares priorities in the order low < medium < high <
+// critical, treating an unset priority as medium.
+func priorityQuery(op queryOp, values []string) (func(task *Task) bool, error) {
+	for _, value := range values {
+		if probe := (Task{Priority: value}); value == "" || !probe.IsValidPriority() {
+			return nil, fmt.Errorf("invalid priority %q", value)
+		}
+	}
+	if op == opMatch || op == opEqual {
+		return func(task *Task) bool {
+			priority := task.Priority
+			if priority == "" {
+				priority = PriorityMedium
+			}
+			return containsFold(values, priority)
+		}, nil
+	}
+	want := priorityRank(values[0])
+	return func(task *Task) bool {
+		return compareQuery(op, float64(priorityRank(strings.ToLower(task.Priority))), float64(want))
+	}, nil
+}
+
+func tagQuery(op queryOp, values []string) (func(task *Task) bool, error) {
+	if op != opMatch && op != opEqual {
+		return nil, fmt.Errorf("cannot compare tags with %s", op)
+	}
+	return func(task *Task) bool {
+		for _, tag := range task.Tags {
+			if containsFold(values, tag) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func depQuery(op queryOp, values []string) (func(task *Task) bool, error) {
+	if op != opMatch && op != opEqual {
+		return nil, fmt.Errorf("cannot compare dependencies with %s", op)
+	}
+	return func(task *Task) bool {
+		for _, dep := range task.Dependencies {
+			if containsFold(values, dep) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// idQuery matches a task or any of its subtasks with ':', the exact ID with
+// ':=' and orders IDs like CompareTaskIDs otherwise.
+func idQuery(op queryOp, values []string) (func(task *Task) bool, error) {
+	switch op {
+	case opMatch:
+		return func(task *Task) bool {
+			for _, value := range values {
+				if task.ID == value || strings.HasPrefix(task.ID, value+".") {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case opEqual:
+		return func(task *Task) bool {
+			return containsFold(values, task.ID)
+		}, nil
+	}
+	want := values[0]
+	return func(task *Task) bool {
+		return compareQuery(op, float64(CompareTaskIDs(task.ID, want)), 0)
+	}, nil
+}
+
+func titleQuery(op queryOp, values []string) (func(task *Task) bool, error) {
+	if op != opMatch {
+		return nil, fmt.Errorf("cannot compare titles with %s", op)
+	}
+	return func(task *Task) bool {
+		title := strings.ToLower(task.Title)
+		for _, value := range values {
+			if strings.Contains(title, value) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// numberQuery builds a numeric field whose ':' means equality.
+func numberQuery(value func(task *Task) float64) queryField {
+	return func(op queryOp, values []string) (func(task *Task) bool, error) {
+		wants := make([]float64, 0, len(values))
+		for _, raw := range values {
+			want, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a number", raw)
+			}
+			wants = append(wants, want)
+		}
+		if op == opMatch {
+			op = opEqual
+		}
+		return func(task *Task) bool {
+			got := value(task)
+			for _, want := range wants {
+				if compareQuery(op, got, want) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+}
+
+func compareQuery(op queryOp, got, want float64) bool {
+	switch op {
+	case opLess:
+		return got < want
+	case opLE:
+		return got <= want
+	case opMore:
+		return got > want
+	case opGE:
+		return got >= want
+	default:
+		return got == want
+	}
+}
+
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package taskmaster
+
+import (
+	"errors"
+	"testing"
+)
+
+func queryFixture() []*Task {
+	return []*Task{
+		{ID: "1", Title: "Set up API", Status: StatusDone, Priority: PriorityHigh, Tags: []string{"api"}, Complexity: 3},
+		{ID: "2", Title: "Login page", Description: "OAuth flow per RFC:6749", Status: StatusPending, Priority: PriorityCritical, Dependencies: []string{"1"}, Tags: []string{"ui", "api"}, Complexity: 8},
+		{ID: "2.1", Title: "Form", Status: StatusInProgress, Priority: PriorityLow, Dependencies: []string{"2"}, EstimatedHours: 2},
+		{ID: "12", Title: "Docs", Status: StatusPending, Dependencies: []string{"12.1"}, Complexity: 5},
+	}
+}
+
+func matchingIDs(t *testing.T, input string) []string {
+	t.Helper()
+	query, err := ParseQuery(input)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q) returned error: %v", input, err)
+	}
+	var ids []string
+	for _, task := range queryFixture() {
+		if query.Match(task) {
+			ids = append(ids, task.ID)
+		}
+	}
+	return ids
+}
+
+func TestParseQueryMatchesTasks(t *testing.T) {
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"1", "2", "2.1", "12"}},
+		{"status:pending", []string{"2", "12"}},
+		{"status:pending,in-progress -dep:1", []string{"2.1", "12"}},
+		{"priority:>=high", []string{"1", "2"}},
+		{"p:<medium", []string{"2.1"}},
+		{"priority:medium", []string{"12"}},
+		{"-p:=medium", []string{"1", "2", "2.1"}},
+		{"tag:API", []string{"1", "2"}},
+		{"dep:1", []string{"2"}},
+		{"complexity:>7", []string{"2"}},
+		{"cx:3,5", []string{"1", "12"}},
+		{"est:>=2", []string{"2.1"}},
+		{"id:2", []string{"2", "2.1"}},
+		{"id:=2", []string{"2"}},
+		{"id:>2", []string{"2.1", "12"}},
+		{"-status:done tag:api", []string{"2"}},
+		{`"login page"`, []string{"2"}},
+		{"oauth", []string{"2"}},
+		{"rfc:6749", []string{"2"}},
+		{"-rfc:6749 status:pending", []string{"12"}},
+		{"colour:red", nil},
+		{`-"page" title:"set up",docs`, []string{"1", "12"}},
+		{"status:pending priority:>=high tag:api dep:1 complexity:>7 -status:done", []string{"2"}},
+	}
+	for _, tc := range cases {
+		got := matchingIDs(t, tc.query)
+		if len(got) != len(tc.want) {
+			t.Errorf("%q: expected %v, got %v", tc.query, tc.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%q: expected %v, got %v", tc.query, tc.want, got)
+				break
+			}
+		}
+	}
+}
+
+func TestParseQueryReportsSyntaxErrors(t *testing.T) {
+	cases := []struct {
+		query string
+		pos   int
+	}{
+		{"status:finished", 0},
+		{"tag:api status:red", 8},
+		{"priority:>high,low", 0},
+		{"complexity:>lots", 0},
+		{`title:"open`, 6},
+		{"status:>pending", 0},
+		{"dep:", 0},
+	}
+	for _, tc := range cases {
+		_, err := ParseQuery(tc.query)
+		var queryErr *QueryError
+		if !errors.As(err, &queryErr) {
+			t.Errorf("%q: expected QueryError, got %v", tc.query, err)
+			continue
+		}
+		if queryErr.Pos != tc.pos {
+			t.Errorf("%q: expected error at %d, got %d (%v)", tc.query, tc.pos, queryErr.Pos, err)
+		}
+	}
+}
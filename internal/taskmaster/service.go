@@ -3,6 +3,7 @@ package taskmaster
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"os"
@@ -15,7 +16,9 @@ import (
 	"time"
 
 	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/git"
 	"github.com/agreen757/tm-tui/internal/projects"
+	"github.com/agreen757/tm-tui/internal/timetrack"
 )
 
 // Service handles Task Master integration with thread-safe access
@@ -37,6 +40,13 @@ type Service struct {
 	
 	// lastModTime tracks the last modification time of tasks.json for caching
 	lastModTime time.Time
+
+	// fileHash is the content hash of tasks.json as last loaded or written;
+	// a mismatch on write means the file was changed externally
+	fileHash [32]byte
+
+	// pendingMerge holds a write stopped by conflicting external changes
+	pendingMerge *pendingMerge
 	
 	// available indicates whether a .taskmaster directory was found
 	available bool
@@ -46,6 +56,25 @@ type Service struct {
 	
 	// reloadChan signals when tasks should be reloaded
 	reloadChan chan struct{}
+
+	// undo holds the bounded undo/redo history of task changes
+	undo *UndoManager
+
+	// latestReports caches the last complexity report per tag
+	latestReports map[string]*ComplexityReport
+
+	// reportsMu protects latestReports independently of task data
+	reportsMu sync.Mutex
+
+	// complexityConfig holds scoring overrides; replaced on config reload
+	complexityConfig config.ComplexityConfig
+
+	// timer tracks time spent on tasks; nil when taskmaster is unavailable
+	timer *timetrack.Tracker
+
+	// repo links tasks to the project's git history; nil when taskmaster
+	// is unavailable
+	repo *git.Repo
 	
 	// mu protects concurrent access to task data
 	mu sync.RWMutex
@@ -82,6 +111,10 @@ func NewService(cfg *config.Config) (*Service, error) {
 		TaskIndex:  make(map[string]*Task),
 		available:  false,
 		reloadChan: make(chan struct{}, 1),
+		undo:       NewUndoManager("", DefaultUndoLimit),
+
+		latestReports:    make(map[string]*ComplexityReport),
+		complexityConfig: cfg.Complexity,
 	}
 	
 	// Try to detect taskmaster root
@@ -109,6 +142,9 @@ func NewService(cfg *config.Config) (*Service, error) {
 	
 	svc.RootDir = rootDir
 	svc.available = true
+	svc.timer = timetrack.ForProject(rootDir, cfg.TimeTracking.IdleTimeout())
+	svc.repo = git.NewRepo(rootDir, cfg.Git.BranchPrefix, cfg.Git.MainBranch)
+	svc.undo = NewUndoManager(filepath.Join(rootDir, ".taskmaster", "history"), DefaultUndoLimit)
 	
 	// Load tasks initially
 	ctx := context.Background()
@@ -153,6 +189,12 @@ func (s *Service) LoadTasks(ctx context.Context) error {
 		return nil
 	}
 	
+	return s.reloadTasksLocked()
+}
+
+// reloadTasksLocked reads tasks.json for the active tag unconditionally.
+// Must be called with write lock held.
+func (s *Service) reloadTasksLocked() error {
 	// Get the active tag from config, default to "master"
 	tag := s.config.ActiveTag
 	if tag == "" {
@@ -160,13 +202,20 @@ func (s *Service) LoadTasks(ctx context.Context) error {
 	}
 	
 	// Load tasks from file with the specified tag
-	tasks, err := LoadTasksFromFile(s.RootDir, tag)
+	data, err := os.ReadFile(s.tasksFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to read tasks file: %w", err)
+	}
+	tasks, err := parseTasksFile(data, tag)
 	if err != nil {
 		return err
 	}
 	
 	s.Tasks = tasks
-	s.lastModTime = modTime
+	s.fileHash = sha256.Sum256(data)
+	if info, err := os.Stat(s.tasksFilePath()); err == nil {
+		s.lastModTime = info.ModTime()
+	}
 	
 	// Rebuild index and validate
 	s.rebuildIndexAndValidate()
@@ -243,39 +292,28 @@ func (s *Service) GetTask(id string) (*Task, error) {
 	return task, nil
 }
 
-// GetNextTask finds the next available task to work on.
-// A task is available if it has status "pending" and all its dependencies are complete.
+// GetNextTask returns the highest ranked task that is ready to work on: a
+// pending task whose dependencies are complete, preferring higher priority and
+// then tasks that unblock the most remaining work.
+// Thread-safe for concurrent access.
 func (s *Service) GetNextTask() (*Task, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	
-	for i := range s.Tasks {
-		if task := s.findNextTask(&s.Tasks[i]); task != nil {
-			return task, nil
-		}
+
+	ready := NewDependencyGraph(s.TaskIndex).ReadyTasks()
+	if len(ready) == 0 {
+		return nil, fmt.Errorf("no available tasks found")
 	}
-	return nil, fmt.Errorf("no available tasks found")
+	return ready[0], nil
 }
 
-// findNextTask recursively finds the next pending task with no pending dependencies.
-// Must be called with read lock held.
-func (s *Service) findNextTask(task *Task) *Task {
-	// Check if this task is available
-	if task.Status == StatusPending {
-		// Check if dependencies are satisfied
-		if !task.HasBlockedDependencies(s.TaskIndex) {
-			return task
-		}
-	}
-	
-	// Check subtasks
-	for i := range task.Subtasks {
-		if found := s.findNextTask(&task.Subtasks[i]); found != nil {
-			return found
-		}
-	}
-	
-	return nil
+// DependencyGraph builds a dependency graph over the current task index.
+// Thread-safe for concurrent access.
+func (s *Service) DependencyGraph() *DependencyGraph {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return NewDependencyGraph(s.TaskIndex)
 }
 
 // GetTaskCount returns count of tasks by status.
@@ -405,6 +443,21 @@ func (s *Service) ActiveProjectMetadata() *projects.Metadata {
 	return nil
 }
 
+// ScoringProfile returns the scoring profile used for the active tag.
+func (s *Service) ScoringProfile() ScoringProfile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewScoringProfile(s.complexityConfig, s.activeTag())
+}
+
+// SetComplexityConfig replaces the scoring overrides used by later analyses.
+// Callers pass the reloaded configuration when config files change.
+func (s *Service) SetComplexityConfig(cfg config.ComplexityConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.complexityConfig = cfg
+}
+
 // AnalyzeComplexity performs complexity analysis on tasks
 func (s *Service) AnalyzeComplexity(ctx context.Context, scope string, taskID string, tags []string) (*ComplexityReport, error) {
 	s.mu.RLock()
@@ -419,8 +472,14 @@ func (s *Service) AnalyzeComplexity(ctx context.Context, scope string, taskID st
 		tasksToAnalyze = append(tasksToAnalyze, &s.Tasks[i])
 	}
 	
-	complexities := AnalyzeComplexity(tasksToAnalyze)
+	profile := NewScoringProfile(s.complexityConfig, s.activeTag())
+	complexities := AnalyzeComplexityWithProfile(tasksToAnalyze, &profile)
 	report := NewComplexityReport(complexities, scope, tags)
+	report.Tag = s.activeTag()
+	report.Scoring = &profile
+	if err := s.storeComplexityReport(report); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to persist complexity report: %v\n", err)
+	}
 	return report, nil
 }
 
@@ -445,20 +504,17 @@ func (s *Service) AnalyzeComplexityWithProgress(ctx context.Context, scope strin
 		})
 	}
 	
-	complexities := AnalyzeComplexity(tasksToAnalyze)
+	profile := NewScoringProfile(s.complexityConfig, s.activeTag())
+	complexities := AnalyzeComplexityWithProfile(tasksToAnalyze, &profile)
 	report := NewComplexityReport(complexities, scope, tags)
+	report.Tag = s.activeTag()
+	report.Scoring = &profile
+	if err := s.storeComplexityReport(report); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to persist complexity report: %v\n", err)
+	}
 	return report, nil
 }
 
-// GetLatestComplexityReport returns the latest cached complexity report
-func (s *Service) GetLatestComplexityReport() *ComplexityReport {
-	return nil
-}
-
-// ExportComplexityReport exports a complexity report in the specified format
-func (s *Service) ExportComplexityReport(ctx context.Context, format string, outputPath string) (string, error) {
-	return "", fmt.Errorf("not implemented")
-}
 
 // ParsePRDWithProgress parses a PRD file and generates tasks with progress reporting
 func (s *Service) ParsePRDWithProgress(ctx context.Context, inputPath string, mode ParsePrdMode, onProgress func(ParsePrdProgressState)) error {
@@ -474,6 +530,8 @@ func (s *Service) ParsePRDWithProgress(ctx context.Context, inputPath string, mo
 		args = append(args, "--append")
 	}
 
+	before := s.tasksFileSnapshot()
+
 	// Execute command with streaming output
 	cmd := exec.CommandContext(ctx, "task-master", args...)
 	cmd.Dir = s.RootDir
@@ -560,6 +618,8 @@ func (s *Service) ParsePRDWithProgress(ctx context.Context, inputPath string, mo
 		})
 	}
 
+	s.recordCommandChange(newUndoAction(UndoActionParsePRD, fmt.Sprintf("Parsed PRD %s (%s)", filepath.Base(inputPath), mode)), before)
+
 	// Reload tasks after parsing
 	reloadCtx := context.WithValue(context.Background(), "force", true)
 	return s.LoadTasks(reloadCtx)
@@ -598,8 +658,7 @@ func (s *Service) ExpandTaskWithProgress(ctx context.Context, taskID string, opt
 		})
 	}
 	
-	_, err := ApplySubtaskDrafts(task, drafts)
-	if err != nil {
+	if _, err := s.applySubtaskDraftsLocked(ctx, taskID, drafts); err != nil {
 		return fmt.Errorf("failed to apply subtasks: %w", err)
 	}
 	
@@ -683,6 +742,8 @@ func (s *Service) ExecuteExpandWithProgress(
 		args = append(args, "--force")
 	}
 
+	before := s.tasksFileSnapshot()
+
 	// Execute command with streaming output
 	cmd := exec.CommandContext(ctx, "task-master", args...)
 	cmd.Dir = s.RootDir
@@ -770,11 +831,27 @@ func (s *Service) ExecuteExpandWithProgress(
 		})
 	}
 
+	s.recordCommandChange(newUndoAction(UndoActionExpand, expandScopeSummary(scope, taskID, fromID, toID, tags)), before)
+
 	// Reload tasks after expansion
 	reloadCtx := context.WithValue(context.Background(), "force", true)
 	return s.LoadTasks(reloadCtx)
 }
 
+// expandScopeSummary describes a CLI expansion for the undo history.
+func expandScopeSummary(scope, taskID, fromID, toID string, tags []string) string {
+	switch scope {
+	case "single":
+		return fmt.Sprintf("Expanded task %s", taskID)
+	case "range":
+		return fmt.Sprintf("Expanded tasks %s to %s", fromID, toID)
+	case "tag":
+		return fmt.Sprintf("Expanded tasks tagged %s", strings.Join(tags, ", "))
+	default:
+		return "Expanded all tasks"
+	}
+}
+
 // parseExpandProgress parses progress information from CLI output
 func parseExpandProgress(line string) ExpandProgressState {
 	state := ExpandProgressState{}
@@ -0,0 +1,127 @@
+package taskmaster
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SortMode orders tasks within each level of the task tree.
+type SortMode string
+
+const (
+	// SortFile keeps the order of tasks.json.
+	SortFile SortMode = ""
+	// SortPriority puts critical tasks first.
+	SortPriority SortMode = "priority"
+	// SortStatus puts active work first: in-progress, pending, blocked,
+	// deferred, done, cancelled.
+	SortStatus SortMode = "status"
+	// SortComplexity puts the most complex tasks first.
+	SortComplexity SortMode = "complexity"
+	// SortID orders dotted IDs numerically, so 2 comes before 10.
+	SortID SortMode = "id"
+	// SortUpdated puts the most recently updated tasks first.
+	SortUpdated SortMode = "updated"
+	// SortEstimate puts the largest estimates first.
+	SortEstimate SortMode = "estimate"
+)
+
+// SortModes lists every sort mode in the order the UI cycles through them.
+var SortModes = []SortMode{SortFile, SortPriority, SortStatus, SortComplexity, SortID, SortUpdated, SortEstimate}
+
+// statusSortRank orders statuses for SortStatus; unknown statuses sort last.
+var statusSortRank = map[string]int{
+	StatusInProgress: 0,
+	StatusPending:    1,
+	StatusBlocked:    2,
+	StatusDeferred:   3,
+	StatusDone:       4,
+	StatusCancelled:  5,
+}
+
+// ParseSortMode validates a sort mode name; "" and "file" mean file order.
+func ParseSortMode(name string) (SortMode, error) {
+	if name == "file" {
+		return SortFile, nil
+	}
+	for _, mode := range SortModes {
+		if string(mode) == name {
+			return mode, nil
+		}
+	}
+	return SortFile, fmt.Errorf("unknown sort mode %q", name)
+}
+
+// Label returns a short human-readable name for the mode.
+func (m SortMode) Label() string {
+	switch m {
+	case SortFile:
+		return "file order"
+	case SortID:
+		return "ID"
+	case SortUpdated:
+		return "recently updated"
+	default:
+		return string(m)
+	}
+}
+
+// SortTaskTree returns a copy of tasks with each level of the tree sorted by
+// mode, so subtasks stay under their parents. Ties keep ID order. tasks is
+// not modified; SortFile returns it unchanged.
+func SortTaskTree(tasks []Task, mode SortMode) []Task {
+	if mode == SortFile || len(tasks) == 0 {
+		return tasks
+	}
+	sorted := make([]Task, len(tasks))
+	copy(sorted, tasks)
+	for i := range sorted {
+		sorted[i].Subtasks = SortTaskTree(sorted[i].Subtasks, mode)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if c := compareForSort(&sorted[i], &sorted[j], mode); c != 0 {
+			return c < 0
+		}
+		return CompareTaskIDs(sorted[i].ID, sorted[j].ID) < 0
+	})
+	return sorted
+}
+
+// compareForSort compares two tasks by the primary key of mode.
+func compareForSort(a, b *Task, mode SortMode) int {
+	switch mode {
+	case SortPriority:
+		return compareInts(priorityRank(b.Priority), priorityRank(a.Priority))
+	case SortStatus:
+		return compareInts(statusRank(a.Status), statusRank(b.Status))
+	case SortComplexity:
+		return compareInts(b.Complexity, a.Complexity)
+	case SortUpdated:
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	case SortEstimate:
+		switch {
+		case a.EstimatedHours > b.EstimatedHours:
+			return -1
+		case a.EstimatedHours < b.EstimatedHours:
+			return 1
+		}
+	}
+	return 0
+}
+
+func statusRank(status string) int {
+	if rank, ok := statusSortRank[status]; ok {
+		return rank
+	}
+	return len(statusSortRank)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
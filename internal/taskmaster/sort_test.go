@@ -0,0 +1,85 @@
+package taskmaster
+
+import (
+	"testing"
+	"time"
+)
+
+func sortFixture() []Task {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []Task{
+		{ID: "10", Status: StatusDone, Priority: PriorityHigh, Complexity: 3, UpdatedAt: base.Add(3 * time.Hour), EstimatedHours: 1},
+		{ID: "2", Status: StatusPending, Priority: PriorityCritical, Complexity: 8, UpdatedAt: base, Subtasks: []Task{
+			{ID: "2.10", Status: StatusDone, Priority: PriorityLow},
+			{ID: "2.2", Status: StatusInProgress, Priority: PriorityHigh},
+			{ID: "2.9", Status: StatusPending},
+		}},
+		{ID: "3", Status: StatusInProgress, Complexity: 3, UpdatedAt: base.Add(time.Hour), EstimatedHours: 5},
+	}
+}
+
+func taskIDs(tasks []Task) []string {
+	ids := make([]string, len(tasks))
+	for i := range tasks {
+		ids[i] = tasks[i].ID
+	}
+	return ids
+}
+
+func TestSortTaskTreeSortsEachLevel(t *testing.T) {
+	cases := []struct {
+		mode     SortMode
+		top, sub []string
+	}{
+		{SortFile, []string{"10", "2", "3"}, []string{"2.10", "2.2", "2.9"}},
+		{SortID, []string{"2", "3", "10"}, []string{"2.2", "2.9", "2.10"}},
+		{SortPriority, []string{"2", "10", "3"}, []string{"2.2", "2.9", "2.10"}},
+		{SortStatus, []string{"3", "2", "10"}, []string{"2.2", "2.9", "2.10"}},
+		{SortComplexity, []string{"2", "3", "10"}, []string{"2.2", "2.9", "2.10"}},
+		{SortUpdated, []string{"10", "3", "2"}, []string{"2.2", "2.9", "2.10"}},
+		{SortEstimate, []string{"3", "10", "2"}, []string{"2.2", "2.9", "2.10"}},
+	}
+	for _, tc := range cases {
+		tasks := sortFixture()
+		sorted := SortTaskTree(tasks, tc.mode)
+		var parent *Task
+		for i := range sorted {
+			if sorted[i].ID == "2" {
+				parent = &sorted[i]
+			}
+		}
+		if got := taskIDs(sorted); !equalIDs(got, tc.top) {
+			t.Errorf("%s: expected top level %v, got %v", tc.mode.Label(), tc.top, got)
+		}
+		if got := taskIDs(parent.Subtasks); !equalIDs(got, tc.sub) {
+			t.Errorf("%s: expected subtasks %v, got %v", tc.mode.Label(), tc.sub, got)
+		}
+		if tasks[0].ID != "10" || tasks[1].Subtasks[0].ID != "2.10" {
+			t.Errorf("%s: input tasks were reordered", tc.mode.Label())
+		}
+	}
+}
+
+func TestParseSortMode(t *testing.T) {
+	if mode, err := ParseSortMode("file"); err != nil || mode != SortFile {
+		t.Fatalf("expected file order, got %q %v", mode, err)
+	}
+	if mode, err := ParseSortMode("priority"); err != nil || mode != SortPriority {
+		t.Fatalf("expected priority, got %q %v", mode, err)
+	}
+	if _, err := ParseSortMode("alphabetical"); err == nil {
+		t.Fatal("expected error for unknown sort mode")
+	}
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
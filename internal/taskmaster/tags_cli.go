@@ -99,10 +99,12 @@ func (s *Service) AddTagContext(ctx context.Context, opts TagAddOptions) (*TagOp
 		args = append(args, fmt.Sprintf("-d=%s", opts.Description))
 	}
 
+	before := s.tasksFileSnapshot()
 	output, err := s.runSimpleTaskMasterCommand(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
+	s.recordCommandChange(newUndoAction(UndoActionTag, fmt.Sprintf("Added tag %s", strings.TrimSpace(opts.Name))), before)
 
 	return &TagOperationResult{
 		Command:     args,
@@ -122,10 +124,12 @@ func (s *Service) DeleteTagContext(ctx context.Context, name string, skipConfirm
 		args = append(args, "--yes")
 	}
 
+	before := s.tasksFileSnapshot()
 	output, err := s.runSimpleTaskMasterCommand(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
+	s.recordCommandChange(newUndoAction(UndoActionTag, fmt.Sprintf("Deleted tag %s", strings.TrimSpace(name))), before)
 
 	return &TagOperationResult{
 		Command:     args,
@@ -166,10 +170,12 @@ func (s *Service) RenameTagContext(ctx context.Context, oldName, newName string)
 	}
 
 	args := []string{"rename-tag", oldName, newName}
+	before := s.tasksFileSnapshot()
 	output, err := s.runSimpleTaskMasterCommand(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
+	s.recordCommandChange(newUndoAction(UndoActionTag, fmt.Sprintf("Renamed tag %s to %s", oldName, newName)), before)
 
 	if s.config != nil && strings.EqualFold(s.config.ActiveTag, oldName) {
 		s.config.ActiveTag = newName
@@ -195,10 +201,12 @@ func (s *Service) CopyTagContext(ctx context.Context, sourceName, targetName str
 		args = append(args, fmt.Sprintf("-d=%s", opts.Description))
 	}
 
+	before := s.tasksFileSnapshot()
 	output, err := s.runSimpleTaskMasterCommand(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
+	s.recordCommandChange(newUndoAction(UndoActionTag, fmt.Sprintf("Copied tag %s to %s", sourceName, targetName)), before)
 
 	return &TagOperationResult{
 		Command:     args,
@@ -0,0 +1,129 @@
+package taskmaster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/timetrack"
+)
+
+// StartTimer starts the time-tracking timer on taskID. A timer running on
+// another task is stopped first; its time is added to that task's actual
+// hours and its entry returned.
+func (s *Service) StartTimer(ctx context.Context, taskID string) (*timetrack.Entry, error) {
+	if s.timer == nil {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	if _, ok := s.GetTaskByID(taskID); !ok {
+		return nil, fmt.Errorf("task %s not found", taskID)
+	}
+	stopped, err := s.timer.Start(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return stopped, s.recordTimeEntry(ctx, stopped)
+}
+
+// StopTimer stops the running timer and adds its time to the task's actual
+// hours, rolled up to the task's ancestors. Returns timetrack.ErrNoActiveTimer
+// when no timer is running.
+func (s *Service) StopTimer(ctx context.Context) (*timetrack.Entry, error) {
+	if s.timer == nil {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	stopped, err := s.timer.Stop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return stopped, s.recordTimeEntry(ctx, stopped)
+}
+
+// CheckIdleTimer stops a timer that has been idle longer than the configured
+// timeout, recording the time up to its last activity. It returns nil when
+// no timer was stopped.
+func (s *Service) CheckIdleTimer(ctx context.Context) (*timetrack.Entry, error) {
+	if s.timer == nil {
+		return nil, nil
+	}
+	stopped, err := s.timer.CheckIdle(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return stopped, s.recordTimeEntry(ctx, stopped)
+}
+
+// TouchTimer records user activity for idle detection.
+func (s *Service) TouchTimer() {
+	if s.timer != nil {
+		s.timer.Touch()
+	}
+}
+
+// ActiveTimer returns the running timer, or nil when none is running.
+func (s *Service) ActiveTimer() *timetrack.Timer {
+	if s.timer == nil {
+		return nil
+	}
+	return s.timer.Active()
+}
+
+// Timesheet returns the time tracked per day and task between from and to,
+// with task titles filled in.
+func (s *Service) Timesheet(ctx context.Context, from, to time.Time) (*timetrack.Timesheet, error) {
+	if s.timer == nil {
+		return nil, fmt.Errorf("taskmaster not available")
+	}
+	sheet, err := s.timer.Timesheet(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := range sheet.Rows {
+		if task, ok := s.TaskIndex[sheet.Rows[i].TaskID]; ok {
+			sheet.Rows[i].Title = task.Title
+		}
+	}
+	return sheet, nil
+}
+
+// ExportTimesheet writes the timesheet between from and to as CSV and
+// returns the absolute path written. outputPath is resolved like
+// ExportComplexityReport's; when empty a timestamped file is written under
+// .taskmaster/reports.
+func (s *Service) ExportTimesheet(ctx context.Context, from, to time.Time, outputPath string) (string, error) {
+	sheet, err := s.Timesheet(ctx, from, to)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := sheet.WriteCSV(&buf); err != nil {
+		return "", fmt.Errorf("failed to render timesheet: %w", err)
+	}
+
+	defaultName := fmt.Sprintf("timesheet-%s.csv", time.Now().Format("20060102-150405"))
+	path := s.resolveExportPath(defaultName, outputPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	if err := writeFileAtomic(path, buf.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("failed to write timesheet: %w", err)
+	}
+	return path, nil
+}
+
+// recordTimeEntry adds a stopped timer's time to its task's actual hours.
+func (s *Service) recordTimeEntry(ctx context.Context, entry *timetrack.Entry) error {
+	if entry == nil || entry.Hours() <= 0 {
+		return nil
+	}
+	if err := s.AddActualHours(ctx, entry.TaskID, entry.Hours()); err != nil {
+		return fmt.Errorf("failed to record %.2fh on task %s: %w", entry.Hours(), entry.TaskID, err)
+	}
+	return nil
+}
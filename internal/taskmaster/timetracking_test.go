@@ -0,0 +1,65 @@
+package taskmaster
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/timetrack"
+)
+
+func TestServiceTimerRecordsActualHours(t *testing.T) {
+	svc := setupDeleteService(t, mutateFixture())
+	ctx := context.Background()
+
+	if _, err := svc.StartTimer(ctx, "9"); err == nil {
+		t.Fatalf("expected unknown task to be rejected")
+	}
+	if _, err := svc.StartTimer(ctx, "2.2"); err != nil {
+		t.Fatalf("StartTimer returned error: %v", err)
+	}
+	if active := svc.ActiveTimer(); active == nil || active.TaskID != "2.2" {
+		t.Fatalf("expected timer running on 2.2, got %+v", active)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	stopped, err := svc.StopTimer(ctx)
+	if err != nil {
+		t.Fatalf("StopTimer returned error: %v", err)
+	}
+	if stopped == nil || stopped.TaskID != "2.2" || stopped.Duration() <= 0 {
+		t.Fatalf("expected entry for 2.2, got %+v", stopped)
+	}
+	if _, err := svc.StopTimer(ctx); !errors.Is(err, timetrack.ErrNoActiveTimer) {
+		t.Fatalf("expected ErrNoActiveTimer, got %v", err)
+	}
+
+	// A fresh service on the same project sees the persisted entry
+	restarted := &Service{RootDir: svc.RootDir, TaskIndex: svc.TaskIndex, timer: timetrack.ForProject(svc.RootDir, 0)}
+	sheet, err := restarted.Timesheet(ctx, stopped.Start.Add(-time.Hour), stopped.End.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Timesheet returned error: %v", err)
+	}
+	if len(sheet.Rows) != 1 || sheet.Rows[0].TaskID != "2.2" || sheet.Rows[0].Title != "Second" {
+		t.Fatalf("expected one row for 2.2 with its title, got %+v", sheet.Rows)
+	}
+
+	path, err := svc.ExportTimesheet(ctx, stopped.Start.Add(-time.Hour), stopped.End.Add(time.Hour), "")
+	if err != nil {
+		t.Fatalf("ExportTimesheet returned error: %v", err)
+	}
+	if filepath.Dir(path) != filepath.Join(svc.RootDir, ".taskmaster", "reports") {
+		t.Errorf("expected export under .taskmaster/reports, got %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "date,task_id,title,hours\n") || !strings.Contains(string(data), ",2.2,Second,") {
+		t.Errorf("unexpected timesheet CSV:\n%s", data)
+	}
+}
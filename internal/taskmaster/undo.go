@@ -1,8 +1,11 @@
 package taskmaster
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 )
@@ -13,14 +16,33 @@ type UndoActionType string
 const (
 	// UndoActionDelete is used when tasks were deleted.
 	UndoActionDelete UndoActionType = "delete_tasks"
+	// UndoActionStatus is used when a task status changed.
+	UndoActionStatus UndoActionType = "set_status"
+	// UndoActionEdit is used for field edits and structural task changes.
+	UndoActionEdit UndoActionType = "edit_task"
+	// UndoActionExpand is used when subtasks were added by an expansion.
+	UndoActionExpand UndoActionType = "expand_task"
+	// UndoActionParsePRD is used when tasks were generated from a PRD.
+	UndoActionParsePRD UndoActionType = "parse_prd"
+	// UndoActionTag is used when a tag context was added, copied, renamed or deleted.
+	UndoActionTag UndoActionType = "tag_operation"
 )
 
+// DefaultUndoLimit is the number of actions kept in the undo history.
+const DefaultUndoLimit = 50
+
 // ErrUndoExpired indicates the undo action has expired.
 var ErrUndoExpired = errors.New("undo action expired")
 
 // ErrUndoNotFound indicates no undo action is available for the supplied ID.
 var ErrUndoNotFound = errors.New("undo action not found")
 
+// ErrNothingToUndo indicates the undo history has no applied actions.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// ErrNothingToRedo indicates no undone actions are available to redo.
+var ErrNothingToRedo = errors.New("nothing to redo")
+
 // UndoToken is a lightweight handle surfaced to callers so they can show UX cues.
 type UndoToken struct {
 	ID        string
@@ -30,14 +52,16 @@ type UndoToken struct {
 	Duration  time.Duration
 }
 
-// UndoAction holds the data required to rollback an operation.
+// UndoAction describes one recorded change to tasks.json. The file contents
+// before and after the change are kept as snapshots by the UndoManager.
+// ExpiresAt is only set for actions handed out as undo tokens.
 type UndoAction struct {
-	ID        string
-	Type      UndoActionType
-	Summary   string
-	ExpiresAt time.Time
-	Payload   []byte
-	Metadata  map[string]string
+	ID        string            `json:"id"`
+	Type      UndoActionType    `json:"type"`
+	Summary   string            `json:"summary"`
+	CreatedAt time.Time         `json:"createdAt"`
+	ExpiresAt time.Time         `json:"expiresAt,omitzero"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
 }
 
 // Token creates a user-facing token for the undo action.
@@ -54,71 +78,225 @@ func (a *UndoAction) Token() *UndoToken {
 	}
 }
 
-// UndoManager stores the latest undoable action with simple expiration support.
+// UndoHistory is a copy of the undo stack. Actions are ordered oldest first;
+// the first Position actions are applied and the remainder can be redone.
+type UndoHistory struct {
+	Actions  []UndoAction
+	Position int
+}
+
+// CanUndo reports whether an applied action is available.
+func (h UndoHistory) CanUndo() bool {
+	return h.Position > 0
+}
+
+// CanRedo reports whether an undone action is available.
+func (h UndoHistory) CanRedo() bool {
+	return h.Position < len(h.Actions)
+}
+
+// undoIndex is the on-disk form of the undo stack.
+type undoIndex struct {
+	Position int           `json:"position"`
+	Actions  []*UndoAction `json:"actions"`
+}
+
+// UndoManager keeps a bounded undo/redo stack of tasks.json snapshots. When
+// dir is set the stack and its snapshots are stored there so the history
+// survives restarts; otherwise snapshots are held in memory.
 type UndoManager struct {
-	mu     sync.Mutex
-	action *UndoAction
+	mu        sync.Mutex
+	dir       string
+	limit     int
+	actions   []*UndoAction
+	position  int
+	snapshots map[string][]byte
 }
 
-// NewUndoManager creates a fresh undo manager.
-func NewUndoManager() *UndoManager {
-	return &UndoManager{}
+// NewUndoManager creates an undo manager keeping up to limit actions. A
+// history previously saved in dir is loaded; an unreadable one is discarded.
+func NewUndoManager(dir string, limit int) *UndoManager {
+	if limit <= 0 {
+		limit = DefaultUndoLimit
+	}
+	m := &UndoManager{dir: dir, limit: limit, snapshots: make(map[string][]byte)}
+	if dir == "" {
+		return m
+	}
+	data, err := os.ReadFile(m.indexPath())
+	if err != nil {
+		return m
+	}
+	var index undoIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return m
+	}
+	if index.Position < 0 || index.Position > len(index.Actions) {
+		return m
+	}
+	m.actions = index.Actions
+	m.position = index.Position
+	return m
 }
 
-// Push replaces the current undo action with the provided one.
-func (m *UndoManager) Push(action *UndoAction) {
+// Record pushes an action with the file contents before and after it. Any
+// undone actions are discarded, and the oldest actions are dropped once the
+// limit is reached.
+func (m *UndoManager) Record(action *UndoAction, before, after []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.action = action
+
+	for _, stale := range m.actions[m.position:] {
+		m.removeSnapshots(stale.ID)
+	}
+	m.actions = m.actions[:m.position]
+
+	if err := m.writeSnapshot(action.ID, "before", before); err != nil {
+		return err
+	}
+	if err := m.writeSnapshot(action.ID, "after", after); err != nil {
+		m.removeSnapshots(action.ID)
+		return err
+	}
+	m.actions = append(m.actions, action)
+	for len(m.actions) > m.limit {
+		m.removeSnapshots(m.actions[0].ID)
+		m.actions = m.actions[1:]
+	}
+	m.position = len(m.actions)
+	return m.saveLocked()
 }
 
-// Peek returns the current undo action without consuming it.
-func (m *UndoManager) Peek() *UndoAction {
+// History returns a copy of the undo stack.
+func (m *UndoManager) History() UndoHistory {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if m.action == nil {
-		return nil
+
+	history := UndoHistory{Actions: make([]UndoAction, len(m.actions)), Position: m.position}
+	for i, action := range m.actions {
+		history.Actions[i] = *action
 	}
-	if time.Now().After(m.action.ExpiresAt) {
-		m.action = nil
-		return nil
+	return history
+}
+
+// Find returns the action with the given ID and its index in the stack.
+func (m *UndoManager) Find(id string) (*UndoAction, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, action := range m.actions {
+		if action.ID == id {
+			return action, i, nil
+		}
 	}
-	return m.action
+	return nil, -1, fmt.Errorf("%w: %s", ErrUndoNotFound, id)
 }
 
-// Consume removes and returns the current undo action if the ID matches and it
-// has not expired.
-func (m *UndoManager) Consume(id string) (*UndoAction, error) {
+// SnapshotAt returns the tasks.json contents at the given stack position:
+// position 0 is the state before the oldest action and len(actions) the
+// state after the newest.
+func (m *UndoManager) SnapshotAt(position int) ([]byte, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if m.action == nil {
-		return nil, ErrUndoNotFound
+
+	if position < 0 || position > len(m.actions) || len(m.actions) == 0 {
+		return nil, fmt.Errorf("undo position %d out of range", position)
 	}
-	if m.action.ID != id {
-		return nil, fmt.Errorf("%w: %s", ErrUndoNotFound, id)
+	if position < len(m.actions) {
+		return m.readSnapshot(m.actions[position].ID, "before")
 	}
-	if time.Now().After(m.action.ExpiresAt) {
-		m.action = nil
-		return nil, ErrUndoExpired
+	return m.readSnapshot(m.actions[position-1].ID, "after")
+}
+
+// SetPosition moves the applied/undone boundary after the caller restored
+// the matching snapshot.
+func (m *UndoManager) SetPosition(position int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if position < 0 || position > len(m.actions) {
+		return fmt.Errorf("undo position %d out of range", position)
 	}
-	action := m.action
-	m.action = nil
-	return action, nil
+	m.position = position
+	return m.saveLocked()
+}
+
+func (m *UndoManager) indexPath() string {
+	return filepath.Join(m.dir, "index.json")
 }
 
-// newDeleteUndoAction creates an undo action for delete workflows.
-func newDeleteUndoAction(payload []byte, deletedCount int, ttl time.Duration) *UndoAction {
+func (m *UndoManager) snapshotPath(id, kind string) string {
+	return filepath.Join(m.dir, fmt.Sprintf("%s.%s.json", id, kind))
+}
+
+func (m *UndoManager) writeSnapshot(id, kind string, data []byte) error {
+	if m.dir == "" {
+		m.snapshots[id+"."+kind] = data
+		return nil
+	}
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create undo history directory: %w", err)
+	}
+	return writeFileAtomic(m.snapshotPath(id, kind), data, 0644)
+}
+
+func (m *UndoManager) readSnapshot(id, kind string) ([]byte, error) {
+	if m.dir == "" {
+		data, ok := m.snapshots[id+"."+kind]
+		if !ok {
+			return nil, fmt.Errorf("undo snapshot for %s missing", id)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(m.snapshotPath(id, kind))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read undo snapshot: %w", err)
+	}
+	return data, nil
+}
+
+func (m *UndoManager) removeSnapshots(id string) {
+	for _, kind := range []string{"before", "after"} {
+		if m.dir == "" {
+			delete(m.snapshots, id+"."+kind)
+			continue
+		}
+		_ = os.Remove(m.snapshotPath(id, kind))
+	}
+}
+
+func (m *UndoManager) saveLocked() error {
+	if m.dir == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(undoIndex{Position: m.position, Actions: m.actions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal undo history: %w", err)
+	}
+	return writeFileAtomic(m.indexPath(), data, 0644)
+}
+
+// newUndoAction creates an action for a change about to be recorded.
+func newUndoAction(actionType UndoActionType, summary string) *UndoAction {
+	now := time.Now()
+	return &UndoAction{
+		ID:        fmt.Sprintf("undo-%d", now.UnixNano()),
+		Type:      actionType,
+		Summary:   summary,
+		CreatedAt: now,
+	}
+}
+
+// newDeleteUndoAction creates an undo action for delete workflows. Its token
+// expires after ttl.
+func newDeleteUndoAction(deletedCount int, ttl time.Duration) *UndoAction {
 	if ttl <= 0 {
 		ttl = 20 * time.Second
 	}
-	return &UndoAction{
-		ID:        fmt.Sprintf("undo-%d", time.Now().UnixNano()),
-		Type:      UndoActionDelete,
-		Summary:   fmt.Sprintf("Deleted %d task(s)", deletedCount),
-		ExpiresAt: time.Now().Add(ttl),
-		Payload:   payload,
-		Metadata: map[string]string{
-			"deletedCount": fmt.Sprintf("%d", deletedCount),
-		},
+	action := newUndoAction(UndoActionDelete, fmt.Sprintf("Deleted %d task(s)", deletedCount))
+	action.ExpiresAt = action.CreatedAt.Add(ttl)
+	action.Metadata = map[string]string{
+		"deletedCount": fmt.Sprintf("%d", deletedCount),
 	}
+	return action
 }
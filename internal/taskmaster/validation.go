@@ -61,61 +61,15 @@ func validateTask(task *Task, index map[string]*Task) []ValidationWarning {
 	return warnings
 }
 
-// detectCircularDependencies uses DFS to detect cycles in the dependency graph
+// detectCircularDependencies reports one warning per group of tasks that
+// depend on each other in a cycle, as found by DependencyGraph.Cycles.
 func detectCircularDependencies(index map[string]*Task) []ValidationWarning {
 	warnings := []ValidationWarning{}
-	
-	visited := make(map[string]bool)
-	recStack := make(map[string]bool)
-	
-	var dfs func(taskID string, path []string) bool
-	dfs = func(taskID string, path []string) bool {
-		if recStack[taskID] {
-			// Found a cycle
-			cycleStart := -1
-			for i, id := range path {
-				if id == taskID {
-					cycleStart = i
-					break
-				}
-			}
-			if cycleStart >= 0 {
-				cyclePath := append(path[cycleStart:], taskID)
-				warnings = append(warnings, ValidationWarning{
-					TaskID:  taskID,
-					Message: fmt.Sprintf("Circular dependency detected: %v", cyclePath),
-				})
-			}
-			return true
-		}
-		
-		if visited[taskID] {
-			return false
-		}
-		
-		visited[taskID] = true
-		recStack[taskID] = true
-		path = append(path, taskID)
-		
-		task, exists := index[taskID]
-		if exists {
-			for _, depID := range task.Dependencies {
-				if dfs(depID, path) {
-					return true
-				}
-			}
-		}
-		
-		recStack[taskID] = false
-		return false
-	}
-	
-	// Check all tasks
-	for taskID := range index {
-		if !visited[taskID] {
-			dfs(taskID, []string{})
-		}
+	for _, cycle := range NewDependencyGraph(index).Cycles() {
+		warnings = append(warnings, ValidationWarning{
+			TaskID:  cycle[0],
+			Message: fmt.Sprintf("Circular dependency detected: %v", cycle),
+		})
 	}
-	
 	return warnings
 }
@@ -0,0 +1,124 @@
+package timetrack
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of timesheet dates.
+const DateLayout = "2006-01-02"
+
+// TimesheetRow is the time spent on one task on one day.
+type TimesheetRow struct {
+	Date   string  `json:"date"`
+	TaskID string  `json:"taskId"`
+	Title  string  `json:"title,omitempty"`
+	Hours  float64 `json:"hours"`
+}
+
+// Timesheet lists time per day and task, ordered by date then task ID.
+type Timesheet struct {
+	From       time.Time      `json:"from"`
+	To         time.Time      `json:"to"`
+	Rows       []TimesheetRow `json:"rows"`
+	TotalHours float64        `json:"totalHours"`
+}
+
+// BuildTimesheet clips entries to [from, to) and splits them at local
+// midnight, so time worked across days is booked on each day. Hours are
+// rounded to two decimals per row.
+func BuildTimesheet(entries []Entry, from, to time.Time) *Timesheet {
+	type rowKey struct{ date, taskID string }
+	durations := make(map[rowKey]time.Duration)
+	var total time.Duration
+
+	for _, entry := range entries {
+		start, end := entry.Start, entry.End
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		for start.Before(end) {
+			local := start.Local()
+			midnight := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, time.Local)
+			chunkEnd := end
+			if midnight.Before(chunkEnd) {
+				chunkEnd = midnight
+			}
+			durations[rowKey{local.Format(DateLayout), entry.TaskID}] += chunkEnd.Sub(start)
+			total += chunkEnd.Sub(start)
+			start = chunkEnd
+		}
+	}
+
+	sheet := &Timesheet{From: from, To: to, Rows: make([]TimesheetRow, 0, len(durations))}
+	for key, duration := range durations {
+		sheet.Rows = append(sheet.Rows, TimesheetRow{Date: key.date, TaskID: key.taskID, Hours: roundHours(duration.Hours())})
+	}
+	sort.Slice(sheet.Rows, func(i, j int) bool {
+		if sheet.Rows[i].Date != sheet.Rows[j].Date {
+			return sheet.Rows[i].Date < sheet.Rows[j].Date
+		}
+		return sheet.Rows[i].TaskID < sheet.Rows[j].TaskID
+	})
+	sheet.TotalHours = roundHours(total.Hours())
+	return sheet
+}
+
+// DailyTotals returns the hours booked per date.
+func (s *Timesheet) DailyTotals() map[string]float64 {
+	totals := make(map[string]float64)
+	for _, row := range s.Rows {
+		totals[row.Date] = roundHours(totals[row.Date] + row.Hours)
+	}
+	return totals
+}
+
+// WriteCSV writes one row per day and task with a header row.
+func (s *Timesheet) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"date", "task_id", "title", "hours"})
+	for _, row := range s.Rows {
+		cw.Write([]string{row.Date, row.TaskID, row.Title, strconv.FormatFloat(row.Hours, 'f', 2, 64)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ParseDateRange parses inclusive from and to dates (YYYY-MM-DD) into
+// half-open [from, to) bounds at local midnight. An empty from defaults to
+// the Monday of now's week and an empty to defaults to now's date.
+func ParseDateRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	local := now.Local()
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+	start := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	end := today
+
+	var err error
+	if strings.TrimSpace(from) != "" {
+		if start, err = time.ParseInLocation(DateLayout, strings.TrimSpace(from), time.Local); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from date %q (want YYYY-MM-DD)", from)
+		}
+	}
+	if strings.TrimSpace(to) != "" {
+		if end, err = time.ParseInLocation(DateLayout, strings.TrimSpace(to), time.Local); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date %q (want YYYY-MM-DD)", to)
+		}
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to date %s is before from date %s", end.Format(DateLayout), start.Format(DateLayout))
+	}
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
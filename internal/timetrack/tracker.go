@@ -0,0 +1,288 @@
+// Package timetrack records the time spent working on tasks. The running
+// timer and finished entries are persisted in the project's memory store so
+// a timer survives restarts of the TUI.
+package timetrack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/memory"
+)
+
+// StateKey is the memory store key holding the tracker state.
+const StateKey = "timetrack:state"
+
+// ErrNoActiveTimer is returned by Stop when no timer is running.
+var ErrNoActiveTimer = errors.New("no timer running")
+
+// Opener opens the memory store holding tracker state. The store is opened
+// only for the duration of each read or write so other processes can use it
+// in between.
+type Opener func() (memory.Memory, error)
+
+// Timer is a running timer.
+type Timer struct {
+	TaskID       string    `json:"taskId"`
+	Start        time.Time `json:"start"`
+	LastActivity time.Time `json:"lastActivity"`
+}
+
+// Elapsed returns the time since the timer started.
+func (t Timer) Elapsed(now time.Time) time.Duration {
+	return now.Sub(t.Start)
+}
+
+// Entry is a finished span of time spent on a task.
+type Entry struct {
+	TaskID string    `json:"taskId"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	// Idle is set when idle detection stopped the timer; End is then the
+	// last activity seen and the idle time is not counted.
+	Idle bool `json:"idle,omitempty"`
+}
+
+// Duration returns the length of the entry.
+func (e Entry) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
+
+// Hours returns the length of the entry in hours.
+func (e Entry) Hours() float64 {
+	return e.Duration().Hours()
+}
+
+// state is the persisted tracker state.
+type state struct {
+	Active  *Timer  `json:"active,omitempty"`
+	Entries []Entry `json:"entries"`
+}
+
+// Tracker starts and stops timers on tasks. It is safe for concurrent use.
+type Tracker struct {
+	mu          sync.Mutex
+	open        Opener
+	idleTimeout time.Duration
+	now         func() time.Time
+
+	state  state
+	loaded bool
+	// dirty is set when activity was seen since the state was last saved
+	dirty bool
+}
+
+// NewTracker creates a tracker persisting its state through open. An
+// idleTimeout of zero or less disables idle detection.
+func NewTracker(open Opener, idleTimeout time.Duration) *Tracker {
+	return &Tracker{
+		open:        open,
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+	}
+}
+
+// ForProject creates a tracker using the memory store of the project rooted
+// at root.
+func ForProject(root string, idleTimeout time.Duration) *Tracker {
+	return NewTracker(func() (memory.Memory, error) {
+		return memory.OpenStore(root)
+	}, idleTimeout)
+}
+
+// Start starts a timer on taskID. A timer already running on another task
+// is stopped first and its entry returned; starting the task that is
+// already being timed only records activity.
+func (t *Tracker) Start(ctx context.Context, taskID string) (*Entry, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("task ID is required")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.loadLocked(ctx); err != nil {
+		return nil, err
+	}
+
+	now := t.now()
+	if active := t.state.Active; active != nil && active.TaskID == taskID && !t.isIdleLocked(now) {
+		active.LastActivity = now
+		t.dirty = true
+		return nil, nil
+	}
+
+	next, stopped := t.stoppedStateLocked(now)
+	next.Active = &Timer{TaskID: taskID, Start: now, LastActivity: now}
+	if err := t.saveLocked(ctx, next); err != nil {
+		return nil, err
+	}
+	return stopped, nil
+}
+
+// Stop stops the running timer and returns its entry. Returns
+// ErrNoActiveTimer when no timer is running.
+func (t *Tracker) Stop(ctx context.Context) (*Entry, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.loadLocked(ctx); err != nil {
+		return nil, err
+	}
+	if t.state.Active == nil {
+		return nil, ErrNoActiveTimer
+	}
+
+	next, stopped := t.stoppedStateLocked(t.now())
+	if err := t.saveLocked(ctx, next); err != nil {
+		return nil, err
+	}
+	return stopped, nil
+}
+
+// Touch records user activity for idle detection. It does not persist; the
+// next CheckIdle does.
+func (t *Tracker) Touch() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state.Active != nil {
+		t.state.Active.LastActivity = t.now()
+		t.dirty = true
+	}
+}
+
+// CheckIdle stops the running timer at its last activity when it has been
+// idle for longer than the idle timeout, returning the entry recorded.
+// Otherwise it saves any activity seen since the last save, so a restart
+// resumes the timer. A timer left running by a previous session is stopped
+// here once it has gone idle.
+func (t *Tracker) CheckIdle(ctx context.Context) (*Entry, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.loadLocked(ctx); err != nil {
+		return nil, err
+	}
+	if t.state.Active == nil {
+		return nil, nil
+	}
+
+	now := t.now()
+	if !t.isIdleLocked(now) {
+		if !t.dirty {
+			return nil, nil
+		}
+		return nil, t.saveLocked(ctx, t.state)
+	}
+	next, stopped := t.stoppedStateLocked(now)
+	if err := t.saveLocked(ctx, next); err != nil {
+		return nil, err
+	}
+	return stopped, nil
+}
+
+// Active returns a copy of the running timer, or nil when none is running
+// or the state has not been loaded yet.
+func (t *Tracker) Active() *Timer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state.Active == nil {
+		return nil
+	}
+	active := *t.state.Active
+	return &active
+}
+
+// Entries returns the finished entries, oldest first.
+func (t *Tracker) Entries(ctx context.Context) ([]Entry, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.loadLocked(ctx); err != nil {
+		return nil, err
+	}
+	return append([]Entry(nil), t.state.Entries...), nil
+}
+
+// Timesheet returns time per day and task between from and to, including
+// the running timer up to now.
+func (t *Tracker) Timesheet(ctx context.Context, from, to time.Time) (*Timesheet, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.loadLocked(ctx); err != nil {
+		return nil, err
+	}
+
+	withRunning, _ := t.stoppedStateLocked(t.now())
+	return BuildTimesheet(withRunning.Entries, from, to), nil
+}
+
+// isIdleLocked reports whether the running timer has seen no activity for
+// longer than the idle timeout.
+func (t *Tracker) isIdleLocked(now time.Time) bool {
+	active := t.state.Active
+	return active != nil && t.idleTimeout > 0 && now.Sub(active.LastActivity) > t.idleTimeout
+}
+
+// stoppedStateLocked returns the state with the running timer, if any,
+// turned into an entry ending now, or at its last activity when idle.
+func (t *Tracker) stoppedStateLocked(now time.Time) (state, *Entry) {
+	next := state{Entries: append([]Entry(nil), t.state.Entries...)}
+	active := t.state.Active
+	if active == nil {
+		return next, nil
+	}
+
+	entry := Entry{TaskID: active.TaskID, Start: active.Start, End: now}
+	if t.isIdleLocked(now) {
+		entry.End = active.LastActivity
+		entry.Idle = true
+	}
+	next.Entries = append(next.Entries, entry)
+	return next, &entry
+}
+
+func (t *Tracker) loadLocked(ctx context.Context) error {
+	if t.loaded {
+		return nil
+	}
+	store, err := t.open()
+	if err != nil {
+		return fmt.Errorf("failed to open time tracking store: %w", err)
+	}
+	defer store.Close()
+
+	data, err := store.Retrieve(ctx, StateKey)
+	if err != nil && !errors.Is(err, memory.ErrKeyNotFound) {
+		return fmt.Errorf("failed to read time tracking state: %w", err)
+	}
+	var loaded state
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &loaded); err != nil {
+			return fmt.Errorf("failed to parse time tracking state: %w", err)
+		}
+	}
+	t.state = loaded
+	t.loaded = true
+	return nil
+}
+
+// saveLocked persists next and, once written, makes it the current state.
+func (t *Tracker) saveLocked(ctx context.Context, next state) error {
+	data, err := json.Marshal(next)
+	if err != nil {
+		return fmt.Errorf("failed to marshal time tracking state: %w", err)
+	}
+	store, err := t.open()
+	if err != nil {
+		return fmt.Errorf("failed to open time tracking store: %w", err)
+	}
+	defer store.Close()
+
+	if err := store.Store(ctx, StateKey, data); err != nil {
+		return fmt.Errorf("failed to save time tracking state: %w", err)
+	}
+	t.state = next
+	t.dirty = false
+	return nil
+}
@@ -0,0 +1,147 @@
+package timetrack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/memory"
+)
+
+// newTestTracker returns a tracker persisting to a JSON file in dir, with a
+// clock the test controls.
+func newTestTracker(dir string, clock *time.Time) *Tracker {
+	tracker := NewTracker(func() (memory.Memory, error) {
+		return memory.NewInMemoryStorage(filepath.Join(dir, "memory.json"))
+	}, 10*time.Minute)
+	tracker.now = func() time.Time { return *clock }
+	return tracker
+}
+
+func TestTrackerSwitchesTasksAndSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	clock := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	tracker := newTestTracker(dir, &clock)
+
+	if _, err := tracker.Stop(ctx); !errors.Is(err, ErrNoActiveTimer) {
+		t.Fatalf("expected ErrNoActiveTimer, got %v", err)
+	}
+	if stopped, err := tracker.Start(ctx, "1"); err != nil || stopped != nil {
+		t.Fatalf("expected clean start, got %+v (%v)", stopped, err)
+	}
+
+	clock = clock.Add(5 * time.Minute)
+	tracker.Touch()
+	if _, err := tracker.CheckIdle(ctx); err != nil {
+		t.Fatalf("CheckIdle returned error: %v", err)
+	}
+
+	// Restart: a new tracker resumes the timer from the store
+	clock = clock.Add(2 * time.Minute)
+	restarted := newTestTracker(dir, &clock)
+	if stopped, err := restarted.CheckIdle(ctx); err != nil || stopped != nil {
+		t.Fatalf("expected timer to resume after restart, got %+v (%v)", stopped, err)
+	}
+	if active := restarted.Active(); active == nil || active.TaskID != "1" {
+		t.Fatalf("expected timer on task 1 after restart, got %+v", active)
+	}
+
+	stopped, err := restarted.Start(ctx, "2")
+	if err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	if stopped == nil || stopped.TaskID != "1" || stopped.Duration() != 7*time.Minute || stopped.Idle {
+		t.Fatalf("expected 7m entry for task 1, got %+v", stopped)
+	}
+	if active := restarted.Active(); active == nil || active.TaskID != "2" {
+		t.Fatalf("expected timer on task 2, got %+v", active)
+	}
+}
+
+func TestTrackerIdleStopDiscardsIdleTime(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	clock := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	tracker := newTestTracker(dir, &clock)
+
+	tracker.Start(ctx, "3")
+	clock = clock.Add(20 * time.Minute)
+	tracker.Touch()
+	tracker.CheckIdle(ctx)
+
+	clock = clock.Add(45 * time.Minute)
+	stopped, err := tracker.CheckIdle(ctx)
+	if err != nil {
+		t.Fatalf("CheckIdle returned error: %v", err)
+	}
+	if stopped == nil || !stopped.Idle || stopped.Duration() != 20*time.Minute {
+		t.Fatalf("expected idle stop at last activity after 20m, got %+v", stopped)
+	}
+	if tracker.Active() != nil {
+		t.Fatalf("expected no running timer after idle stop")
+	}
+
+	entries, err := newTestTracker(dir, &clock).Entries(ctx)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected persisted entry, got %+v (%v)", entries, err)
+	}
+}
+
+func TestBuildTimesheetSplitsAtMidnight(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	entries := []Entry{
+		{TaskID: "1", Start: day.Add(22 * time.Hour), End: day.Add(26 * time.Hour)},
+		{TaskID: "2", Start: day.Add(9 * time.Hour), End: day.Add(10*time.Hour + 30*time.Minute)},
+		{TaskID: "1", Start: day.Add(-3 * time.Hour), End: day.Add(time.Hour)},
+	}
+
+	sheet := BuildTimesheet(entries, day, day.AddDate(0, 0, 2))
+	want := []TimesheetRow{
+		{Date: "2026-03-02", TaskID: "1", Hours: 3},
+		{Date: "2026-03-02", TaskID: "2", Hours: 1.5},
+		{Date: "2026-03-03", TaskID: "1", Hours: 2},
+	}
+	if len(sheet.Rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), sheet.Rows)
+	}
+	for i, row := range want {
+		if sheet.Rows[i] != row {
+			t.Errorf("row %d: expected %+v, got %+v", i, row, sheet.Rows[i])
+		}
+	}
+	if sheet.TotalHours != 6.5 || sheet.DailyTotals()["2026-03-02"] != 4.5 {
+		t.Errorf("unexpected totals: %v, %v", sheet.TotalHours, sheet.DailyTotals())
+	}
+
+	var buf bytes.Buffer
+	if err := sheet.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "2026-03-03,1,,2.00\n") {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	wednesday := time.Date(2026, 3, 4, 15, 0, 0, 0, time.Local)
+
+	from, to, err := ParseDateRange("", "", wednesday)
+	if err != nil {
+		t.Fatalf("ParseDateRange returned error: %v", err)
+	}
+	if from.Format(DateLayout) != "2026-03-02" || to.Format(DateLayout) != "2026-03-05" {
+		t.Errorf("expected Monday to end of today, got %s..%s", from, to)
+	}
+
+	if _, _, err := ParseDateRange("2026-03-05", "2026-03-01", wednesday); err == nil {
+		t.Errorf("expected reversed range to be rejected")
+	}
+	if _, _, err := ParseDateRange("March 1", "", wednesday); err == nil {
+		t.Errorf("expected invalid date to be rejected")
+	}
+}
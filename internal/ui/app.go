@@ -10,6 +10,7 @@ import (
 
 	"github.com/agreen757/tm-tui/internal/config"
 	"github.com/agreen757/tm-tui/internal/executor"
+	"github.com/agreen757/tm-tui/internal/git"
 	"github.com/agreen757/tm-tui/internal/projects"
 	"github.com/agreen757/tm-tui/internal/taskmaster"
 	"github.com/agreen757/tm-tui/internal/ui/dialog"
@@ -41,7 +42,8 @@ type ViewMode int
 const (
 	ViewModeTree ViewMode = iota
 	ViewModeList
-	ViewModeKanban // Placeholder for future implementation
+	ViewModeKanban
+	ViewModeGraph
 )
 
 const (
@@ -75,6 +77,9 @@ type Model struct {
 
 	// View state
 	viewMode             ViewMode
+	kanbanColumn         int             // Focused column index in kanbanStatuses
+	kanbanCollapsed      map[string]bool // Collapsed kanban columns by status
+	graphPrevID          string          // Node we last followed an edge from in graph view
 	focusedPanel         Panel
 	selectedIndex        int // Index in visibleTasks array
 	selectedTask         *taskmaster.Task
@@ -109,6 +114,9 @@ type Model struct {
 	currentComplexityTags    []string
 	complexityStartedAt      time.Time
 	waitingForComplexityHold bool
+	complexityTrend          *taskmaster.ComplexityTrend
+	gitActivity              *git.Activity
+	gitScanFailed            bool
 	parsePrdChan             chan tea.Msg
 	parsePrdCancel           context.CancelFunc
 
@@ -139,11 +147,25 @@ type Model struct {
 	searchMode    bool
 	searchInput   textinput.Model
 	searchQuery   string
+	searchFilter  *taskmaster.Query // last valid parse of searchQuery
+	searchErr     error             // syntax error in searchQuery, shown inline
 	searchResults []*taskmaster.Task
 
 	// Filter state
 	statusFilter string // empty = all, or specific status like "pending", "in-progress", etc.
 
+	// Sort order applied within each level of the task tree
+	sortMode taskmaster.SortMode
+
+	// Saved views, persisted in the state file
+	savedViews  config.SavedViews
+	defaultView string // view applied at startup, empty for none
+	activeView  string // last applied or saved view
+
+	// Details panel rendering: Markdown by default, raw text when toggled
+	rawDetails bool
+	markdown   *markdownRenderer
+
 	// Confirmation mode state
 	confirmingClearState bool
 
@@ -186,10 +208,10 @@ func NewModel(cfg *config.Config, configManager *config.ConfigManager, taskServi
 
 	// Initialize search input
 	searchInput := textinput.New()
-	searchInput.Placeholder = "Search tasks (ID, title, description)..."
+	searchInput.Placeholder = "Search text or query, e.g. status:pending tag:api..."
 	searchInput.Focus()
-	searchInput.CharLimit = 100
-	searchInput.Width = 40
+	searchInput.CharLimit = 200
+	searchInput.Width = 60
 	searchInput.Prompt = ""
 
 	// Style the text input for better visibility
@@ -248,6 +270,7 @@ func NewModel(cfg *config.Config, configManager *config.ConfigManager, taskServi
 		searchInput:       searchInput,
 		styles:            NewStyles(),
 		logLines:          []string{},
+		markdown:          newMarkdownRenderer(),
 		projectRegistry:   taskService.ProjectRegistry(),
 		activeProject:     taskService.ActiveProjectMetadata(),
 	}
@@ -298,6 +321,16 @@ func (m *Model) registerDefaultCommandShortcuts() {
 		{binding: m.keyMap.ProjectTags, command: CommandProjectTags, help: "Project Tags"},
 		{binding: m.keyMap.ProjectQuickSwitch, command: CommandProjectQuickSwitch, help: "Quick Project Switch"},
 		{binding: m.keyMap.ProjectSearch, command: CommandProjectSearch, help: "Project Search"},
+		{binding: m.keyMap.ToggleTimer, command: CommandToggleTimer, help: "Start/Stop Timer"},
+		{binding: m.keyMap.Undo, command: CommandUndo, help: "Undo"},
+		{binding: m.keyMap.Redo, command: CommandRedo, help: "Redo"},
+		{binding: m.keyMap.UndoHistory, command: CommandUndoHistory, help: "Undo History"},
+		{binding: m.keyMap.SavedViews, command: CommandSwitchView, help: "Switch View"},
+		{binding: m.keyMap.EditTask, command: CommandEditTask, help: "Edit Task"},
+		{binding: m.keyMap.EditInEditor, command: CommandEditInEditor, help: "Edit in $EDITOR"},
+		{binding: m.keyMap.BulkActions, command: CommandBulkActions, help: "Bulk Actions"},
+		{binding: m.keyMap.ToggleMarkdown, command: CommandToggleMarkdown, help: "Toggle Markdown Details"},
+		{binding: m.keyMap.FollowLink, command: CommandFollowLink, help: "Follow Link"},
 	}
 }
 
@@ -458,8 +491,8 @@ func (m *Model) buildTaskIndex() {
 // preserve the selection. Callers should use ensureTaskSelected() if they need
 // to maintain the current selection after the rebuild.
 func (m *Model) rebuildVisibleTasks() {
-	// In list view, show all tasks regardless of expanded state
-	if m.viewMode == ViewModeList {
+	// In list, kanban and graph views, show all tasks regardless of expanded state
+	if m.viewMode == ViewModeList || m.viewMode == ViewModeKanban || m.viewMode == ViewModeGraph {
 		m.visibleTasks = m.flattenAllTasks()
 	} else {
 		// In tree view, respect expanded state
@@ -617,12 +650,15 @@ func (m *Model) extractUIState() *config.UIState {
 	}
 
 	// Convert ViewMode to string
-	viewModeStr := "tree"
-	switch m.viewMode {
-	case ViewModeList:
-		viewModeStr = "list"
-	case ViewModeKanban:
-		viewModeStr = "kanban"
+	viewModeStr := viewModeName(m.viewMode)
+
+	// Copy collapsed kanban columns
+	var kanbanCollapsed map[string]bool
+	if len(m.kanbanCollapsed) > 0 {
+		kanbanCollapsed = make(map[string]bool, len(m.kanbanCollapsed))
+		for status, collapsed := range m.kanbanCollapsed {
+			kanbanCollapsed[status] = collapsed
+		}
 	}
 
 	// Convert Panel to string
@@ -643,6 +679,11 @@ func (m *Model) extractUIState() *config.UIState {
 		ShowLogPanel:     m.showLogPanel,
 		PanelHeights:     make(map[string]int), // Can be extended later
 		LastPrdPath:      m.lastPrdPath,
+		KanbanCollapsed:  kanbanCollapsed,
+		SortMode:         string(m.sortMode),
+		SavedViews:       m.savedViews,
+		DefaultView:      m.defaultView,
+		RawDetails:       m.rawDetails,
 	}
 }
 
@@ -669,13 +710,12 @@ func (m *Model) restoreUIState(state *config.UIState) {
 	}
 
 	// Restore view mode
-	switch state.ViewMode {
-	case "list":
-		m.viewMode = ViewModeList
-	case "kanban":
-		m.viewMode = ViewModeKanban
-	default:
-		m.viewMode = ViewModeTree
+	m.viewMode = parseViewMode(state.ViewMode)
+
+	// Restore collapsed kanban columns
+	m.kanbanCollapsed = make(map[string]bool, len(state.KanbanCollapsed))
+	for status, collapsed := range state.KanbanCollapsed {
+		m.kanbanCollapsed[status] = collapsed
 	}
 
 	// Restore focused panel
@@ -692,6 +732,10 @@ func (m *Model) restoreUIState(state *config.UIState) {
 	m.showDetailsPanel = state.ShowDetailsPanel
 	m.showLogPanel = state.ShowLogPanel
 	m.lastPrdPath = state.LastPrdPath
+	m.sortMode, _ = taskmaster.ParseSortMode(state.SortMode)
+	m.savedViews = state.SavedViews
+	m.defaultView = state.DefaultView
+	m.rawDetails = state.RawDetails
 
 	// Rebuild visible tasks with restored expanded state
 	m.rebuildVisibleTasks()
@@ -709,6 +753,12 @@ func (m *Model) restoreUIState(state *config.UIState) {
 			}
 		}
 	}
+	m.syncKanbanColumn()
+
+	// A default view replaces the restored filters and layout
+	if view, ok := m.savedViews.Find(m.defaultView); ok {
+		m.applySavedView(view)
+	}
 }
 
 // ClearUIState resets all UI state to defaults and deletes the state file
@@ -724,6 +774,8 @@ func (m *Model) ClearUIState() error {
 	m.expandedNodes = make(map[string]bool)
 	m.selectedIndex = 0
 	m.viewMode = ViewModeTree
+	m.kanbanColumn = 0
+	m.kanbanCollapsed = nil
 	m.focusedPanel = PanelTaskList
 	m.showDetailsPanel = true
 	m.showLogPanel = false
@@ -752,6 +804,10 @@ func (m *Model) ClearUIState() error {
 	m.updateTaskListViewport()
 	m.updateDetailsViewport()
 
+	// Saved views are kept; write them back to the fresh state file
+	if len(m.savedViews) > 0 {
+		return m.SaveUIState()
+	}
 	return nil
 }
 
@@ -1031,12 +1087,12 @@ func (m Model) flattenTasks() []*taskmaster.Task {
 			if task, ok := m.taskIndex[tasks[i].ID]; ok {
 				result = append(result, task)
 				if len(task.Subtasks) > 0 && m.expandedNodes[task.ID] {
-					flatten(task.Subtasks)
+					flatten(tasks[i].Subtasks)
 				}
 			}
 		}
 	}
-	flatten(m.tasks)
+	flatten(m.sortedTasks())
 	return result
 }
 
@@ -1298,11 +1354,11 @@ func (m Model) filterTaskTree(tasks []taskmaster.Task) []taskmaster.Task {
 	}
 
 	// Then apply search filter
-	if m.searchQuery == "" {
+	if m.searchFilter.IsEmpty() {
 		return statusFiltered
 	}
 
-	return m.filterTaskTreeBySearch(statusFiltered, m.searchQuery)
+	return m.filterTaskTreeBySearch(statusFiltered, m.searchFilter)
 }
 
 // filterTaskTreeByStatus filters tasks in tree structure by status
@@ -1335,20 +1391,17 @@ func (m Model) filterTaskTreeByStatus(tasks []taskmaster.Task, status string) []
 }
 
 // filterTaskTreeBySearch filters tasks in tree structure by search query
-func (m Model) filterTaskTreeBySearch(tasks []taskmaster.Task, query string) []taskmaster.Task {
-	if query == "" {
+func (m Model) filterTaskTreeBySearch(tasks []taskmaster.Task, query *taskmaster.Query) []taskmaster.Task {
+	if query.IsEmpty() {
 		return tasks
 	}
 
-	lowerQuery := strings.ToLower(query)
 	var filtered []taskmaster.Task
 
 	for i := range tasks {
 		task := tasks[i]
 		// Check if this task matches
-		matches := strings.Contains(strings.ToLower(task.ID), lowerQuery) ||
-			strings.Contains(strings.ToLower(task.Title), lowerQuery) ||
-			strings.Contains(strings.ToLower(task.Description), lowerQuery)
+		matches := query.Match(&task)
 
 		// Check if any subtask matches
 		if len(task.Subtasks) > 0 {
@@ -1462,6 +1515,20 @@ func (m Model) renderTaskDetails() string {
 		b.WriteString("\n\n")
 	}
 
+	// Tracked time
+	if tracked := m.trackedTime(task); tracked != "" {
+		b.WriteString(m.styles.Subtitle.Render("Time: "))
+		b.WriteString(tracked)
+		b.WriteString("\n\n")
+	}
+
+	// Complexity score history across analyses
+	if scores := m.complexityTrend.TaskScores(task.ID); len(scores) > 1 {
+		b.WriteString(m.styles.Subtitle.Render("Score Trend: "))
+		b.WriteString(fmt.Sprintf("%s %d → %d", dialog.Sparkline(scores), scores[0], scores[len(scores)-1]))
+		b.WriteString("\n\n")
+	}
+
 	// Dependencies
 	if len(task.Dependencies) > 0 {
 		b.WriteString(m.styles.Subtitle.Render("Dependencies: "))
@@ -1484,8 +1551,7 @@ func (m Model) renderTaskDetails() string {
 	if task.Description != "" {
 		b.WriteString(m.styles.Subtitle.Render("Description:"))
 		b.WriteString("\n")
-		wrappedDesc := wrapText(task.Description, wrapWidth)
-		b.WriteString(wrappedDesc)
+		b.WriteString(m.renderDetailText(task.Description, wrapWidth))
 		b.WriteString("\n\n")
 	}
 
@@ -1493,8 +1559,7 @@ func (m Model) renderTaskDetails() string {
 	if task.Details != "" {
 		b.WriteString(m.styles.Subtitle.Render("Details:"))
 		b.WriteString("\n")
-		wrappedDetails := wrapText(task.Details, wrapWidth)
-		b.WriteString(wrappedDetails)
+		b.WriteString(m.renderDetailText(task.Details, wrapWidth))
 		b.WriteString("\n\n")
 	}
 
@@ -1502,11 +1567,24 @@ func (m Model) renderTaskDetails() string {
 	if task.TestStrategy != "" {
 		b.WriteString(m.styles.Subtitle.Render("Test Strategy:"))
 		b.WriteString("\n")
-		wrappedStrategy := wrapText(task.TestStrategy, wrapWidth)
-		b.WriteString(wrappedStrategy)
+		b.WriteString(m.renderDetailText(task.TestStrategy, wrapWidth))
 		b.WriteString("\n\n")
 	}
 
+	// Links that can be followed from the details panel
+	if links := detectDetailLinks(task, m.taskIndex); len(links) > 0 {
+		b.WriteString(m.styles.Subtitle.Render("Links:"))
+		b.WriteString("\n")
+		for i, link := range links {
+			b.WriteString(ansiWrapText(fmt.Sprintf("[%d] %s", i+1, m.detailLinkLabel(link)), wrapWidth))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Task branch and the commits referencing the task
+	b.WriteString(m.renderGitDetails(task, wrapWidth))
+
 	// Subtasks count
 	if len(task.Subtasks) > 0 {
 		completed := 0
@@ -1545,6 +1623,8 @@ func (m *Model) updateTaskListViewport() {
 		viewModeStr = " [List]"
 	case ViewModeKanban:
 		viewModeStr = " [Kanban]"
+	case ViewModeGraph:
+		viewModeStr = " [Graph]"
 	}
 	title := m.styles.PanelTitle.Render("📋 Tasks" + viewModeStr)
 
@@ -1554,13 +1634,14 @@ func (m *Model) updateTaskListViewport() {
 	case ViewModeList:
 		content = title + "\n\n" + m.renderTaskList()
 	case ViewModeKanban:
-		// Placeholder for future kanban view
-		content = title + "\n\n" + m.styles.Info.Render("Kanban view not yet implemented")
+		content = title + "\n\n" + m.renderKanbanBoard()
+	case ViewModeGraph:
+		content = title + "\n\n" + m.renderDependencyGraph()
 	default: // ViewModeTree
 		// Apply filters if any are active
-		tasksToRender := m.tasks
+		tasksToRender := m.sortedTasks()
 		if m.searchQuery != "" || m.statusFilter != "" {
-			tasksToRender = m.filterTaskTree(m.tasks)
+			tasksToRender = m.filterTaskTree(tasksToRender)
 			if len(tasksToRender) == 0 {
 				content = title + "\n\n" + m.styles.Info.Render("No tasks match current filters")
 			} else {
@@ -1579,12 +1660,7 @@ func (m Model) renderTaskList() string {
 	var b strings.Builder
 
 	// Use visibleTasks if any filter is active, otherwise flatten all tasks
-	var tasksToRender []*taskmaster.Task
-	if (m.searchQuery != "" || m.statusFilter != "") && m.visibleTasks != nil {
-		tasksToRender = m.visibleTasks
-	} else {
-		tasksToRender = m.flattenAllTasks()
-	}
+	tasksToRender := m.filteredFlatTasks()
 
 	for i, task := range tasksToRender {
 		statusIcon := GetStatusIcon(task.Status)
@@ -1645,15 +1721,24 @@ func (m Model) flattenAllTasks() []*taskmaster.Task {
 			if task, ok := m.taskIndex[tasks[i].ID]; ok {
 				result = append(result, task)
 				if len(task.Subtasks) > 0 {
-					flatten(task.Subtasks)
+					flatten(tasks[i].Subtasks)
 				}
 			}
 		}
 	}
-	flatten(m.tasks)
+	flatten(m.sortedTasks())
 	return result
 }
 
+// filteredFlatTasks returns all tasks in a flat list with the active search
+// and status filters applied, as shown by the list, kanban and graph views.
+func (m Model) filteredFlatTasks() []*taskmaster.Task {
+	if (m.searchQuery != "" || m.statusFilter != "") && m.visibleTasks != nil {
+		return m.visibleTasks
+	}
+	return m.flattenAllTasks()
+}
+
 // addLogLine adds a line to the log panel
 func (m *Model) addLogLine(line string) {
 	m.logLines = append(m.logLines, line)
@@ -1715,37 +1800,19 @@ func (m Model) renderLog() string {
 	return strings.Join(wrappedLines, "\n")
 }
 
-// FUTURE ENHANCEMENT: ANSI-aware wrapping helper
-// This commented-out helper demonstrates how to improve ANSI color handling in the future.
-// Do NOT enable this without adding the charmbracelet/x/ansi dependency.
-//
-// // ansiWrapText wraps text while respecting ANSI escape sequences for proper visual alignment.
-// // This is a future enhancement to replace the byte-based width counting in wrapText().
-// //
-// // Implementation would require: go get github.com/charmbracelet/x/ansi
-// //
-// // func ansiWrapText(text string, width int) string {
-// //     // Strip ANSI codes to get visual content
-// //     // This requires importing "github.com/charmbracelet/x/ansi"
-// //     plain := ansi.Strip(text)      // Get text without color codes
-// //     wrapped := wrapText(plain, width) // Wrap based on visual width
-// //
-// //     // If no ANSI codes were present, wrapped text is ready to use
-// //     if text == plain {
-// //         return wrapped
-// //     }
-// //
-// //     // If ANSI codes were present, this would require reapplying them to wrapped lines
-// //     // (Implementation detail omitted; this is more complex and depends on code structure)
-// //     return wrapped
-// // }
-//
-// Alternative simpler approach without a new dependency:
-// 1. Create a simple pattern matcher to find ANSI sequences
-// 2. Count only non-ANSI characters when checking line width
-// 3. Preserve ANSI codes in output unchanged
-//
-// This would avoid the visual misalignment without adding external dependencies.
+// ansiWrapText wraps text line by line like renderLog, but measures visible
+// width so styled text (such as rendered Markdown) is not wrapped early.
+// Empty lines and leading indentation are kept.
+func ansiWrapText(text string, width int) string {
+	if width <= 0 || text == "" {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Wrap(line, width, "")
+	}
+	return strings.Join(lines, "\n")
+}
 
 // updateLogViewport updates the log viewport content
 func (m *Model) updateLogViewport() {
@@ -1755,13 +1822,9 @@ func (m *Model) updateLogViewport() {
 	m.logViewport.GotoBottom()
 }
 
-// setTaskStatus sets the status of selected task(s) via executor
-func (m *Model) setTaskStatus(status string) {
-	if m.execService.IsRunning() {
-		m.addLogLine("Command already running, please wait...")
-		return
-	}
-
+// setTaskStatus sets the status of selected task(s) by writing tasks.json
+// directly through the task service.
+func (m *Model) setTaskStatus(status string) tea.Cmd {
 	// Get tasks to update (selected or current)
 	var taskIDs []string
 	if len(m.selectedIDs) > 0 {
@@ -1770,20 +1833,33 @@ func (m *Model) setTaskStatus(status string) {
 		taskIDs = []string{m.selectedTask.ID}
 	} else {
 		m.addLogLine("No task selected")
-		return
+		return nil
 	}
 
-	// Execute set-status command for each task
-	for _, taskID := range taskIDs {
-		m.addLogLine(fmt.Sprintf("Setting task %s to %s", taskID, status))
-		if err := m.execService.Execute("set-status", fmt.Sprintf("--id=%s", taskID), fmt.Sprintf("--status=%s", status)); err != nil {
-			m.addLogLine(fmt.Sprintf("Error: %v", err))
-			return
-		}
-	}
+	m.addLogLine(fmt.Sprintf("Setting %s to %s", formatTaskIDList(taskIDs), status))
 
 	// Clear selection after status change
 	m.clearSelection()
+
+	svc := m.taskService
+	return func() tea.Msg {
+		updated := make([]string, 0, len(taskIDs))
+		for _, taskID := range taskIDs {
+			if err := svc.SetTaskStatus(taskID, status); err != nil {
+				return TaskStatusUpdatedMsg{TaskIDs: updated, Status: status, Err: fmt.Errorf("task %s: %w", taskID, err)}
+			}
+			updated = append(updated, taskID)
+		}
+		return TaskStatusUpdatedMsg{TaskIDs: updated, Status: status}
+	}
+}
+
+// formatTaskIDList renders task IDs for log output.
+func formatTaskIDList(taskIDs []string) string {
+	if len(taskIDs) == 1 {
+		return "task " + taskIDs[0]
+	}
+	return fmt.Sprintf("%d tasks (%s)", len(taskIDs), strings.Join(taskIDs, ", "))
 }
 
 // Init initializes the model and starts watching for file changes
@@ -1793,11 +1869,17 @@ func (m Model) Init() tea.Cmd {
 	// 2. Start watching for task file changes
 	// 3. Start watching for config changes
 	// 4. Start listening for executor output
+	// 5. Resume or idle-stop a timer left running by the last session
+	// 6. Scan the git history for task branches and commits
 	return tea.Batch(
 		LoadTasksCmd(m.taskService),
 		WaitForTasksReload(m.taskService),
 		WaitForConfigReload(m.configManager),
 		WaitForExecutorOutput(m.execService),
+		checkIdleTimerCmd(m.taskService),
+		timerTickCmd(),
+		scanGitCmd(m.taskService),
+		gitTickCmd(),
 	)
 }
 
@@ -1866,17 +1948,14 @@ func (m *Model) updateFilteredTasks() {
 	}
 
 	// Apply search filter on top of status filter
-	if m.searchQuery == "" {
+	if m.searchFilter.IsEmpty() {
 		m.visibleTasks = statusFiltered
 		m.searchResults = nil
 	} else {
-		lowerQuery := strings.ToLower(m.searchQuery)
 		var searchFiltered []*taskmaster.Task
 
 		for _, task := range statusFiltered {
-			if strings.Contains(strings.ToLower(task.ID), lowerQuery) ||
-				strings.Contains(strings.ToLower(task.Title), lowerQuery) ||
-				strings.Contains(strings.ToLower(task.Description), lowerQuery) {
+			if m.searchFilter.Match(task) {
 				searchFiltered = append(searchFiltered, task)
 			}
 		}
@@ -1940,14 +2019,25 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 		m.tasks = msg.Tasks
 		m.buildTaskIndex()
 
-		// Select first task if available
-		if len(m.tasks) > 0 {
+		// Keep the current selection if it survived the reload, otherwise
+		// select the first task
+		selectedID := ""
+		if m.selectedTask != nil {
+			selectedID = m.selectedTask.ID
+		}
+		m.rebuildVisibleTasks()
+		if _, ok := m.taskIndex[selectedID]; ok {
+			m.ensureTaskSelected(selectedID)
+		} else if len(m.tasks) > 0 {
 			m.selectedTask = &m.tasks[0]
 		}
+		if m.viewMode == ViewModeKanban {
+			m.syncKanbanColumn()
+		}
 
 		m.updateTaskListViewport()
 		m.updateDetailsViewport()
-		return m, nil
+		return m, loadComplexityTrendCmd(m.taskService)
 
 	case TasksReloadedMsg:
 		// Tasks were reloaded from disk, refresh the view
@@ -1971,6 +2061,10 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 	case ConfigReloadedMsg:
 		// Config was reloaded from disk, update local reference
 		m.config = m.configManager.GetConfig()
+		if m.taskService != nil {
+			// Later complexity analyses pick up changed scoring weights
+			m.taskService.SetComplexityConfig(m.config.Complexity)
+		}
 		m.addLogLine("Configuration reloaded")
 
 		// Continue listening for next reload
@@ -2054,6 +2148,15 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case prdOfflineParsedMsg:
+		return m, m.handlePrdOfflineParsed(msg)
+
+	case prdSyncPlannedMsg:
+		return m, m.handlePrdSyncPlanned(msg)
+
+	case prdSyncAppliedMsg:
+		return m, m.handlePrdSyncApplied(msg)
+
 	case parsePrdStreamClosedMsg:
 		m.clearParsePrdRuntimeState()
 		return m, nil
@@ -2118,6 +2221,10 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 		cmd := m.handleComplexityExportRequest(msg)
 		return m, cmd
 
+	case complexityTrendLoadedMsg:
+		m.handleComplexityTrendLoaded(msg)
+		return m, nil
+
 	case ComplexityExportCompletedMsg:
 		// Handle export completion
 		cmd := m.handleComplexityExportCompleted(msg)
@@ -2198,6 +2305,58 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.showTagListDialog(msg.List)
 		return m, nil
+	case TaskStatusUpdatedMsg:
+		if len(msg.TaskIDs) > 0 {
+			m.addLogLine(fmt.Sprintf("✓ Set %s to %s", formatTaskIDList(msg.TaskIDs), msg.Status))
+		}
+		if msg.Err != nil && !m.showMergeConflict(msg.Err) {
+			appErr := NewOperationError("Set Status", "Failed to update task status", msg.Err).
+				WithRecoveryHints(
+					"Check that tasks.json is writable",
+					"Reload tasks and try again",
+				)
+			m.showAppError(appErr)
+		}
+		if cmd := m.timerForStatusChange(msg.TaskIDs, msg.Status); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		cmds = append(cmds, LoadTasksCmd(m.taskService))
+		return m, tea.Batch(cmds...)
+	case TimerUpdatedMsg:
+		return m, m.handleTimerUpdated(msg)
+	case timerTickMsg:
+		m.updateDetailsViewport()
+		return m, tea.Batch(checkIdleTimerCmd(m.taskService), timerTickCmd())
+	case gitTickMsg:
+		return m, tea.Batch(scanGitCmd(m.taskService), gitTickCmd())
+	case gitScannedMsg:
+		return m, m.handleGitScanned(msg)
+	case gitBranchSwitchedMsg:
+		return m, m.handleGitBranchSwitched(msg)
+	case gitStatusSyncedMsg:
+		return m, m.handleGitStatusSynced(msg)
+	case TimesheetExportedMsg:
+		if msg.Err != nil {
+			m.ShowNotificationDialog("Export Failed", fmt.Sprintf("Error exporting timesheet: %s", msg.Err), "error", 5*time.Second)
+			return m, nil
+		}
+		m.ShowNotificationDialog("Export Successful", fmt.Sprintf("Timesheet exported to: %s", msg.FilePath), "success", 5*time.Second)
+		return m, nil
+	case HistoryMovedMsg:
+		return m, m.handleHistoryMoved(msg)
+	case MergeResolvedMsg:
+		return m, m.handleMergeResolved(msg)
+	case TaskEditedMsg:
+		return m, m.handleTaskEdited(msg)
+	case TaskMarkdownEditedMsg:
+		return m, m.handleTaskMarkdownEdited(msg)
+	case BulkAppliedMsg:
+		return m, m.handleBulkApplied(msg)
+	case LinkOpenedMsg:
+		if msg.Err != nil {
+			m.addLogLine(fmt.Sprintf("Failed to open %s: %v", msg.URL, msg.Err))
+		}
+		return m, nil
 	case TagOperationMsg:
 		if cmd := m.handleTagOperationMsg(msg); cmd != nil {
 			return m, cmd
@@ -2352,6 +2511,11 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// Any key press counts as activity for the task timer
+		if m.taskService != nil {
+			m.taskService.TouchTimer()
+		}
+
 		// Handle help overlay mode first - takes priority
 		if m.showHelp {
 			switch msg.String() {
@@ -2440,7 +2604,7 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 			case "esc":
 				// Exit search mode
 				m.searchMode = false
-				m.searchQuery = ""
+				m.setSearchQuery("")
 				m.searchInput.SetValue("")
 				m.searchResults = nil
 				m.visibleTasks = nil
@@ -2448,10 +2612,12 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 				m.addLogLine("Search cancelled")
 			case "enter":
 				// Confirm search
-				m.searchQuery = m.searchInput.Value()
+				m.setSearchQuery(m.searchInput.Value())
 
-				// Only exit search mode if there's actual input
-				if m.searchQuery != "" {
+				// Only exit search mode if there's valid input
+				if m.searchErr != nil {
+					m.addLogLine("Invalid query: " + m.searchErr.Error())
+				} else if m.searchQuery != "" {
 					m.searchMode = false
 					m.updateSearchResults()
 					if len(m.searchResults) == 0 {
@@ -2462,7 +2628,7 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			default:
 				// Update search results as user types
-				m.searchQuery = m.searchInput.Value()
+				m.setSearchQuery(m.searchInput.Value())
 				m.updateSearchResults()
 			}
 			return m, cmd
@@ -2543,23 +2709,68 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 			case key.Matches(msg, m.keyMap.Up):
-				m.selectPrevious()
+				if m.viewMode == ViewModeKanban {
+					m.kanbanMoveVertical(-1)
+				} else if m.viewMode == ViewModeGraph {
+					m.graphMoveVertical(-1)
+				} else {
+					m.selectPrevious()
+				}
 				m.updateTaskListViewport()
 				m.updateDetailsViewport()
 
 			case key.Matches(msg, m.keyMap.Down):
-				m.selectNext()
+				if m.viewMode == ViewModeKanban {
+					m.kanbanMoveVertical(1)
+				} else if m.viewMode == ViewModeGraph {
+					m.graphMoveVertical(1)
+				} else {
+					m.selectNext()
+				}
 				m.updateTaskListViewport()
 				m.updateDetailsViewport()
 
 			case key.Matches(msg, m.keyMap.Left):
-				m.collapseSelected()
+				if m.viewMode == ViewModeKanban {
+					m.kanbanMoveHorizontal(-1)
+					m.updateDetailsViewport()
+				} else if m.viewMode == ViewModeGraph {
+					m.graphFollowEdge(true)
+					m.updateDetailsViewport()
+				} else {
+					m.collapseSelected()
+				}
 				m.updateTaskListViewport()
 
 			case key.Matches(msg, m.keyMap.Right):
-				m.expandSelected()
+				if m.viewMode == ViewModeKanban {
+					m.kanbanMoveHorizontal(1)
+					m.updateDetailsViewport()
+				} else if m.viewMode == ViewModeGraph {
+					m.graphFollowEdge(false)
+					m.updateDetailsViewport()
+				} else {
+					m.expandSelected()
+				}
+				m.updateTaskListViewport()
+
+			case m.viewMode == ViewModeKanban && key.Matches(msg, m.keyMap.MoveCardLeft):
+				if cmd := m.moveKanbanCard(-1); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 				m.updateTaskListViewport()
 
+			case m.viewMode == ViewModeKanban && key.Matches(msg, m.keyMap.MoveCardRight):
+				if cmd := m.moveKanbanCard(1); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+				m.updateTaskListViewport()
+
+			case m.viewMode == ViewModeKanban && key.Matches(msg, m.keyMap.ToggleFinishedColumns):
+				m.toggleKanbanFinishedColumns()
+				m.updateTaskListViewport()
+				m.updateDetailsViewport()
+
 			case key.Matches(msg, m.keyMap.ToggleExpand):
 				m.toggleExpanded()
 				m.updateTaskListViewport()
@@ -2618,29 +2829,44 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 				// Cycle through status filters
 				m.cycleStatusFilter()
 
+			case key.Matches(msg, m.keyMap.CycleSort):
+				m.cycleSortMode()
+
 			case key.Matches(msg, m.keyMap.SetInProgress):
 				// Set task(s) to in-progress
-				m.setTaskStatus("in-progress")
+				if cmd := m.setTaskStatus("in-progress"); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 
 			case key.Matches(msg, m.keyMap.SetDone):
 				// Set task(s) to done
-				m.setTaskStatus("done")
+				if cmd := m.setTaskStatus("done"); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 
 			case key.Matches(msg, m.keyMap.SetBlocked):
 				// Set task(s) to blocked
-				m.setTaskStatus("blocked")
+				if cmd := m.setTaskStatus("blocked"); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 
 			case key.Matches(msg, m.keyMap.SetCancelled):
 				// Set task(s) to cancelled
-				m.setTaskStatus("cancelled")
+				if cmd := m.setTaskStatus("cancelled"); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 
 			case key.Matches(msg, m.keyMap.SetDeferred):
 				// Set task(s) to deferred
-				m.setTaskStatus("deferred")
+				if cmd := m.setTaskStatus("deferred"); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 
 			case key.Matches(msg, m.keyMap.SetPending):
 				// Set task(s) to pending
-				m.setTaskStatus("pending")
+				if cmd := m.setTaskStatus("pending"); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 
 			case key.Matches(msg, m.keyMap.CyclePanel):
 				// Cycle focus between panels
@@ -2716,6 +2942,40 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 					m.addLogLine("Switched to list view")
 				}
 
+			case key.Matches(msg, m.keyMap.ViewKanban):
+				// Switch to kanban view
+				if m.viewMode != ViewModeKanban {
+					selectedID := ""
+					if m.selectedTask != nil {
+						selectedID = m.selectedTask.ID
+					}
+					m.viewMode = ViewModeKanban
+					m.rebuildVisibleTasks()
+					if selectedID != "" {
+						m.ensureTaskSelected(selectedID)
+					}
+					m.syncKanbanColumn()
+					m.updateTaskListViewport()
+					m.addLogLine("Switched to kanban view")
+				}
+
+			case key.Matches(msg, m.keyMap.ViewGraph):
+				// Switch to dependency graph view
+				if m.viewMode != ViewModeGraph {
+					selectedID := ""
+					if m.selectedTask != nil {
+						selectedID = m.selectedTask.ID
+					}
+					m.viewMode = ViewModeGraph
+					m.graphPrevID = ""
+					m.rebuildVisibleTasks()
+					if selectedID != "" {
+						m.ensureTaskSelected(selectedID)
+					}
+					m.updateTaskListViewport()
+					m.addLogLine("Switched to dependency graph view")
+				}
+
 			case key.Matches(msg, m.keyMap.CycleView):
 				// Cycle through view modes
 				selectedID := ""
@@ -2728,10 +2988,13 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 					m.viewMode = ViewModeList
 					m.addLogLine("Switched to list view")
 				case ViewModeList:
-					// Skip kanban for now since it's not implemented
-					m.viewMode = ViewModeTree
-					m.addLogLine("Switched to tree view")
+					m.viewMode = ViewModeKanban
+					m.addLogLine("Switched to kanban view")
 				case ViewModeKanban:
+					m.viewMode = ViewModeGraph
+					m.graphPrevID = ""
+					m.addLogLine("Switched to dependency graph view")
+				case ViewModeGraph:
 					m.viewMode = ViewModeTree
 					m.addLogLine("Switched to tree view")
 				}
@@ -2740,6 +3003,9 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 				if selectedID != "" {
 					m.ensureTaskSelected(selectedID)
 				}
+				if m.viewMode == ViewModeKanban {
+					m.syncKanbanColumn()
+				}
 				m.updateTaskListViewport()
 
 			case key.Matches(msg, m.keyMap.Help):
@@ -2750,7 +3016,7 @@ func (m Model) Update(incomingMsg tea.Msg) (tea.Model, tea.Cmd) {
 				// Clear search and/or filter if active
 				cleared := false
 				if m.searchQuery != "" {
-					m.searchQuery = ""
+					m.setSearchQuery("")
 					m.searchInput.SetValue("")
 					m.searchResults = nil
 					cleared = true
@@ -3047,6 +3313,11 @@ func (m Model) renderHelpOverlay() string {
 		{m.renderBinding(m.keyMap.ToggleLog) + "  Toggle log panel"},
 		{m.renderBinding(m.keyMap.ViewTree) + "  Switch to tree view"},
 		{m.renderBinding(m.keyMap.ViewList) + "  Switch to list view"},
+		{m.renderBinding(m.keyMap.ViewKanban) + "  Switch to kanban view"},
+		{m.renderBinding(m.keyMap.ViewGraph) + "  Switch to dependency graph view"},
+		{m.renderBinding(m.keyMap.MoveCardLeft) + "  Kanban: move card left"},
+		{m.renderBinding(m.keyMap.MoveCardRight) + "  Kanban: move card right"},
+		{m.renderBinding(m.keyMap.ToggleFinishedColumns) + "  Kanban: collapse done/cancelled"},
 		{m.renderBinding(m.keyMap.CycleView) + "  Cycle view modes"},
 	}
 	panelSection := createSection(" PANELS & VIEWS", panelBindings)
@@ -0,0 +1,360 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bulkActions lists the bulk actions in menu order.
+var bulkActions = []struct {
+	action      taskmaster.BulkAction
+	label       string
+	description string
+}{
+	{taskmaster.BulkSetStatus, "Set status", "Give every task the same status"},
+	{taskmaster.BulkSetPriority, "Set priority", "Give every task the same priority"},
+	{taskmaster.BulkAddTag, "Add tag", "Label every task with a tag"},
+	{taskmaster.BulkRemoveTag, "Remove tag", "Remove a tag from every task that has it"},
+	{taskmaster.BulkAddDependency, "Add dependency", "Make every task depend on one task"},
+	{taskmaster.BulkMoveToTag, "Move to tag context", "Move top-level tasks and their subtasks into another tag"},
+	{taskmaster.BulkExpand, "Expand", "Break every task into subtasks with task-master"},
+}
+
+// bulkPriorities lists the priorities offered by the bulk set-priority action.
+var bulkPriorities = []string{
+	taskmaster.PriorityCritical,
+	taskmaster.PriorityHigh,
+	taskmaster.PriorityMedium,
+	taskmaster.PriorityLow,
+}
+
+// showBulkActionsDialog lists the actions that can be applied to the
+// selected tasks, or to the current task when nothing is selected.
+func (m *Model) showBulkActionsDialog() {
+	ids := m.selectedOrCurrentTaskIDs()
+	if len(ids) == 0 {
+		appErr := NewValidationError("Bulk Actions", "Select at least one task first.", nil).
+			WithRecoveryHints("Use space to select tasks, then press B again")
+		m.showAppError(appErr)
+		return
+	}
+	taskmaster.SortTaskIDs(ids)
+
+	choices := make([]bulkChoice, len(bulkActions))
+	for i, entry := range bulkActions {
+		choices[i] = bulkChoice{value: string(entry.action), title: entry.label, description: entry.description}
+	}
+	title := fmt.Sprintf("Bulk Actions · %s", pluralTasks(len(ids)))
+	m.showBulkChoiceDialog(title, choices, func(value string) tea.Cmd {
+		return m.promptBulkValue(ids, taskmaster.BulkAction(value))
+	})
+}
+
+// promptBulkValue asks for the value of action, then applies it to ids.
+func (m *Model) promptBulkValue(ids []string, action taskmaster.BulkAction) tea.Cmd {
+	apply := func(value string) tea.Cmd {
+		return m.applyBulk(ids, taskmaster.BulkOperation{Action: action, Value: value})
+	}
+
+	switch action {
+	case taskmaster.BulkSetStatus:
+		m.showBulkChoiceDialog("Set Status", bulkValueChoices(kanbanStatuses), apply)
+	case taskmaster.BulkSetPriority:
+		m.showBulkChoiceDialog("Set Priority", bulkValueChoices(bulkPriorities), apply)
+	case taskmaster.BulkAddTag:
+		m.showBulkTextDialog("Add Tag", "Tag:", "api", nil, apply)
+	case taskmaster.BulkRemoveTag:
+		tags := m.bulkTaskTags(ids)
+		if len(tags) == 0 {
+			m.addLogLine("The selected tasks have no tags")
+			return nil
+		}
+		m.showBulkChoiceDialog("Remove Tag", bulkValueChoices(tags), apply)
+	case taskmaster.BulkAddDependency:
+		selected := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			selected[id] = true
+		}
+		candidates := make([]string, 0, len(m.taskIndex))
+		for id := range m.taskIndex {
+			if !selected[id] {
+				candidates = append(candidates, id)
+			}
+		}
+		taskmaster.SortTaskIDs(candidates)
+		m.showBulkTextDialog("Add Dependency", "Depends on:", "task ID", candidates, apply)
+	case taskmaster.BulkMoveToTag:
+		active := ""
+		if m.config != nil {
+			active = m.config.ActiveTag
+		}
+		var tags []string
+		for _, name := range m.taskService.TagNames() {
+			if name != active {
+				tags = append(tags, name)
+			}
+		}
+		if len(tags) == 0 {
+			m.addLogLine("No other tag contexts to move tasks to")
+			return nil
+		}
+		m.showBulkChoiceDialog("Move to Tag Context", bulkValueChoices(tags), apply)
+	case taskmaster.BulkExpand:
+		m.showBulkExpandDialog(ids)
+	}
+	return nil
+}
+
+// applyBulk runs op against ids in the background.
+func (m *Model) applyBulk(ids []string, op taskmaster.BulkOperation) tea.Cmd {
+	m.addLogLine(fmt.Sprintf("%s…", op.Describe(len(ids))))
+	svc := m.taskService
+	return func() tea.Msg {
+		result, err := svc.ApplyBulk(context.Background(), ids, op)
+		return BulkAppliedMsg{Operation: op, Result: result, Err: err}
+	}
+}
+
+// handleBulkApplied reports the outcome of a bulk action, clears the
+// selection it was applied to and reloads the tasks.
+func (m *Model) handleBulkApplied(msg BulkAppliedMsg) tea.Cmd {
+	if msg.Err != nil && msg.Result == nil {
+		if !m.showMergeConflict(msg.Err) {
+			appErr := NewOperationError("Bulk Actions", fmt.Sprintf("Failed to apply %s", msg.Operation.Action), msg.Err).
+				WithRecoveryHints(
+					"Check that tasks.json is writable",
+					"Reload tasks and try again",
+				)
+			m.showAppError(appErr)
+		}
+		return nil
+	}
+
+	result := msg.Result
+	m.clearSelection()
+	if len(result.Changed) > 0 {
+		m.addLogLine(fmt.Sprintf("✓ %s", msg.Operation.Describe(len(result.Changed))))
+	}
+	if len(result.Failed) > 0 {
+		m.addLogLine(fmt.Sprintf("✗ %s failed", pluralTasks(len(result.Failed))))
+	}
+	if msg.Err != nil {
+		m.addLogLine(fmt.Sprintf("Reload after bulk action failed: %v", msg.Err))
+	}
+	if len(result.Changed) == 0 && len(result.Failed) == 0 {
+		m.addLogLine("Nothing to change: the selected tasks already match")
+		return nil
+	}
+	m.showBulkSummary(msg.Operation, result)
+	return LoadTasksCmd(m.taskService)
+}
+
+// showBulkSummary lists what a bulk action changed, left alone and failed.
+func (m *Model) showBulkSummary(op taskmaster.BulkOperation, result *taskmaster.BulkResult) {
+	dm := m.dialogManager()
+	if dm == nil {
+		return
+	}
+
+	items := make([]dialog.ListItem, 0, len(result.Changed)+len(result.Unchanged)+len(result.Failed)+len(result.Notes))
+	for _, id := range result.Changed {
+		items = append(items, dialog.NewSimpleListItem("✓ "+m.bulkTaskLabel(id), "Changed"))
+	}
+	for _, id := range result.Unchanged {
+		items = append(items, dialog.NewSimpleListItem("· "+m.bulkTaskLabel(id), "Already up to date"))
+	}
+	for _, failure := range result.Failed {
+		items = append(items, dialog.NewSimpleListItem("✗ "+m.bulkTaskLabel(failure.TaskID), failure.Reason))
+	}
+	for _, note := range result.Notes {
+		items = append(items, dialog.NewSimpleListItem("ℹ "+note, ""))
+	}
+
+	title := op.Describe(len(result.Changed))
+	if len(result.Failed) > 0 {
+		title += fmt.Sprintf(", %d failed", len(result.Failed))
+	}
+	list := dialog.NewListDialog(title, 80, 20, items)
+	list.SetShowDescription(true)
+	list.SetFooterHints(
+		dialog.ShortcutHint{Key: "↑/↓", Label: "Navigate"},
+		dialog.ShortcutHint{Key: "Esc", Label: "Close"},
+	)
+	if dm.Style != nil {
+		dialog.ApplyStyleToDialog(list, dm.Style)
+	}
+	m.appState.AddDialog(list, nil)
+}
+
+// bulkTaskLabel names a task by ID and, when known, title.
+func (m *Model) bulkTaskLabel(id string) string {
+	if task, ok := m.taskIndex[id]; ok && task.Title != "" {
+		return fmt.Sprintf("%s  %s", id, task.Title)
+	}
+	return id
+}
+
+// bulkTaskTags returns the tags used by any of ids, sorted.
+func (m *Model) bulkTaskTags(ids []string) []string {
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if task, ok := m.taskIndex[id]; ok {
+			for _, tag := range task.Tags {
+				seen[tag] = true
+			}
+		}
+	}
+	tags := make([]string, 0, len(seen))
+	for tag := range seen {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// showBulkExpandDialog asks for the expansion options, then expands ids one
+// after the other with the task-master CLI.
+func (m *Model) showBulkExpandDialog(ids []string) {
+	dm := m.dialogManager()
+	if dm == nil {
+		return
+	}
+	fields := []dialog.FormField{
+		{ID: "num", Label: "Subtasks per task:", Type: dialog.FormFieldTypeText, Placeholder: "default"},
+		{ID: "research", Label: "Use research (--research)", Type: dialog.FormFieldTypeCheckbox},
+		{ID: "force", Label: "Replace existing subtasks (--force)", Type: dialog.FormFieldTypeCheckbox},
+	}
+	form := dialog.NewFormDialog(
+		"Expand "+pluralTasks(len(ids)),
+		"Each task is expanded in turn; one undo reverts them all.",
+		fields,
+		[]string{"Expand", "Cancel"},
+		dm.Style,
+		func(form *dialog.FormDialog, button string, values map[string]interface{}) (interface{}, error) {
+			if button != "Expand" {
+				return nil, nil
+			}
+			opts := taskmaster.ExpandTaskOptions{
+				UseAI: boolValue(values, "research"),
+				Force: boolValue(values, "force"),
+			}
+			if num := strings.TrimSpace(stringValue(values, "num")); num != "" {
+				n, err := strconv.Atoi(num)
+				if err != nil || n <= 0 {
+					return nil, dialog.ErrorFormValidation{FieldID: "num", Message: "Enter a positive number of subtasks"}
+				}
+				opts.NumSubtasks = n
+			}
+			return opts, nil
+		},
+	)
+	m.appState.AddDialog(form, func(value interface{}, err error) tea.Cmd {
+		opts, ok := value.(taskmaster.ExpandTaskOptions)
+		if err != nil || !ok {
+			return nil
+		}
+		return m.applyBulk(ids, taskmaster.BulkOperation{Action: taskmaster.BulkExpand, Expand: opts})
+	})
+}
+
+// showBulkTextDialog asks for a single value, offering suggestions when given.
+func (m *Model) showBulkTextDialog(title, label, placeholder string, suggestions []string, onSubmit func(value string) tea.Cmd) {
+	dm := m.dialogManager()
+	if dm == nil {
+		return
+	}
+	field := dialog.FormField{ID: "value", Label: label, Type: dialog.FormFieldTypeText, Required: true, Placeholder: placeholder, Suggestions: suggestions}
+	if len(suggestions) > 0 {
+		field.Help = "→ completes, ↑/↓ cycles matches"
+	}
+	form := dialog.NewFormDialog(
+		title,
+		"",
+		[]dialog.FormField{field},
+		[]string{"Apply", "Cancel"},
+		dm.Style,
+		func(form *dialog.FormDialog, button string, values map[string]interface{}) (interface{}, error) {
+			if button != "Apply" {
+				return nil, nil
+			}
+			return strings.TrimSpace(stringValue(values, "value")), nil
+		},
+	)
+	m.appState.AddDialog(form, func(value interface{}, err error) tea.Cmd {
+		text, ok := value.(string)
+		if err != nil || !ok || text == "" {
+			return nil
+		}
+		return onSubmit(text)
+	})
+}
+
+// showBulkChoiceDialog lists choices; picking one calls onSelect with its value.
+func (m *Model) showBulkChoiceDialog(title string, choices []bulkChoice, onSelect func(value string) tea.Cmd) {
+	dm := m.dialogManager()
+	if dm == nil {
+		return
+	}
+
+	items := make([]dialog.ListItem, len(choices))
+	for i := range choices {
+		items[i] = &choices[i]
+	}
+	list := dialog.NewListDialog(title, 60, 16, items)
+	list.SetShowDescription(true)
+	list.SetFooterHints(
+		dialog.ShortcutHint{Key: "↑/↓", Label: "Navigate"},
+		dialog.ShortcutHint{Key: "Enter", Label: "Choose"},
+		dialog.ShortcutHint{Key: "Esc", Label: "Cancel"},
+	)
+	if dm.Style != nil {
+		dialog.ApplyStyleToDialog(list, dm.Style)
+	}
+
+	m.appState.AddDialog(list, func(value interface{}, err error) tea.Cmd {
+		if err != nil {
+			return nil
+		}
+		msg, ok := value.(dialog.ListSelectionMsg)
+		if !ok || msg.SelectedItem == nil {
+			return nil
+		}
+		if item, ok := msg.SelectedItem.(*bulkChoice); ok {
+			return onSelect(item.value)
+		}
+		return nil
+	})
+}
+
+// bulkChoice adapts a bulk action or value to a dialog.ListItem.
+type bulkChoice struct {
+	value       string
+	title       string
+	description string
+}
+
+func bulkValueChoices(values []string) []bulkChoice {
+	choices := make([]bulkChoice, len(values))
+	for i, value := range values {
+		choices[i] = bulkChoice{value: value, title: value}
+	}
+	return choices
+}
+
+func (c *bulkChoice) Title() string       { return c.title }
+func (c *bulkChoice) Description() string { return c.description }
+func (c *bulkChoice) FilterValue() string { return c.title }
+
+func pluralTasks(count int) string {
+	if count == 1 {
+		return "1 task"
+	}
+	return fmt.Sprintf("%d tasks", count)
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+)
+
+func TestBulkStatusAppliesToSelectionAndShowsSummary(t *testing.T) {
+	model := newTestModel()
+	svc := mockTaskService()
+	model.taskService = svc
+	model.tasks = svc.tasks
+	model.buildTaskIndex()
+	model.selectedIDs = map[string]bool{"1": true, "2": true, "missing": true}
+
+	model.showBulkActionsDialog()
+	actions, ok := model.appState.ActiveDialog().(*dialog.ListDialog)
+	if !ok || !strings.Contains(actions.Title(), "3 tasks") {
+		t.Fatalf("expected bulk actions list for 3 tasks, got %T", model.appState.ActiveDialog())
+	}
+
+	op := taskmaster.BulkOperation{Action: taskmaster.BulkSetStatus, Value: taskmaster.StatusDone}
+	msg, ok := model.applyBulk([]string{"1", "2", "missing"}, op)().(BulkAppliedMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("expected BulkAppliedMsg, got %+v", msg)
+	}
+	if cmd := model.handleBulkApplied(msg); cmd == nil {
+		t.Fatal("expected tasks to be reloaded")
+	}
+	if len(model.selectedIDs) != 0 {
+		t.Fatalf("expected selection cleared, got %v", model.selectedIDs)
+	}
+	for _, id := range []string{"1", "2"} {
+		if task, _ := svc.GetTaskByID(id); task.Status != taskmaster.StatusDone {
+			t.Fatalf("expected task %s done, got %s", id, task.Status)
+		}
+	}
+
+	summary, ok := model.appState.ActiveDialog().(*dialog.ListDialog)
+	if !ok || summary.Title() != "Set 2 tasks to done, 1 failed" {
+		t.Fatalf("expected summary dialog, got %T", model.appState.ActiveDialog())
+	}
+}
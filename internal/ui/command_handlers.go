@@ -34,14 +34,52 @@ func (m *Model) dispatchCommand(id CommandID) tea.Cmd {
 	switch id {
 	case CommandParsePRD:
 		return m.openParsePrdWorkflow()
+	case CommandResyncPRD:
+		return m.openPrdSyncWorkflow()
 	case CommandAnalyzeComplexity:
 		m.showComplexityScopeDialog()
+	case CommandCalibrateScoring:
+		m.showCalibrationDialog()
 	case CommandExpandTask:
 		return m.handleExpandTaskCommand()
 	case CommandDeleteTask:
 		m.handleDeleteTaskCommand()
 	case CommandRunTask:
 		return m.handleRunTaskCommand()
+	case CommandToggleTimer:
+		return m.toggleTimer()
+	case CommandExportTimesheet:
+		m.showTimesheetExportDialog()
+	case CommandSwitchBranch:
+		return m.switchTaskBranch()
+	case CommandScanGit:
+		return scanGitCmd(m.taskService)
+	case CommandUndo:
+		return m.undoLastChange()
+	case CommandRedo:
+		return m.redoLastChange()
+	case CommandUndoHistory:
+		m.showUndoHistoryDialog()
+	case CommandFilterTasks:
+		m.showTaskQueryDialog()
+	case CommandEditTask:
+		m.showTaskEditor()
+	case CommandEditInEditor:
+		return m.openTaskInEditor()
+	case CommandBulkActions:
+		m.showBulkActionsDialog()
+	case CommandToggleMarkdown:
+		m.toggleRawDetails()
+	case CommandFollowLink:
+		return m.followDetailLink()
+	case CommandSortTasks:
+		m.showSortDialog()
+	case CommandSwitchView:
+		m.showSavedViewsDialog()
+	case CommandSaveView:
+		m.showSaveViewDialog()
+	case CommandDeleteView:
+		m.showDeleteViewDialog()
 	case CommandManageTags:
 		m.openAddTagDialog()
 	case CommandTagManagement:
@@ -167,7 +205,9 @@ func (m *Model) sortedTasksForSelection() []*taskmaster.Task {
 	for id := range m.taskIndex {
 		ids = append(ids, id)
 	}
-	sort.Strings(ids)
+	sort.Slice(ids, func(i, j int) bool {
+		return taskmaster.CompareTaskIDs(ids[i], ids[j]) < 0
+	})
 	tasks := make([]*taskmaster.Task, 0, len(ids))
 	for _, id := range ids {
 		if task, ok := m.taskIndex[id]; ok {
@@ -735,21 +775,15 @@ func (m *Model) applyExpandTaskDrafts(parentID string, drafts []taskmaster.Subta
 		return nil
 	}
 
-	// Get the parent task
-	parentTask, ok := m.taskIndex[parentID]
-	if !ok {
-		m.showErrorDialog("Expand Task", fmt.Sprintf("Parent task %s not found", parentID))
-		return nil
-	}
-
-	// Apply the drafts
-	newIDs, err := taskmaster.ApplySubtaskDrafts(parentTask, drafts)
+	// Apply and persist the drafts
+	newIDs, err := m.taskService.ApplySubtaskDrafts(context.Background(), parentID, drafts)
 	if err != nil {
-		m.showErrorDialog("Expand Task", fmt.Sprintf("Failed to apply subtasks: %v", err))
+		if !m.showMergeConflict(err) {
+			m.showErrorDialog("Expand Task", fmt.Sprintf("Failed to apply subtasks: %v", err))
+		}
 		return nil
 	}
 
-	// Persist the changes by reloading all tasks
 	m.addLogLine(fmt.Sprintf("Successfully created %d subtasks for task %s: %v", len(newIDs), parentID, newIDs))
 
 	// Reload tasks to refresh the UI
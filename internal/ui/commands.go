@@ -13,7 +13,9 @@ type CommandID string
 
 const (
 	CommandParsePRD           CommandID = "parse_prd"
+	CommandResyncPRD          CommandID = "resync_prd"
 	CommandAnalyzeComplexity  CommandID = "analyze_complexity"
+	CommandCalibrateScoring   CommandID = "calibrate_scoring"
 	CommandExpandTask         CommandID = "expand_task"
 	CommandDeleteTask         CommandID = "delete_task"
 	CommandManageTags         CommandID = "manage_tags"
@@ -23,6 +25,23 @@ const (
 	CommandProjectQuickSwitch CommandID = "project_quick_switch"
 	CommandProjectSearch      CommandID = "project_search"
 	CommandRunTask            CommandID = "run_task"
+	CommandToggleTimer        CommandID = "toggle_timer"
+	CommandExportTimesheet    CommandID = "export_timesheet"
+	CommandUndo               CommandID = "undo"
+	CommandRedo               CommandID = "redo"
+	CommandUndoHistory        CommandID = "undo_history"
+	CommandFilterTasks        CommandID = "filter_tasks"
+	CommandSwitchView         CommandID = "switch_view"
+	CommandSaveView           CommandID = "save_view"
+	CommandDeleteView         CommandID = "delete_view"
+	CommandSortTasks          CommandID = "sort_tasks"
+	CommandEditTask           CommandID = "edit_task"
+	CommandEditInEditor       CommandID = "edit_in_editor"
+	CommandBulkActions        CommandID = "bulk_actions"
+	CommandToggleMarkdown     CommandID = "toggle_markdown"
+	CommandFollowLink         CommandID = "follow_link"
+	CommandSwitchBranch       CommandID = "switch_branch"
+	CommandScanGit            CommandID = "scan_git"
 )
 
 // CommandSpec captures palette metadata for a command.
@@ -36,10 +55,29 @@ type CommandSpec struct {
 func defaultCommandSpecs() []CommandSpec {
 	return []CommandSpec{
 		{ID: CommandParsePRD, Label: "Parse PRD", Description: "Parse a PRD file and generate tasks", Shortcut: "Alt+P"},
+		{ID: CommandResyncPRD, Label: "Re-sync PRD", Description: "Compare a revised PRD with the tasks parsed from it and review adds, updates and orphaned tasks"},
 		{ID: CommandAnalyzeComplexity, Label: "Analyze Complexity", Description: "Run complexity analysis via Task Master", Shortcut: "Alt+C"},
+		{ID: CommandCalibrateScoring, Label: "Calibrate Complexity", Description: "Fit scoring weights to actual hours of completed tasks"},
+		{ID: CommandEditTask, Label: "Edit Task", Description: "Edit the title, description, details, priority, dependencies, tags and estimate of the selected task", Shortcut: "E"},
+		{ID: CommandEditInEditor, Label: "Edit in $EDITOR", Description: "Edit the selected task as Markdown in your editor and review the changes", Shortcut: "Ctrl+E"},
+		{ID: CommandBulkActions, Label: "Bulk Actions", Description: "Set status or priority, tag, add a dependency, move to another tag or expand all selected tasks at once", Shortcut: "B"},
+		{ID: CommandToggleMarkdown, Label: "Toggle Markdown Details", Description: "Switch the details panel between rendered Markdown and raw text", Shortcut: "M"},
+		{ID: CommandFollowLink, Label: "Follow Link", Description: "Jump to a task or open a URL referenced in the selected task", Shortcut: "G"},
 		{ID: CommandExpandTask, Label: "Expand Task", Description: "Break down the selected task with AI", Shortcut: "Alt+E"},
 		{ID: CommandDeleteTask, Label: "Delete Task", Description: "Open the safe delete workflow for selected tasks", Shortcut: "Alt+D"},
 		{ID: CommandRunTask, Label: "Run Task with Crush", Description: "Execute the selected task via Crush AI agent", Shortcut: "Alt+R / Ctrl+R"},
+		{ID: CommandToggleTimer, Label: "Start/Stop Timer", Description: "Track time on the selected task", Shortcut: "S"},
+		{ID: CommandExportTimesheet, Label: "Export Timesheet", Description: "Export tracked time per day and task as CSV"},
+		{ID: CommandSwitchBranch, Label: "Switch to Task Branch", Description: "Check out the git branch of the selected task, creating it from the task ID and title"},
+		{ID: CommandScanGit, Label: "Refresh Git Activity", Description: "Rescan git history for task branches, commits referencing tasks and merges"},
+		{ID: CommandFilterTasks, Label: "Filter Tasks", Description: "Filter the task list with a query such as status:pending priority:>=high"},
+		{ID: CommandSortTasks, Label: "Sort Tasks", Description: "Order tasks by priority, status, complexity, ID, last update or estimate", Shortcut: "O"},
+		{ID: CommandSwitchView, Label: "Switch View", Description: "Apply a saved view of filters and layout", Shortcut: "V"},
+		{ID: CommandSaveView, Label: "Save View", Description: "Save the current query, filter, view mode, sort order and expanded tasks as a named view"},
+		{ID: CommandDeleteView, Label: "Delete View", Description: "Remove a saved view"},
+		{ID: CommandUndo, Label: "Undo", Description: "Revert the most recent task change", Shortcut: "Ctrl+Z"},
+		{ID: CommandRedo, Label: "Redo", Description: "Reapply the most recently undone change", Shortcut: "Ctrl+Y"},
+		{ID: CommandUndoHistory, Label: "Undo History", Description: "Browse past changes and jump back to any point", Shortcut: "H"},
 		{ID: CommandManageTags, Label: "Add Tag Context", Description: "Create a new tag context", Shortcut: "Ctrl+Shift+A"},
 		{ID: CommandTagManagement, Label: "Manage Tag Contexts", Description: "View and modify tag contexts", Shortcut: "Ctrl+Shift+M"},
 		{ID: CommandUseTag, Label: "Use Tag Context", Description: "Switch the active Task Master tag", Shortcut: "Ctrl+Shift+U"},
@@ -13,6 +13,12 @@ import (
 
 type complexityStreamClosedMsg struct{}
 
+// complexityTrendLoadedMsg carries the history read by loadComplexityTrendCmd.
+type complexityTrendLoadedMsg struct {
+	Trend *taskmaster.ComplexityTrend
+	Err   error
+}
+
 func dialogEnqueuedCmd() tea.Cmd {
 	return func() tea.Msg { return nil }
 }
@@ -30,7 +36,13 @@ func (m *Model) showComplexityScopeDialog() {
 	}
 
 	// Create the dialog
-	scopeDialog, err := dialog.NewComplexityScopeDialog(selectedTaskID, dm.Style)
+	// Show the weights the analysis will use
+	var profile *taskmaster.ScoringProfile
+	if m.taskService != nil {
+		current := m.taskService.ScoringProfile()
+		profile = &current
+	}
+	scopeDialog, err := dialog.NewComplexityScopeDialogWithProfile(selectedTaskID, profile, dm.Style)
 	if err != nil {
 		m.logLines = append(m.logLines, fmt.Sprintf("Error creating complexity scope dialog: %s", err))
 		return
@@ -68,6 +80,40 @@ func (m *Model) showComplexityScopeDialog() {
 	})
 }
 
+// showCalibrationDialog fits scoring weights to completed tasks' actual
+// hours and offers to save them to the project config.
+func (m *Model) showCalibrationDialog() {
+	dm := m.dialogManager()
+	if dm == nil || m.taskService == nil {
+		return
+	}
+
+	result, err := m.taskService.CalibrateComplexity()
+	if err != nil {
+		m.showErrorDialog("Calibrate Complexity", err.Error())
+		return
+	}
+
+	calibrationDialog := dialog.NewCalibrationDialog(result, dm.Style)
+	m.appState.AddDialog(calibrationDialog, func(value interface{}, err error) tea.Cmd {
+		decision, ok := value.(dialog.CalibrationDecision)
+		if err != nil || !ok {
+			return nil
+		}
+		if err := m.taskService.SaveScoringWeights(decision.Weights, decision.ForActiveTag); err != nil {
+			return func() tea.Msg {
+				return ErrorMsg{Err: fmt.Errorf("failed to save scoring weights: %w", err)}
+			}
+		}
+		target := "project"
+		if decision.ForActiveTag {
+			target = fmt.Sprintf("tag %s", result.Tag)
+		}
+		m.addLogLine(fmt.Sprintf("Saved calibrated scoring weights for %s (R² %.2f)", target, result.RSquared))
+		return nil
+	})
+}
+
 // handleComplexityScopeSelected handles the selected complexity scope
 func (m *Model) handleComplexityScopeSelected(msg ComplexityScopeSelectedMsg) tea.Cmd {
 	dm := m.dialogManager()
@@ -186,7 +232,9 @@ func (m *Model) handleComplexityAnalysisCompleted(msg ComplexityAnalysisComplete
 	if dm == nil {
 		return nil
 	}
+	// The trend is refreshed in the background to include this report
 	reportDialog := dialog.NewComplexityReportDialog(msg.Report, dm.Style)
+	reportDialog.SetTrend(m.complexityTrend)
 	reportDialog.SetSize(m.width, m.height)
 
 	// Add the dialog
@@ -229,7 +277,7 @@ func (m *Model) handleComplexityAnalysisCompleted(msg ComplexityAnalysisComplete
 		return dialogEnqueuedCmd()
 	})
 
-	return dialogEnqueuedCmd()
+	return tea.Batch(dialogEnqueuedCmd(), loadComplexityTrendCmd(m.taskService))
 }
 
 func (m *Model) startComplexityAnalysis(scope string, taskID string, tags []string, totalTasks int) tea.Cmd {
@@ -404,7 +452,7 @@ func (m *Model) handleComplexityReportAction(msg ComplexityReportActionMsg) tea.
 			}
 
 			// Extract export settings
-			settings, ok := value.(map[string]string)
+			request, ok := value.(dialog.ExportRequest)
 			if !ok {
 				return func() tea.Msg {
 					return ErrorMsg{Err: fmt.Errorf("invalid export settings")}
@@ -414,8 +462,8 @@ func (m *Model) handleComplexityReportAction(msg ComplexityReportActionMsg) tea.
 			// Export the report
 			return func() tea.Msg {
 				return ComplexityExportRequestMsg{
-					Format: settings["format"],
-					Path:   settings["path"],
+					Format: request.Format,
+					Path:   request.FilePath,
 				}
 			}
 		})
@@ -482,3 +530,33 @@ func (m *Model) handleComplexityExportCompleted(msg ComplexityExportCompletedMsg
 	m.ShowNotificationDialog("Export Successful", fmt.Sprintf("Complexity report exported to: %s", msg.FilePath), "success", 5*time.Second)
 	return nil
 }
+
+// loadComplexityTrendCmd reads the active tag's complexity history, used by
+// the report trend view and the details panel sparkline, off the UI loop.
+func loadComplexityTrendCmd(svc TaskService) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		trend, err := svc.ComplexityTrend()
+		return complexityTrendLoadedMsg{Trend: trend, Err: err}
+	}
+}
+
+// handleComplexityTrendLoaded stores the loaded history and passes it to an
+// open report dialog.
+func (m *Model) handleComplexityTrendLoaded(msg complexityTrendLoadedMsg) {
+	if msg.Err != nil {
+		m.addLogLine(fmt.Sprintf("Failed to load complexity history: %v", msg.Err))
+		return
+	}
+	m.complexityTrend = msg.Trend
+	if dm := m.dialogManager(); dm != nil {
+		if d, ok := dm.GetDialogByModel("ComplexityReportDialog"); ok {
+			if reportDialog, ok := d.(*dialog.ComplexityReportDialog); ok {
+				reportDialog.SetTrend(msg.Trend)
+			}
+		}
+	}
+	m.updateDetailsViewport()
+}
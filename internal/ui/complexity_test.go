@@ -2,11 +2,19 @@ package ui
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/git"
+	"github.com/agreen757/tm-tui/internal/memory"
+	"github.com/agreen757/tm-tui/internal/prd"
 	"github.com/agreen757/tm-tui/internal/projects"
 	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/timetrack"
 	"github.com/agreen757/tm-tui/internal/ui/dialog"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -66,17 +74,36 @@ func mockTaskService() *mockService {
 			},
 		},
 		latestReport: nil,
+		timer:        newMockTracker(),
 		reloadCh:     make(chan struct{}),
 		available:    true,
 	}
 }
 
+// newMockTracker returns a tracker backed by an unpersisted in-memory store.
+func newMockTracker() *timetrack.Tracker {
+	store, _ := memory.NewInMemoryStorage("")
+	return timetrack.NewTracker(func() (memory.Memory, error) {
+		return store, nil
+	}, 10*time.Minute)
+}
+
 // mockService implements a minimal version of the taskmaster.Service for testing
 type mockService struct {
 	tasks        []taskmaster.Task
 	latestReport *taskmaster.ComplexityReport
+	history      []*taskmaster.ComplexityReport
+	scoring      config.ComplexityConfig
+	timer        *timetrack.Tracker
+	undo         taskmaster.UndoHistory
+	conflict     *taskmaster.MergeConflictError
+	resolved     taskmaster.MergeResolution
 	reloadCh     chan struct{}
 	available    bool
+	prdSync      *taskmaster.PRDSyncPlan
+	prdSynced    []taskmaster.PRDSyncChange
+	gitActivity  *git.Activity
+	gitApplied   []*git.Activity
 }
 
 func (s *mockService) GetTasks() ([]taskmaster.Task, []string) {
@@ -172,6 +199,76 @@ func (s *mockService) GetLatestComplexityReport() *taskmaster.ComplexityReport {
 	return s.latestReport
 }
 
+func (s *mockService) ComplexityTrend() (*taskmaster.ComplexityTrend, error) {
+	return taskmaster.NewComplexityTrend("master", s.history), nil
+}
+
+func (s *mockService) ScoringProfile() taskmaster.ScoringProfile {
+	return taskmaster.NewScoringProfile(s.scoring, "master")
+}
+
+func (s *mockService) SetComplexityConfig(cfg config.ComplexityConfig) {
+	s.scoring = cfg
+}
+
+func (s *mockService) CalibrateComplexity() (*taskmaster.CalibrationResult, error) {
+	tasks := make([]*taskmaster.Task, 0, len(s.tasks))
+	for i := range s.tasks {
+		tasks = append(tasks, &s.tasks[i])
+	}
+	return taskmaster.CalibrateWeights(tasks, s.ScoringProfile())
+}
+
+func (s *mockService) SaveScoringWeights(weights taskmaster.ScoringWeights, forActiveTag bool) error {
+	if s.scoring.Weights == nil {
+		s.scoring.Weights = make(map[string]float64)
+	}
+	for name, value := range weights.WeightMap() {
+		s.scoring.Weights[name] = value
+	}
+	return nil
+}
+
+func (s *mockService) StartTimer(ctx context.Context, taskID string) (*timetrack.Entry, error) {
+	return s.timer.Start(ctx, taskID)
+}
+
+func (s *mockService) StopTimer(ctx context.Context) (*timetrack.Entry, error) {
+	return s.timer.Stop(ctx)
+}
+
+func (s *mockService) CheckIdleTimer(ctx context.Context) (*timetrack.Entry, error) {
+	return s.timer.CheckIdle(ctx)
+}
+
+func (s *mockService) TouchTimer() {
+	s.timer.Touch()
+}
+
+func (s *mockService) ActiveTimer() *timetrack.Timer {
+	return s.timer.Active()
+}
+
+func (s *mockService) ExportTimesheet(ctx context.Context, from, to time.Time, outputPath string) (string, error) {
+	return outputPath, nil
+}
+
+func (s *mockService) SwitchTaskBranch(ctx context.Context, taskID string) (string, bool, error) {
+	return "task/" + taskID, true, nil
+}
+
+func (s *mockService) GitActivity(ctx context.Context) (*git.Activity, error) {
+	if s.gitActivity == nil {
+		return nil, git.ErrNotRepository
+	}
+	return s.gitActivity, nil
+}
+
+func (s *mockService) ApplyGitActivity(ctx context.Context, activity *git.Activity) ([]taskmaster.GitStatusChange, error) {
+	s.gitApplied = append(s.gitApplied, activity)
+	return nil, nil
+}
+
 func (s *mockService) ParsePRDWithProgress(ctx context.Context, inputPath string, mode taskmaster.ParsePrdMode, onProgress func(taskmaster.ParsePrdProgressState)) error {
 	if onProgress != nil {
 		onProgress(taskmaster.ParsePrdProgressState{Progress: 1.0, Label: "Parsed"})
@@ -179,6 +276,32 @@ func (s *mockService) ParsePRDWithProgress(ctx context.Context, inputPath string
 	return nil
 }
 
+func (s *mockService) ImportPRD(ctx context.Context, source string, nodes []*prd.Node, mode taskmaster.ParsePrdMode) ([]string, error) {
+	start := len(s.tasks) + 1
+	if mode == taskmaster.ParsePrdModeReplace {
+		s.tasks = nil
+		start = 1
+	}
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = strconv.Itoa(start + i)
+		s.tasks = append(s.tasks, taskmaster.Task{ID: ids[i], Title: node.Title, Description: node.Description, Status: taskmaster.StatusPending})
+	}
+	return ids, nil
+}
+
+func (s *mockService) PlanPRDSync(source string, nodes []*prd.Node) (*taskmaster.PRDSyncPlan, error) {
+	if s.prdSync == nil {
+		return nil, fmt.Errorf("no tasks were generated from %s", source)
+	}
+	return s.prdSync, nil
+}
+
+func (s *mockService) ApplyPRDSync(ctx context.Context, plan *taskmaster.PRDSyncPlan, changes []taskmaster.PRDSyncChange) error {
+	s.prdSynced = append(s.prdSynced, changes...)
+	return nil
+}
+
 func (s *mockService) ExpandTaskWithProgress(ctx context.Context, taskID string, opts taskmaster.ExpandTaskOptions, prompt string, onProgress func(taskmaster.ExpandProgressState)) error {
 	if onProgress != nil {
 		onProgress(taskmaster.ExpandProgressState{Stage: "Expanding task...", Progress: 0.5})
@@ -187,6 +310,14 @@ func (s *mockService) ExpandTaskWithProgress(ctx context.Context, taskID string,
 	return nil
 }
 
+func (s *mockService) ApplySubtaskDrafts(ctx context.Context, parentID string, drafts []taskmaster.SubtaskDraft) ([]string, error) {
+	task, ok := s.GetTaskByID(parentID)
+	if !ok {
+		return nil, fmt.Errorf("task %s not found", parentID)
+	}
+	return taskmaster.ApplySubtaskDrafts(task, drafts)
+}
+
 func (s *mockService) ExecuteExpandWithProgress(ctx context.Context, scope string, taskID string, fromID string, toID string, tags []string, opts taskmaster.ExpandTaskOptions, onProgress func(taskmaster.ExpandProgressState)) error {
 	if onProgress != nil {
 		onProgress(taskmaster.ExpandProgressState{Stage: "Analyzing", Progress: 0.2, Message: "Analyzing tasks..."})
@@ -206,6 +337,52 @@ func (s *mockService) LoadTasks(ctx context.Context) error {
 	return nil
 }
 
+func (s *mockService) SetTaskStatus(taskID, status string) error {
+	if s.conflict != nil {
+		return s.conflict
+	}
+	task, ok := s.GetTaskByID(taskID)
+	if !ok {
+		return fmt.Errorf("task %s not found", taskID)
+	}
+	task.Status = status
+	return nil
+}
+
+func (s *mockService) UpdateTaskFields(ctx context.Context, taskID string, update taskmaster.TaskUpdate) (*taskmaster.Task, error) {
+	task, ok := s.GetTaskByID(taskID)
+	if !ok {
+		return nil, fmt.Errorf("task %s not found", taskID)
+	}
+	if update.Title != nil {
+		task.Title = *update.Title
+	}
+	if update.Status != nil {
+		task.Status = *update.Status
+	}
+	if update.Priority != nil {
+		task.Priority = *update.Priority
+	}
+	return task, nil
+}
+
+func (s *mockService) ApplyBulk(ctx context.Context, taskIDs []string, op taskmaster.BulkOperation) (*taskmaster.BulkResult, error) {
+	result := &taskmaster.BulkResult{}
+	for _, id := range taskIDs {
+		task, ok := s.GetTaskByID(id)
+		switch {
+		case !ok:
+			result.Failed = append(result.Failed, taskmaster.BulkFailure{TaskID: id, Reason: "task not found"})
+		case op.Action == taskmaster.BulkSetStatus && task.Status != op.Value:
+			task.Status = op.Value
+			result.Changed = append(result.Changed, id)
+		default:
+			result.Unchanged = append(result.Unchanged, id)
+		}
+	}
+	return result, nil
+}
+
 func (s *mockService) ReloadEvents() <-chan struct{} {
 	return s.reloadCh
 }
@@ -222,10 +399,62 @@ func (s *mockService) UndoAction(ctx context.Context, actionID string) error {
 	return nil
 }
 
+func (s *mockService) Undo(ctx context.Context) (*taskmaster.UndoAction, error) {
+	if !s.undo.CanUndo() {
+		return nil, taskmaster.ErrNothingToUndo
+	}
+	s.undo.Position--
+	return &s.undo.Actions[s.undo.Position], nil
+}
+
+func (s *mockService) Redo(ctx context.Context) (*taskmaster.UndoAction, error) {
+	if !s.undo.CanRedo() {
+		return nil, taskmaster.ErrNothingToRedo
+	}
+	s.undo.Position++
+	return &s.undo.Actions[s.undo.Position-1], nil
+}
+
+func (s *mockService) UndoHistory() taskmaster.UndoHistory {
+	return s.undo
+}
+
+func (s *mockService) RestoreHistory(ctx context.Context, actionID string) error {
+	for i, action := range s.undo.Actions {
+		if action.ID != actionID {
+			continue
+		}
+		if i < s.undo.Position {
+			s.undo.Position = i
+		} else {
+			s.undo.Position = i + 1
+		}
+		return nil
+	}
+	return taskmaster.ErrUndoNotFound
+}
+
+func (s *mockService) PendingMergeConflict() *taskmaster.MergeConflictError {
+	return s.conflict
+}
+
+func (s *mockService) ResolveMergeConflict(ctx context.Context, resolution taskmaster.MergeResolution) error {
+	if s.conflict == nil {
+		return taskmaster.ErrNoPendingMerge
+	}
+	s.conflict = nil
+	s.resolved = resolution
+	return nil
+}
+
 func (s *mockService) ListTagContexts(ctx context.Context, includeMetadata bool) (*taskmaster.TagList, error) {
 	return &taskmaster.TagList{}, nil
 }
 
+func (s *mockService) TagNames() []string {
+	return []string{"master"}
+}
+
 func (s *mockService) AddTagContext(ctx context.Context, opts taskmaster.TagAddOptions) (*taskmaster.TagOperationResult, error) {
 	return &taskmaster.TagOperationResult{}, nil
 }
@@ -488,3 +717,48 @@ func TestUpdateMethodsForComplexity(t *testing.T) {
 		t.Error("Expected Alt+C to match AnalyzeComplexity keybinding")
 	}
 }
+
+// TestComplexityTrendView verifies the report dialog's trend view and the
+// details panel sparkline.
+func TestComplexityTrendView(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	mockService := mockTaskService()
+	mockService.history = []*taskmaster.ComplexityReport{
+		{AnalyzedAt: start, Tasks: []taskmaster.TaskComplexity{
+			{TaskID: "3", Title: "Complex refactoring task", Score: 6, Level: taskmaster.ComplexityMedium},
+		}},
+		{AnalyzedAt: start.Add(time.Hour), Tasks: []taskmaster.TaskComplexity{
+			{TaskID: "3", Title: "Complex refactoring task", Score: 13, Level: taskmaster.ComplexityVeryHigh},
+		}},
+	}
+
+	trend, _ := mockService.ComplexityTrend()
+	reportDialog := dialog.NewComplexityReportDialog(mockService.history[1], dialog.DefaultDialogStyle())
+	reportDialog.SetTrend(trend)
+	reportDialog.SetSize(100, 30)
+	reportDialog.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if !reportDialog.ShowTrend {
+		t.Fatalf("expected t to toggle the trend view")
+	}
+	view := reportDialog.View()
+	for _, want := range []string{"Level distribution", "Largest score changes", "6 → 13"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected trend view to contain %q", want)
+		}
+	}
+
+	if got := dialog.Sparkline([]int{1, 5, 9}); got != "▁▄█" {
+		t.Errorf("unexpected sparkline %q", got)
+	}
+
+	model := NewModel(&config.Config{}, nil, mockService, nil)
+	model.selectedTask = &mockService.tasks[2]
+	if details := model.renderTaskDetails(); strings.Contains(details, "Score Trend") {
+		t.Errorf("expected no score trend before the history loads")
+	}
+	updated, _ := model.Update(loadComplexityTrendCmd(mockService)())
+	model = updated.(Model)
+	if details := model.renderTaskDetails(); !strings.Contains(details, "Score Trend") {
+		t.Errorf("expected details panel to show the score trend")
+	}
+}
@@ -207,7 +207,10 @@ func (m *Model) performDelete() tea.Cmd {
 	ctx := context.Background()
 	result, err := m.taskService.DeleteTasks(ctx, m.deleteWorkflow.TaskIDs, m.deleteWorkflow.Options)
 	if err != nil {
-		m.showErrorDialog("Delete Task", err.Error())
+		m.deleteWorkflow = nil
+		if !m.showMergeConflict(err) {
+			m.showErrorDialog("Delete Task", err.Error())
+		}
 		return nil
 	}
 	m.addLogLine(fmt.Sprintf("Deleted %d task(s)", result.DeletedCount))
@@ -328,6 +331,10 @@ func (m *Model) handleDialogResultMsg(msg dialog.DialogResultMsg) tea.Cmd {
 			return m.executeUndo("")
 		}
 		m.undoSession = nil
+	case mergeConflictDialogID:
+		if resolution, ok := msg.Value.(taskmaster.MergeResolution); ok {
+			return resolveMergeConflictCmd(m.taskService, resolution)
+		}
 	}
 	return nil
 }
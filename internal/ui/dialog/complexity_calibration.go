@@ -0,0 +1,94 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+)
+
+// Calibration dialog buttons.
+const (
+	calibrationSaveProject = "Save"
+	calibrationSaveTag     = "Save for tag"
+	calibrationDiscard     = "Discard"
+)
+
+// CalibrationDecision is returned by the calibration dialog when the user
+// chooses to save the calibrated weights.
+type CalibrationDecision struct {
+	Weights      taskmaster.ScoringWeights
+	ForActiveTag bool
+}
+
+// NewCalibrationDialog previews calibrated weights, their fit and the
+// recalculated thresholds. Saving returns a CalibrationDecision; discarding
+// returns nil.
+func NewCalibrationDialog(result *taskmaster.CalibrationResult, style *DialogStyle) *FormDialog {
+	lines := FormatCalibration(result)
+	form := NewFormDialog(
+		"Calibrate Complexity Weights",
+		strings.Join(lines, "\n"),
+		nil,
+		[]string{calibrationSaveProject, calibrationSaveTag, calibrationDiscard},
+		style,
+		func(form *FormDialog, button string, values map[string]interface{}) (interface{}, error) {
+			switch button {
+			case calibrationSaveProject:
+				return CalibrationDecision{Weights: result.Weights}, nil
+			case calibrationSaveTag:
+				return CalibrationDecision{Weights: result.Weights, ForActiveTag: true}, nil
+			}
+			return nil, nil
+		},
+	)
+	width, height, x, y := form.GetRect()
+	form.SetRect(width+8, height+len(lines)+1, x, y)
+	return form
+}
+
+// FormatCalibration renders a calibration result as aligned text lines:
+// current and calibrated weights, fit quality and thresholds side by side.
+func FormatCalibration(result *taskmaster.CalibrationResult) []string {
+	if result == nil {
+		return nil
+	}
+	fitted := make(map[string]bool, len(result.Fitted))
+	for _, name := range result.Fitted {
+		fitted[name] = true
+	}
+
+	lines := []string{
+		fmt.Sprintf("Fitted to %d completed tasks with actual hours (tag %s).", result.SampleSize, orDash(result.Tag)),
+		"",
+		fmt.Sprintf("%-20s %9s %11s", "Weight", "Current", "Calibrated"),
+	}
+	current := result.CurrentWeights.WeightMap()
+	calibrated := result.Weights.WeightMap()
+	for _, name := range config.ScoringWeightNames {
+		note := ""
+		if !fitted[name] {
+			note = "  (kept)"
+		}
+		lines = append(lines, fmt.Sprintf("%-20s %9.2f %11.2f%s", name, current[name], calibrated[name], note))
+	}
+
+	lines = append(lines,
+		"",
+		fmt.Sprintf("Fit: R² %.2f (current scores %.2f), RMSE %.1fh", result.RSquared, result.BaselineRSquared, result.RMSE),
+		"",
+		fmt.Sprintf("%-20s %9s %11s", "Threshold", "Current", "Calibrated"),
+		fmt.Sprintf("%-20s %9d %11d", "Low ≤", result.CurrentThresholds.Low, result.CalibratedThresholds.Low),
+		fmt.Sprintf("%-20s %9d %11d", "Medium ≤", result.CurrentThresholds.Medium, result.CalibratedThresholds.Medium),
+		fmt.Sprintf("%-20s %9d %11d", "High ≤", result.CurrentThresholds.High, result.CalibratedThresholds.High),
+	)
+	return lines
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -3,14 +3,16 @@ package dialog
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/agreen757/tm-tui/internal/taskmaster"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // ExportRequest contains the details for file export
 type ExportRequest struct {
-	Format   string // "json", "csv"
+	Format   string // "json", "csv", "markdown"
 	FilePath string // Path for the output file
 }
 
@@ -27,6 +29,7 @@ func ComplexityExportDialog(style *DialogStyle) (*FormDialog, error) {
 	formatOptions := []FormOption{
 		{Value: "csv", Label: "CSV (Comma-Separated Values)"},
 		{Value: "json", Label: "JSON (JavaScript Object Notation)"},
+		{Value: "markdown", Label: "Markdown (summary and task table)"},
 	}
 
 	// Create fields
@@ -45,7 +48,7 @@ func ComplexityExportDialog(style *DialogStyle) (*FormDialog, error) {
 			Type:     FormFieldTypeText,
 			Required: false,
 			Value:    "",
-			Help:     "Leave empty for current project directory",
+			Help:     "Leave empty for .taskmaster/reports",
 		},
 		{
 			ID:       "filename",
@@ -77,25 +80,29 @@ func ComplexityExportDialog(style *DialogStyle) (*FormDialog, error) {
 
 			// Extract custom path if provided
 			path, _ := values["file_path"].(string)
+			path = strings.TrimSpace(path)
 
 			// Extract custom filename if provided
+			ext := taskmaster.ExportExtension(format)
 			filename, _ := values["filename"].(string)
+			filename = strings.TrimSpace(filename)
 			if filename == "" {
 				// Generate filename with timestamp
 				timestamp := time.Now().Format("20060102-150405")
-				filename = fmt.Sprintf("complexity-report-%s.%s", timestamp, format)
+				filename = fmt.Sprintf("complexity-report-%s.%s", timestamp, ext)
 			} else {
 				// Ensure correct extension
 				if filepath.Ext(filename) == "" {
-					filename = fmt.Sprintf("%s.%s", filename, format)
+					filename = fmt.Sprintf("%s.%s", filename, ext)
 				}
 			}
 
-			// Combine path and filename if path provided
-			filePath := filename
-			if path != "" {
-				filePath = filepath.Join(path, filename)
+			// Combine path and filename, keeping exports out of the
+			// project root when no directory is given
+			if path == "" {
+				path = filepath.Join(".taskmaster", "reports")
 			}
+			filePath := filepath.Join(path, filename)
 
 			return ExportRequest{
 				Format:   format,
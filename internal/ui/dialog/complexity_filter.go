@@ -96,58 +96,8 @@ func NewComplexityFilterDialog(
 	return form, nil
 }
 
-// NewComplexityExportDialog creates a dialog for exporting complexity report results
+// NewComplexityExportDialog creates a dialog for exporting complexity report
+// results. The dialog resolves to an ExportRequest.
 func NewComplexityExportDialog(style *DialogStyle) (*FormDialog, error) {
-	// Define options for export format
-	formatOptions := []FormOption{
-		{Value: "csv", Label: "CSV (Comma-Separated Values)"},
-		{Value: "json", Label: "JSON (JavaScript Object Notation)"},
-	}
-
-	// Define fields for the export form
-	fields := []FormField{
-		{
-			ID:       "format",
-			Label:    "Export Format:",
-			Type:     FormFieldTypeRadio,
-			Required: true,
-			Options:  formatOptions,
-			Value:    "csv", // Default to CSV
-		},
-		{
-			ID:       "path",
-			Label:    "Output Path (leave empty for current directory):",
-			Type:     FormFieldTypeText,
-			Required: false,
-			Value:    "",
-		},
-	}
-
-	// Create the form dialog
-	form := NewFormDialog(
-		"Export Complexity Results",
-		"Select export format and location:",
-		fields,
-		[]string{"Export", "Cancel"},
-		style,
-		func(form *FormDialog, button string, values map[string]interface{}) (interface{}, error) {
-			if button != "Export" {
-				return nil, nil // Return nil for cancel
-			}
-
-			// Extract export format and path
-			result := map[string]string{
-				"format": values["format"].(string),
-			}
-
-			// Add path if provided
-			if path, ok := values["path"].(string); ok {
-				result["path"] = path
-			}
-
-			return result, nil
-		},
-	)
-
-	return form, nil
+	return ComplexityExportDialog(style)
 }
@@ -57,6 +57,8 @@ type ComplexityReportDialog struct {
 	Help           help.Model
 	ShowHelp       bool
 	ShowLegend     bool
+	Trend          *taskmaster.ComplexityTrend
+	ShowTrend      bool
 	width          int
 	height         int
 }
@@ -74,18 +76,19 @@ type ComplexityReportKeyMap struct {
 	SortMode key.Binding
 	Filter   key.Binding
 	Export   key.Binding
+	Trend    key.Binding
 	Help     key.Binding
 	Close    key.Binding
 }
 
 func (k ComplexityReportKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Enter, k.SortMode, k.Filter, k.Export, k.Help, k.Close}
+	return []key.Binding{k.Up, k.Down, k.Enter, k.SortMode, k.Filter, k.Export, k.Trend, k.Help, k.Close}
 }
 
 func (k ComplexityReportKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Enter},
-		{k.SortMode, k.Filter, k.Export},
+		{k.SortMode, k.Filter, k.Export, k.Trend},
 		{k.Help, k.Close},
 	}
 }
@@ -117,6 +120,10 @@ func DefaultComplexityReportKeyMap() ComplexityReportKeyMap {
 			key.WithKeys("e"),
 			key.WithHelp("e", "export results"),
 		),
+		Trend: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "toggle trend view"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "toggle help"),
@@ -170,6 +177,11 @@ func NewComplexityReportDialog(
 	return dialog
 }
 
+// SetTrend attaches the complexity history shown by the trend view.
+func (d *ComplexityReportDialog) SetTrend(trend *taskmaster.ComplexityTrend) {
+	d.Trend = trend
+}
+
 // GetSelectedTask returns the currently selected task complexity info
 func (d *ComplexityReportDialog) GetSelectedTask() *taskmaster.TaskComplexity {
 	if d.SelectedIndex < 0 || d.SelectedIndex >= len(d.FilteredTasks) {
@@ -354,6 +366,9 @@ func (d *ComplexityReportDialog) HandleKey(msg tea.KeyMsg) (DialogResult, tea.Cm
 		return DialogResultNone, func() tea.Msg {
 			return ComplexityReportResultMsg{Action: "export"}
 		}
+	case key.Matches(msg, d.KeyMap.Trend):
+		d.ShowTrend = !d.ShowTrend
+		d.Viewport.SetYOffset(0)
 	case key.Matches(msg, d.KeyMap.Help):
 		d.ShowHelp = !d.ShowHelp
 		d.SetSize(d.width, d.height)
@@ -395,6 +410,15 @@ func (d *ComplexityReportDialog) View() string {
 			Render("No complexity analysis results available.")
 	}
 
+	if d.ShowTrend {
+		d.Viewport.SetContent(d.renderTrend())
+		helpView := ""
+		if d.ShowHelp {
+			helpView = "\n" + d.Help.View(d.KeyMap)
+		}
+		return d.RenderBorder(d.Viewport.View() + helpView)
+	}
+
 	// Render the tabular view
 	var sb strings.Builder
 
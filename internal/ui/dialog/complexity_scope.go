@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/agreen757/tm-tui/internal/taskmaster"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -15,6 +16,12 @@ type ComplexityScopeResult struct {
 
 // NewComplexityScopeDialog creates a dialog for selecting the scope of complexity analysis
 func NewComplexityScopeDialog(selectedTaskID string, style *DialogStyle) (*FormDialog, error) {
+	return NewComplexityScopeDialogWithProfile(selectedTaskID, nil, style)
+}
+
+// NewComplexityScopeDialogWithProfile creates the scope dialog and, when
+// profile is non-nil, lists the scoring weights the analysis will use.
+func NewComplexityScopeDialogWithProfile(selectedTaskID string, profile *taskmaster.ScoringProfile, style *DialogStyle) (*FormDialog, error) {
 	// Define options in the form
 	options := []FormOption{
 		{Value: "all", Label: "All tasks in project"},
@@ -43,10 +50,16 @@ func NewComplexityScopeDialog(selectedTaskID string, style *DialogStyle) (*FormD
 		},
 	}
 
+	description := "Select the scope of tasks to analyze (Alt+C)."
+	profileLines := FormatScoringProfile(profile)
+	if len(profileLines) > 0 {
+		description += "\n\n" + strings.Join(profileLines, "\n")
+	}
+
 	// Create the form dialog
 	form := NewFormDialog(
 		"Analyze Task Complexity",
-		"Select the scope of tasks to analyze (Alt+C).",
+		description,
 		fields,
 		[]string{"Analyze", "Cancel"},
 		style,
@@ -77,6 +90,12 @@ func NewComplexityScopeDialog(selectedTaskID string, style *DialogStyle) (*FormD
 		},
 	)
 
+	// Make room for the scoring summary
+	if len(profileLines) > 0 {
+		width, height, x, y := form.GetRect()
+		form.SetRect(width, height+len(profileLines)+1, x, y)
+	}
+
 	// Validate that selected task ID is provided when scope is "selected"
 	form.AddValidator(func(values map[string]interface{}) error {
 		scope, _ := values["scope"].(string)
@@ -109,3 +128,51 @@ func NewComplexityScopeDialog(selectedTaskID string, style *DialogStyle) (*FormD
 
 	return form, nil
 }
+
+// maxProfileKeywords limits how many keywords are listed in the summary.
+const maxProfileKeywords = 6
+
+// FormatScoringProfile summarises a scoring profile in a few short lines so
+// users can see which weights produce complexity scores.
+func FormatScoringProfile(profile *taskmaster.ScoringProfile) []string {
+	if profile == nil {
+		return nil
+	}
+
+	source := "defaults"
+	if profile.Customized {
+		source = "project config"
+	}
+	header := fmt.Sprintf("Scoring weights (%s", source)
+	if profile.Tag != "" {
+		header += ", tag " + profile.Tag
+	}
+	header += "):"
+
+	w := profile.Weights
+	weights := fmt.Sprintf("  desc %g/80ch · subtask %g · dep %g · keyword %g · hour %g",
+		w.DescriptionLength, w.SubtaskCount, w.DependencyCount, w.ComplexityKeywords, w.EstimatedHours)
+
+	var priorities []string
+	for _, priority := range []string{taskmaster.PriorityCritical, taskmaster.PriorityHigh, taskmaster.PriorityMedium, taskmaster.PriorityLow} {
+		weight, ok := profile.PriorityWeights[priority]
+		if !ok {
+			weight = 1
+		}
+		priorities = append(priorities, fmt.Sprintf("%s %g", priority, weight))
+	}
+	priorityLine := fmt.Sprintf("  priority (×%g): %s", w.PriorityFactor, strings.Join(priorities, " · "))
+
+	keywords := profile.Keywords
+	more := ""
+	if len(keywords) > maxProfileKeywords {
+		more = fmt.Sprintf(" (+%d)", len(keywords)-maxProfileKeywords)
+		keywords = keywords[:maxProfileKeywords]
+	}
+	keywordLine := fmt.Sprintf("  keywords: %s%s", strings.Join(keywords, ", "), more)
+	if len(profile.Keywords) == 0 {
+		keywordLine = "  keywords: none"
+	}
+
+	return []string{header, weights, priorityLine, keywordLine}
+}
@@ -0,0 +1,133 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkBlocks are the glyphs used by Sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a row of block glyphs scaled between the
+// smallest and largest value. A flat series renders at mid height.
+func Sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		idx := len(sparkBlocks) / 2
+		if hi > lo {
+			idx = (v - lo) * (len(sparkBlocks) - 1) / (hi - lo)
+		}
+		sb.WriteRune(sparkBlocks[idx])
+	}
+	return sb.String()
+}
+
+// trendLevels lists levels in the order the distribution is drawn.
+var trendLevels = []taskmaster.ComplexityLevel{
+	taskmaster.ComplexityLow,
+	taskmaster.ComplexityMedium,
+	taskmaster.ComplexityHigh,
+	taskmaster.ComplexityVeryHigh,
+}
+
+// maxTrendSnapshots limits how many analyses are listed in the trend view.
+const maxTrendSnapshots = 12
+
+// maxTrendMovers limits how many changed tasks are listed in the trend view.
+const maxTrendMovers = 10
+
+// renderTrend renders the level distribution over time and the tasks whose
+// score changed most.
+func (d *ComplexityReportDialog) renderTrend() string {
+	if d.Trend == nil || len(d.Trend.Snapshots) == 0 {
+		return lipgloss.NewStyle().Italic(true).Render("No complexity history recorded yet. Run another analysis to start a trend.")
+	}
+
+	var sb strings.Builder
+	headerStyle := lipgloss.NewStyle().Bold(true)
+	barWidth := d.GetWidth() - 32
+	if barWidth < 10 {
+		barWidth = 10
+	}
+
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("Level distribution (%d analyses, tag %s)", len(d.Trend.Snapshots), d.Trend.Tag)))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("─", d.GetWidth()))
+	sb.WriteString("\n")
+
+	snapshots := d.Trend.Snapshots
+	if len(snapshots) > maxTrendSnapshots {
+		snapshots = snapshots[len(snapshots)-maxTrendSnapshots:]
+	}
+	for _, snapshot := range snapshots {
+		sb.WriteString(fmt.Sprintf("%-16s %4d  ", snapshot.AnalyzedAt.Local().Format("2006-01-02 15:04"), snapshot.TotalTasks))
+		sb.WriteString(d.renderDistributionBar(snapshot, barWidth))
+		sb.WriteString(fmt.Sprintf(" avg %.1f\n", snapshot.AverageScore))
+	}
+
+	averages := make([]int, 0, len(d.Trend.Snapshots))
+	for _, snapshot := range d.Trend.Snapshots {
+		averages = append(averages, int(snapshot.AverageScore*10))
+	}
+	sb.WriteString(fmt.Sprintf("\nAverage score trend: %s\n\n", Sparkline(averages)))
+
+	sb.WriteString(headerStyle.Render("Largest score changes"))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("─", d.GetWidth()))
+	sb.WriteString("\n")
+	if len(d.Trend.Movers) == 0 {
+		sb.WriteString("No task scores have changed.\n")
+	}
+	for i, mover := range d.Trend.Movers {
+		if i >= maxTrendMovers {
+			break
+		}
+		title := mover.Title
+		if len(title) > 30 {
+			title = title[:27] + "..."
+		}
+		sb.WriteString(fmt.Sprintf("%-8s %-32s %3d → %-3d (%+d) %s\n",
+			mover.TaskID, title, mover.From, mover.To, mover.Delta, Sparkline(d.Trend.TaskScores(mover.TaskID))))
+	}
+	return sb.String()
+}
+
+// renderDistributionBar draws a stacked bar with one colored segment per level.
+func (d *ComplexityReportDialog) renderDistributionBar(snapshot taskmaster.ComplexitySnapshot, width int) string {
+	if snapshot.TotalTasks == 0 {
+		return strings.Repeat(" ", width)
+	}
+	// Segment boundaries come from cumulative counts so the bar always
+	// spans exactly width cells.
+	var sb strings.Builder
+	cumulative, drawn := 0, 0
+	for _, level := range trendLevels {
+		cumulative += snapshot.LevelCounts[level]
+		boundary := (cumulative*width + snapshot.TotalTasks/2) / snapshot.TotalTasks
+		if segment := boundary - drawn; segment > 0 {
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(level.GetColorForLevel()))
+			sb.WriteString(style.Render(strings.Repeat("█", segment)))
+			drawn = boundary
+		}
+	}
+	if drawn < width {
+		sb.WriteString(strings.Repeat(" ", width-drawn))
+	}
+	return sb.String()
+}
@@ -1,8 +1,10 @@
 package dialog
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/agreen757/tm-tui/internal/taskmaster"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -333,6 +335,15 @@ func TestListDialog(t *testing.T) {
 		t.Errorf("Expected 0 selected items after toggling, got %d", len(selectedItems))
 	}
 
+	// Preselect an item
+	dialog.SetItemSelected(2, true)
+	dialog.SetItemSelected(5, true)
+	selectedItems = dialog.SelectedItems()
+	if len(selectedItems) != 1 || selectedItems[0].Title() != "Item 3" {
+		t.Errorf("Expected Item 3 preselected, got %v", selectedItems)
+	}
+	dialog.SetItemSelected(2, false)
+
 	// Test item selection with Enter
 	enterKey := tea.KeyMsg{Type: tea.KeyEnter}
 	result, cmd := dialog.HandleKey(enterKey)
@@ -665,3 +676,26 @@ func TestSimpleListItem(t *testing.T) {
 		t.Errorf("Expected filter value 'Title', got '%s'", item.FilterValue())
 	}
 }
+
+func TestComplexityScopeDialogShowsScoringProfile(t *testing.T) {
+	profile := taskmaster.DefaultScoringProfile()
+	profile.Tag = "master"
+	profile.Weights.SubtaskCount = 4
+	profile.Customized = true
+
+	lines := FormatScoringProfile(&profile)
+	if len(lines) != 4 || !strings.Contains(lines[0], "project config, tag master") || !strings.Contains(lines[1], "subtask 4") {
+		t.Fatalf("unexpected scoring summary: %q", lines)
+	}
+
+	form, err := NewComplexityScopeDialogWithProfile("1", &profile, nil)
+	if err != nil {
+		t.Fatalf("failed to create scope dialog: %v", err)
+	}
+	if !strings.Contains(form.View(), "subtask 4") {
+		t.Errorf("expected scope dialog to list scoring weights")
+	}
+	if FormatScoringProfile(nil) != nil {
+		t.Errorf("expected no summary without a profile")
+	}
+}
@@ -13,6 +13,7 @@ import (
 // SubtaskEditDialog allows editing of proposed subtasks before application
 type SubtaskEditDialog struct {
 	title            string
+	description      string
 	drafts           []taskmaster.SubtaskDraft
 	editingIndex     int
 	selectedIndex    int
@@ -48,6 +49,7 @@ func NewSubtaskEditDialog(
 
 	d := &SubtaskEditDialog{
 		title:          "Edit Subtasks",
+		description:    "Edit the proposed subtasks. Keys: a=add, d=delete, e=edit, ↑/↓=navigate, Enter=confirm, Esc=cancel",
 		drafts:         copyDrafts(drafts),
 		selectedIndex:  0,
 		editingIndex:   -1,
@@ -76,8 +78,14 @@ func copyDrafts(drafts []taskmaster.SubtaskDraft) []taskmaster.SubtaskDraft {
 
 func copyDraft(d taskmaster.SubtaskDraft) taskmaster.SubtaskDraft {
 	result := taskmaster.SubtaskDraft{
-		Title:       d.Title,
-		Description: d.Description,
+		Title:        d.Title,
+		Description:  d.Description,
+		Status:       d.Status,
+		Priority:     d.Priority,
+		TestStrategy: d.TestStrategy,
+		DependsOn:    append([]string(nil), d.DependsOn...),
+		Section:      d.Section,
+		Hash:         d.Hash,
 	}
 	if len(d.Children) > 0 {
 		result.Children = copyDrafts(d.Children)
@@ -270,9 +278,7 @@ func (d *SubtaskEditDialog) renderListMode() string {
 	descStyle := lipgloss.NewStyle().
 		Foreground(d.style.TextColor).
 		PaddingBottom(1)
-	desc := descStyle.Render(
-		"Edit the proposed subtasks. Keys: a=add, d=delete, e=edit, ↑/↓=navigate, Enter=confirm, Esc=cancel",
-	)
+	desc := descStyle.Render(d.description)
 
 	// Render list
 	listLines := d.renderList()
@@ -451,14 +457,35 @@ func (d *SubtaskEditDialog) SetCancelCallback(cb func()) {
 	d.cancelCallback = cb
 }
 
+// SetTitle replaces the dialog title and the help text shown under it
+func (d *SubtaskEditDialog) SetTitle(title, description string) {
+	d.title = title
+	d.description = description
+}
+
 // GetDrafts returns the current drafts being edited
 func (d *SubtaskEditDialog) GetDrafts() []taskmaster.SubtaskDraft {
 	return d.drafts
 }
 
-// HandleKey implements Dialog interface
+// DialogResultValue returns the edited drafts when the dialog is confirmed
+func (d *SubtaskEditDialog) DialogResultValue() (interface{}, error) {
+	return copyDrafts(d.drafts), nil
+}
+
+// HandleKey implements Dialog interface. Enter and Esc outside of field
+// editing confirm or cancel the dialog.
 func (d *SubtaskEditDialog) HandleKey(msg tea.KeyMsg) (DialogResult, tea.Cmd) {
+	editing := d.editingMode
 	_, cmd := d.handleKeyMsg(msg)
+	if !editing {
+		switch msg.String() {
+		case "enter":
+			return DialogResultConfirm, cmd
+		case "esc", "ctrl+c":
+			return DialogResultCancel, cmd
+		}
+	}
 	return DialogResultNone, cmd
 }
 
@@ -110,6 +110,29 @@ func TestSubtaskEditDialogDeleteSubtask(t *testing.T) {
 	}
 }
 
+func TestSubtaskEditDialogConfirmReturnsDrafts(t *testing.T) {
+	dialog := NewSubtaskEditDialog([]taskmaster.SubtaskDraft{{Title: "Keep"}}, DefaultDialogStyle())
+
+	// Enter while editing a field must not close the dialog.
+	dialog.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if result, _ := dialog.HandleKey(tea.KeyMsg{Type: tea.KeyEnter}); result != DialogResultNone {
+		t.Fatalf("expected Enter in edit mode to move fields, got %v", result)
+	}
+	dialog.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if result, _ := dialog.HandleKey(tea.KeyMsg{Type: tea.KeyEnter}); result != DialogResultConfirm {
+		t.Fatalf("expected Enter to confirm, got %v", result)
+	}
+	value, err := dialog.DialogResultValue()
+	drafts, ok := value.([]taskmaster.SubtaskDraft)
+	if err != nil || !ok || len(drafts) != 1 || drafts[0].Title != "Keep" {
+		t.Fatalf("expected confirmed drafts, got %v %v", value, err)
+	}
+	if result, _ := dialog.HandleKey(tea.KeyMsg{Type: tea.KeyEsc}); result != DialogResultCancel {
+		t.Fatalf("expected Esc to cancel, got %v", result)
+	}
+}
+
 func TestApplySubtaskDrafts(t *testing.T) {
 	// Create a parent task
 	parentTask := &taskmaster.Task{
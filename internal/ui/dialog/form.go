@@ -64,6 +64,8 @@ type FormField struct {
 	Placeholder     string
 	ConditionalShow string
 	Help            string
+	CharLimit       int      // text fields; defaults to 256
+	Suggestions     []string // text fields; completed with the right arrow
 
 	ValidationError string
 
@@ -203,9 +205,17 @@ func NewFormDialog(
 			input.Placeholder = field.Placeholder
 			input.Prompt = ""
 			input.CharLimit = 256
+			if field.CharLimit > 0 {
+				input.CharLimit = field.CharLimit
+			}
 			if value, ok := field.Value.(string); ok {
 				input.SetValue(value)
 			}
+			if len(field.Suggestions) > 0 {
+				input.ShowSuggestions = true
+				input.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+				input.SetSuggestions(field.Suggestions)
+			}
 			if !autoFocused {
 				input.Focus()
 				autoFocused = true
@@ -261,6 +271,7 @@ func (d *FormDialog) HandleKey(msg tea.KeyMsg) (DialogResult, tea.Cmd) {
 	}
 
 	focused := d.FocusedIndex()
+	d.syncInputFocus(focused)
 	if focused < len(d.fields) {
 		return d.handleFieldKey(focused, msg)
 	}
@@ -268,6 +279,21 @@ func (d *FormDialog) HandleKey(msg tea.KeyMsg) (DialogResult, tea.Cmd) {
 	return d.handleButtonKey(msg)
 }
 
+// syncInputFocus focuses the text input of the focused field and blurs the
+// others, so typing goes to the field the cursor was moved to.
+func (d *FormDialog) syncInputFocus(focused int) {
+	for i := range d.fields {
+		if d.fields[i].Type != FormFieldTypeText {
+			continue
+		}
+		if i == focused {
+			d.fields[i].input.Focus()
+		} else {
+			d.fields[i].input.Blur()
+		}
+	}
+}
+
 func (d *FormDialog) handleFieldKey(index int, msg tea.KeyMsg) (DialogResult, tea.Cmd) {
 	field := &d.fields[index]
 	switch field.Type {
@@ -535,6 +561,18 @@ func (d *FormDialog) GetField(id string) (*FormField, bool) {
 	return nil, false
 }
 
+// SetFieldSuggestions replaces the completions offered by a text field.
+func (d *FormDialog) SetFieldSuggestions(id string, suggestions []string) {
+	field, ok := d.GetField(id)
+	if !ok || field.Type != FormFieldTypeText {
+		return
+	}
+	field.Suggestions = suggestions
+	field.input.ShowSuggestions = true
+	field.input.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	field.input.SetSuggestions(suggestions)
+}
+
 func (d *FormDialog) emitValueChanged(id string, value interface{}) {
 	msg := FormValueChangedMsg{FieldID: id, NewValue: value}
 	for _, handler := range d.events {
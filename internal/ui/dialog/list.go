@@ -1,6 +1,7 @@
 package dialog
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -269,7 +270,7 @@ func (d *ListDialog) renderItem(item ListItem, width int, focused, selected bool
 			Foreground(d.Style.FocusedBorderColor).
 			Bold(true).
 			Underline(true) // Add underline for better visibility
-		prefix = "> " + prefix[2:]
+		prefix = "> " + strings.TrimPrefix(prefix, "  ")
 	}
 
 	// Render the item
@@ -467,23 +468,9 @@ func (d *ListDialog) HandleKey(msg tea.KeyMsg) (DialogResult, tea.Cmd) {
 
 	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
 		if totalItems > 0 {
-			var selectedItems []int
-			if d.multiSelect {
-				for idx := range d.selectedItems {
-					selectedItems = append(selectedItems, idx)
-				}
-			} else {
-				selectedItems = []int{d.actualIndex(d.selectedIndex)}
-			}
-
-			currentItem := d.viewItems[d.selectedIndex]
+			selection := d.selection()
 			return DialogResultConfirm, func() tea.Msg {
-				return ListSelectionMsg{
-					SelectedIndex: d.selectedIndex,
-					SelectedItem:  currentItem,
-					MultiSelect:   d.multiSelect,
-					SelectedItems: selectedItems,
-				}
+				return selection
 			}
 		}
 	}
@@ -491,6 +478,32 @@ func (d *ListDialog) HandleKey(msg tea.KeyMsg) (DialogResult, tea.Cmd) {
 	return DialogResultNone, nil
 }
 
+// selection describes the focused item and, in a multi-select list, the
+// selected items in list order.
+func (d *ListDialog) selection() ListSelectionMsg {
+	var selectedItems []int
+	if d.multiSelect {
+		for idx := range d.selectedItems {
+			selectedItems = append(selectedItems, idx)
+		}
+		sort.Ints(selectedItems)
+	} else {
+		selectedItems = []int{d.actualIndex(d.selectedIndex)}
+	}
+	return ListSelectionMsg{
+		SelectedIndex: d.selectedIndex,
+		SelectedItem:  d.SelectedItem(),
+		MultiSelect:   d.multiSelect,
+		SelectedItems: selectedItems,
+	}
+}
+
+// DialogResultValue implements DialogResultProvider, handing the selection
+// the list was confirmed with to the dialog callback.
+func (d *ListDialog) DialogResultValue() (interface{}, error) {
+	return d.selection(), nil
+}
+
 // SelectedIndex returns the selected index
 func (d *ListDialog) SelectedIndex() int {
 	return d.selectedIndex
@@ -532,6 +545,18 @@ func (d *ListDialog) SetMultiSelect(multiSelect bool) {
 	d.multiSelect = multiSelect
 }
 
+// SetItemSelected marks the item at index as selected in a multi-select list.
+func (d *ListDialog) SetItemSelected(index int, selected bool) {
+	if index < 0 || index >= len(d.items) {
+		return
+	}
+	if selected {
+		d.selectedItems[index] = true
+	} else {
+		delete(d.selectedItems, index)
+	}
+}
+
 // EnableFiltering turns on inline filtering for the dialog.
 func (d *ListDialog) EnableFiltering(placeholder string) {
 	d.filterEnabled = true
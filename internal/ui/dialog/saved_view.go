@@ -0,0 +1,46 @@
+package dialog
+
+import "strings"
+
+// SaveViewRequest is returned by the save view dialog.
+type SaveViewRequest struct {
+	Name    string
+	Default bool
+}
+
+// NewSaveViewDialog asks for the name of a saved view and whether it opens at
+// startup. name and isDefault prefill the form when updating an active view.
+func NewSaveViewDialog(name string, isDefault bool, style *DialogStyle) *FormDialog {
+	fields := []FormField{
+		{
+			ID:       "name",
+			Label:    "View Name:",
+			Type:     FormFieldTypeText,
+			Required: true,
+			Value:    name,
+			Help:     "Saving under an existing name replaces that view",
+		},
+		{
+			ID:    "default",
+			Label: "Open this view at startup",
+			Type:  FormFieldTypeCheckbox,
+			Value: isDefault,
+		},
+	}
+
+	return NewFormDialog(
+		"Save View",
+		"Save the current query, status filter, view mode and expanded tasks:",
+		fields,
+		[]string{"Save", "Cancel"},
+		style,
+		func(form *FormDialog, button string, values map[string]interface{}) (interface{}, error) {
+			if button != "Save" {
+				return nil, nil // User cancelled
+			}
+			name, _ := values["name"].(string)
+			isDefault, _ := values["default"].(bool)
+			return SaveViewRequest{Name: strings.TrimSpace(name), Default: isDefault}, nil
+		},
+	)
+}
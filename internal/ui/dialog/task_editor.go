@@ -0,0 +1,193 @@
+package dialog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TaskEditRequest is returned by the task editor. Update holds only the
+// fields that were changed.
+type TaskEditRequest struct {
+	TaskID string
+	Update taskmaster.TaskUpdate
+}
+
+const (
+	taskEditorWidth      = 80
+	taskEditorInputWidth = 56
+	taskEditorLongText   = 4000
+)
+
+var taskEditorPriorities = []string{
+	taskmaster.PriorityLow,
+	taskmaster.PriorityMedium,
+	taskmaster.PriorityHigh,
+	taskmaster.PriorityCritical,
+}
+
+// NewTaskEditorDialog edits the fields of task. taskIDs are offered as
+// dependency completions. validate is called with the changed fields before
+// the dialog closes, so problems such as dependency cycles are shown inline.
+func NewTaskEditorDialog(task *taskmaster.Task, taskIDs []string, validate func(taskmaster.TaskUpdate) error, style *DialogStyle) *FormDialog {
+	priority := 1 // medium, the default for tasks without a priority
+	for i, p := range taskEditorPriorities {
+		if p == task.Priority {
+			priority = i
+		}
+	}
+	priorityOptions := make([]FormOption, len(taskEditorPriorities))
+	for i, p := range taskEditorPriorities {
+		priorityOptions[i] = FormOption{Value: p, Label: p}
+	}
+
+	deps := strings.Join(task.Dependencies, ", ")
+	candidates := make([]string, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		if id != task.ID {
+			candidates = append(candidates, id)
+		}
+	}
+
+	fields := []FormField{
+		{ID: "title", Label: "Title:", Type: FormFieldTypeText, Required: true, Value: task.Title},
+		{ID: "description", Label: "Description:", Type: FormFieldTypeText, Value: task.Description, CharLimit: taskEditorLongText},
+		{ID: "details", Label: "Details:", Type: FormFieldTypeText, Value: task.Details, CharLimit: taskEditorLongText, Help: "Line breaks are shown as spaces and dropped if the field is edited"},
+		{ID: "testStrategy", Label: "Test Strategy:", Type: FormFieldTypeText, Value: task.TestStrategy, CharLimit: taskEditorLongText},
+		{ID: "priority", Label: "Priority:", Type: FormFieldTypeRadio, Options: priorityOptions, Value: taskEditorPriorities[priority], SelectedOption: priority},
+		{
+			ID:          "dependencies",
+			Label:       "Dependencies:",
+			Type:        FormFieldTypeText,
+			Value:       deps,
+			Placeholder: "1, 2.3",
+			Suggestions: dependencySuggestions(deps, candidates),
+			Help:        "Comma-separated task IDs; → completes, ↑/↓ cycles matches",
+		},
+		{ID: "tags", Label: "Tags:", Type: FormFieldTypeText, Value: strings.Join(task.Tags, ", "), Placeholder: "api, backend"},
+		{ID: "estimatedHours", Label: "Estimate (h):", Type: FormFieldTypeText, Value: formatEditorHours(task.EstimatedHours), Placeholder: "0"},
+	}
+
+	var initial map[string]interface{}
+	form := NewFormDialog(
+		"Edit Task "+task.ID,
+		"Unchanged fields are left as they are.",
+		fields,
+		[]string{"Save", "Cancel"},
+		style,
+		func(form *FormDialog, button string, values map[string]interface{}) (interface{}, error) {
+			if button != "Save" {
+				return nil, nil // User cancelled
+			}
+			update, err := taskEditorUpdate(initial, values)
+			if err != nil {
+				return nil, err
+			}
+			if !update.IsEmpty() && validate != nil {
+				if err := validate(update); err != nil {
+					return nil, err
+				}
+			}
+			return TaskEditRequest{TaskID: task.ID, Update: update}, nil
+		},
+	)
+
+	form.SetRect(taskEditorWidth, form.height, 0, 0)
+	for i := range form.fields {
+		form.fields[i].input.Width = taskEditorInputWidth
+	}
+	form.AddEventHandler(func(form *FormDialog, msg tea.Msg) {
+		changed, ok := msg.(FormValueChangedMsg)
+		if !ok || changed.FieldID != "dependencies" {
+			return
+		}
+		if field, ok := form.GetField("dependencies"); ok {
+			form.SetFieldSuggestions("dependencies", dependencySuggestions(field.input.Value(), candidates))
+		}
+	})
+	initial = form.collectValues()
+	return form
+}
+
+// taskEditorUpdate builds a TaskUpdate from the fields that differ from their
+// initial values.
+func taskEditorUpdate(initial, values map[string]interface{}) (taskmaster.TaskUpdate, error) {
+	var update taskmaster.TaskUpdate
+	changed := func(id string) (string, bool) {
+		value, _ := values[id].(string)
+		before, _ := initial[id].(string)
+		return value, value != before
+	}
+
+	if value, ok := changed("title"); ok {
+		update.Title = &value
+	}
+	if value, ok := changed("description"); ok {
+		update.Description = &value
+	}
+	if value, ok := changed("details"); ok {
+		update.Details = &value
+	}
+	if value, ok := changed("testStrategy"); ok {
+		update.TestStrategy = &value
+	}
+	if value, ok := changed("priority"); ok {
+		update.Priority = &value
+	}
+	if value, ok := changed("dependencies"); ok {
+		deps := splitEditorList(value)
+		update.Dependencies = &deps
+	}
+	if value, ok := changed("tags"); ok {
+		tags := splitEditorList(value)
+		update.Tags = &tags
+	}
+	if value, ok := changed("estimatedHours"); ok {
+		hours := 0.0
+		if value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed < 0 {
+				return update, ErrorFormValidation{FieldID: "estimatedHours", Message: fmt.Sprintf("Estimate must be a number of hours, got %q", value)}
+			}
+			hours = parsed
+		}
+		update.EstimatedHours = &hours
+	}
+	return update, nil
+}
+
+// dependencySuggestions completes the last entry of a comma-separated
+// dependency list with each candidate ID not already listed.
+func dependencySuggestions(value string, candidates []string) []string {
+	head := ""
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		last := value[i+1:]
+		head = value[:i+1] + last[:len(last)-len(strings.TrimLeft(last, " "))]
+	}
+
+	listed := make(map[string]bool)
+	for _, id := range splitEditorList(head) {
+		listed[id] = true
+	}
+	suggestions := make([]string, 0, len(candidates))
+	for _, id := range candidates {
+		if !listed[id] {
+			suggestions = append(suggestions, head+id)
+		}
+	}
+	return suggestions
+}
+
+func splitEditorList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+func formatEditorHours(hours float64) string {
+	if hours == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(hours, 'f', -1, 64)
+}
@@ -0,0 +1,71 @@
+package dialog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeInto(form *FormDialog, index int, text string) {
+	form.SetFocusedIndex(index)
+	form.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+}
+
+func TestTaskEditorReturnsOnlyChangedFields(t *testing.T) {
+	task := &taskmaster.Task{ID: "3", Title: "Ship", Details: "line one\nline two", Dependencies: []string{"1"}}
+	form := NewTaskEditorDialog(task, []string{"1", "2", "3"}, nil, DefaultDialogStyle())
+
+	typeInto(form, 0, " it")
+	typeInto(form, 5, ", 2")
+	typeInto(form, 7, "4.5")
+	if result, _ := form.submit("Save"); result != DialogResultConfirm {
+		t.Fatalf("expected save to confirm, got %v (%v)", result, form.lastError)
+	}
+
+	request, ok := form.resultValue.(TaskEditRequest)
+	if !ok || request.TaskID != "3" {
+		t.Fatalf("expected edit request for task 3, got %#v", form.resultValue)
+	}
+	update := request.Update
+	if update.Title == nil || *update.Title != "Ship it" {
+		t.Fatalf("expected title change, got %v", update.Title)
+	}
+	if update.Dependencies == nil || len(*update.Dependencies) != 2 || (*update.Dependencies)[1] != "2" {
+		t.Fatalf("expected dependencies 1, 2, got %v", update.Dependencies)
+	}
+	if update.EstimatedHours == nil || *update.EstimatedHours != 4.5 {
+		t.Fatalf("expected estimate 4.5, got %v", update.EstimatedHours)
+	}
+	if update.Details != nil || update.Priority != nil || update.Tags != nil {
+		t.Fatalf("expected untouched fields left out, got %+v", update)
+	}
+}
+
+func TestTaskEditorShowsValidationErrorsInline(t *testing.T) {
+	task := &taskmaster.Task{ID: "1", Title: "Setup"}
+	validate := func(taskmaster.TaskUpdate) error { return errors.New("dependency 2 would create a cycle with task 1") }
+	form := NewTaskEditorDialog(task, []string{"1", "2"}, validate, DefaultDialogStyle())
+
+	typeInto(form, 5, "2")
+	if result, _ := form.submit("Save"); result != DialogResultNone || form.lastError == nil || !strings.Contains(form.lastError.Message, "cycle") {
+		t.Fatalf("expected validation error to keep the editor open, got %v", result)
+	}
+
+	typeInto(form, 7, "lots")
+	if result, _ := form.submit("Save"); result != DialogResultNone || form.lastError == nil || !strings.Contains(form.lastError.Message, "Estimate") {
+		t.Fatalf("expected a non-numeric estimate to be rejected, got %v", form.lastError)
+	}
+}
+
+func TestDependencySuggestionsCompleteLastEntry(t *testing.T) {
+	got := dependencySuggestions("1, ", []string{"1", "2", "2.1"})
+	if len(got) != 2 || got[0] != "1, 2" || got[1] != "1, 2.1" {
+		t.Fatalf("unexpected suggestions %v", got)
+	}
+	if got := dependencySuggestions("", []string{"1", "2"}); len(got) != 2 || got[0] != "1" {
+		t.Fatalf("unexpected suggestions for empty list %v", got)
+	}
+}
@@ -0,0 +1,42 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+)
+
+// NewTaskQueryDialog asks for a task query, prefilled with current. Syntax
+// errors are shown inline and keep the dialog open; the result is the query
+// string, empty to clear the filter.
+func NewTaskQueryDialog(current string, style *DialogStyle) *FormDialog {
+	fields := []FormField{
+		{
+			ID:          "query",
+			Label:       "Query:",
+			Type:        FormFieldTypeText,
+			Value:       current,
+			Placeholder: "status:pending tag:api",
+			Help:        "Fields: status priority tag dep id title complexity est actual; -field:value negates",
+		},
+	}
+
+	return NewFormDialog(
+		"Filter Tasks",
+		"e.g. "+taskmaster.QuerySyntax,
+		fields,
+		[]string{"Apply", "Cancel"},
+		style,
+		func(form *FormDialog, button string, values map[string]interface{}) (interface{}, error) {
+			if button != "Apply" {
+				return nil, nil // User cancelled
+			}
+			query, _ := values["query"].(string)
+			query = strings.TrimSpace(query)
+			if _, err := taskmaster.ParseQuery(query); err != nil {
+				return nil, err
+			}
+			return query, nil
+		},
+	)
+}
@@ -0,0 +1,67 @@
+package dialog
+
+import (
+	"strings"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/timetrack"
+)
+
+// TimesheetExportRequest is returned by the timesheet export dialog. From
+// and To bound the export as [From, To).
+type TimesheetExportRequest struct {
+	From     time.Time
+	To       time.Time
+	FilePath string
+}
+
+// NewTimesheetExportDialog asks for the date range and output file of a
+// timesheet export. The range defaults to the current week up to today.
+func NewTimesheetExportDialog(now time.Time, style *DialogStyle) *FormDialog {
+	from, to, _ := timetrack.ParseDateRange("", "", now)
+	fields := []FormField{
+		{
+			ID:       "from",
+			Label:    "From (YYYY-MM-DD):",
+			Type:     FormFieldTypeText,
+			Required: true,
+			Value:    from.Format(timetrack.DateLayout),
+		},
+		{
+			ID:       "to",
+			Label:    "To (YYYY-MM-DD):",
+			Type:     FormFieldTypeText,
+			Required: true,
+			Value:    to.AddDate(0, 0, -1).Format(timetrack.DateLayout),
+		},
+		{
+			ID:       "file_path",
+			Label:    "Output File (optional):",
+			Type:     FormFieldTypeText,
+			Required: false,
+			Value:    "",
+			Help:     "Leave empty to write a timestamped CSV under .taskmaster/reports",
+		},
+	}
+
+	return NewFormDialog(
+		"Export Timesheet",
+		"Export tracked time per day and task as CSV:",
+		fields,
+		[]string{"Export", "Cancel"},
+		style,
+		func(form *FormDialog, button string, values map[string]interface{}) (interface{}, error) {
+			if button != "Export" {
+				return nil, nil // User cancelled
+			}
+			fromValue, _ := values["from"].(string)
+			toValue, _ := values["to"].(string)
+			from, to, err := timetrack.ParseDateRange(fromValue, toValue, now)
+			if err != nil {
+				return nil, err
+			}
+			path, _ := values["file_path"].(string)
+			return TimesheetExportRequest{From: from, To: to, FilePath: strings.TrimSpace(path)}, nil
+		},
+	)
+}
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxDiffValueLen caps how much of a field value the diff dialog shows.
+const maxDiffValueLen = 40
+
+// editorCommand builds the command that opens path in the user's editor,
+// taken from $VISUAL, then $EDITOR, falling back to vi. The variable may
+// carry arguments, e.g. "code --wait".
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if strings.TrimSpace(editor) == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	parts := strings.Fields(editor)
+	if len(parts) == 0 {
+		parts = []string{"vi"}
+	}
+	return exec.Command(parts[0], append(parts[1:], path)...)
+}
+
+// openTaskInEditor writes the selected task to a Markdown file and suspends
+// the TUI while the user's editor runs on it.
+func (m *Model) openTaskInEditor() tea.Cmd {
+	if m.selectedTask == nil {
+		appErr := NewValidationError("Edit in Editor", "No task selected.", nil).
+			WithRecoveryHints("Use arrow keys to select a task, then try again")
+		m.showAppError(appErr)
+		return nil
+	}
+	if m.taskService == nil {
+		return nil
+	}
+
+	original := *m.selectedTask
+	path, err := writeTaskMarkdownFile(&original)
+	if err != nil {
+		appErr := NewOperationError("Edit in Editor", "Failed to write the task file", err).
+			WithRecoveryHints("Check that the temporary directory is writable")
+		m.showAppError(appErr)
+		return nil
+	}
+	cmd := editorCommand(path)
+	m.addLogLine(fmt.Sprintf("Editing task %s in %s", original.ID, cmd.Path))
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return readEditedTask(path, &original, err)
+	})
+}
+
+// writeTaskMarkdownFile writes task to a temporary Markdown file and returns
+// its path.
+func writeTaskMarkdownFile(task *taskmaster.Task) (string, error) {
+	data, err := taskmaster.MarshalTaskMarkdown(task)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp("", fmt.Sprintf("task-%s-*.md", task.ID))
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// readEditedTask parses the file the editor saved and diffs it against the
+// task as it was opened. The file is kept until the edit is saved or
+// discarded, so the user's edits are not lost to a parse or validation error.
+func readEditedTask(path string, original *taskmaster.Task, editorErr error) TaskMarkdownEditedMsg {
+	msg := TaskMarkdownEditedMsg{TaskID: original.ID, Path: path}
+	if editorErr != nil {
+		os.Remove(path)
+		msg.Path = ""
+		msg.Err = fmt.Errorf("editor exited with an error: %w", editorErr)
+		return msg
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		msg.Err = err
+		return msg
+	}
+	edited, err := taskmaster.ParseTaskMarkdown(data)
+	if err == nil {
+		msg.Update, msg.Changes, err = taskmaster.DiffTaskEdit(original, edited)
+	}
+	if err != nil {
+		msg.Err = err
+	}
+	return msg
+}
+
+// handleTaskMarkdownEdited shows the changes made in the editor and applies
+// them once confirmed.
+func (m *Model) handleTaskMarkdownEdited(msg TaskMarkdownEditedMsg) tea.Cmd {
+	if msg.Err == nil && len(msg.Changes) > 0 {
+		msg.Err = taskmaster.ValidateTaskUpdate(m.taskIndex, msg.TaskID, msg.Update)
+	}
+	if msg.Err != nil {
+		appErr := NewOperationError("Edit in Editor", fmt.Sprintf("Could not apply edits to task %s", msg.TaskID), msg.Err)
+		if msg.Path != "" {
+			appErr = appErr.WithRecoveryHints(fmt.Sprintf("Your edits are kept in %s", msg.Path))
+		} else {
+			appErr = appErr.WithRecoveryHints("Open the task in the editor again and fix the problem")
+		}
+		m.showAppError(appErr)
+		return nil
+	}
+	if len(msg.Changes) == 0 {
+		os.Remove(msg.Path)
+		m.addLogLine(fmt.Sprintf("No changes to task %s", msg.TaskID))
+		return nil
+	}
+	if m.dialogManager() == nil {
+		return nil
+	}
+
+	lines := make([]string, 0, len(msg.Changes)+1)
+	lines = append(lines, fmt.Sprintf("Apply these changes to task %s?", msg.TaskID), "")
+	for _, change := range msg.Changes {
+		lines = append(lines, fmt.Sprintf("• %s: %s → %s", change.Field, diffValue(change.Old), diffValue(change.New)))
+	}
+	confirm := dialog.NewConfirmationDialog("Review Changes", strings.Join(lines, "\n"), 78, 8+len(lines))
+	confirm.SetYesText("Apply")
+	confirm.SetNoText("Discard")
+	svc := m.taskService
+	m.appState.AddDialog(confirm, func(value interface{}, err error) tea.Cmd {
+		if confirm.Result() != dialog.ConfirmationResultYes {
+			os.Remove(msg.Path)
+			m.addLogLine(fmt.Sprintf("Discarded edits to task %s", msg.TaskID))
+			return nil
+		}
+		return saveEditedTaskCmd(svc, msg.TaskID, msg.Update, msg.Path)
+	})
+	return nil
+}
+
+// saveEditedTaskCmd saves edits made in the editor. The edited file travels
+// with the result so handleTaskEdited removes it only once the edit is saved.
+func saveEditedTaskCmd(svc TaskService, taskID string, update taskmaster.TaskUpdate, path string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := svc.UpdateTaskFields(context.Background(), taskID, update)
+		return TaskEditedMsg{TaskID: taskID, Path: path, Err: err}
+	}
+}
+
+// diffValue renders a field value on one line for the diff dialog.
+func diffValue(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	lines := strings.Count(value, "\n") + 1
+	value = strings.Join(strings.Fields(value), " ")
+	if len([]rune(value)) > maxDiffValueLen {
+		value = string([]rune(value)[:maxDiffValueLen-1]) + "…"
+	}
+	if lines > 1 {
+		return fmt.Sprintf("%q (%d lines)", value, lines)
+	}
+	return fmt.Sprintf("%q", value)
+}
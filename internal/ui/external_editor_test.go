@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+)
+
+func TestEditorCommandPrefersVisual(t *testing.T) {
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "nano")
+	if cmd := editorCommand("/tmp/task.md"); strings.Join(cmd.Args, " ") != "code --wait /tmp/task.md" {
+		t.Fatalf("unexpected editor command %v", cmd.Args)
+	}
+	t.Setenv("VISUAL", "")
+	if cmd := editorCommand("/tmp/task.md"); cmd.Args[0] != "nano" {
+		t.Fatalf("expected $EDITOR fallback, got %v", cmd.Args)
+	}
+}
+
+func TestEditedTaskMarkdownIsReviewedBeforeApplying(t *testing.T) {
+	model := newTestModel()
+	svc := mockTaskService()
+	model.taskService = svc
+	model.tasks = svc.tasks
+	model.buildTaskIndex()
+	original := *model.taskIndex["2"]
+
+	path, err := writeTaskMarkdownFile(&original)
+	if err != nil {
+		t.Fatalf("writeTaskMarkdownFile returned error: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	edited := strings.Replace(string(data), "title: Medium complexity task", "title: Renamed task", 1)
+	if err := os.WriteFile(path, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := readEditedTask(path, &original, nil)
+	if msg.Err != nil || len(msg.Changes) != 1 || msg.Changes[0].Field != "title" {
+		t.Fatalf("expected a title change, got %+v", msg)
+	}
+	if _, err := os.Stat(path); err != nil || msg.Path != path {
+		t.Fatalf("expected the edited file to be kept until saved, got %q (%v)", msg.Path, err)
+	}
+
+	// A dangling dependency the task already had does not block the edit
+	model.taskIndex["2"].Dependencies = append(model.taskIndex["2"].Dependencies, "99")
+	model.handleTaskMarkdownEdited(msg)
+	review, ok := model.appState.ActiveDialog().(*dialog.ConfirmationDialog)
+	if !ok || review.Title() != "Review Changes" {
+		t.Fatalf("expected review dialog, got %T", model.appState.ActiveDialog())
+	}
+
+	// A validation error keeps the file so the edits can be recovered
+	invalid := msg
+	deps := []string{"42"}
+	invalid.Update.Dependencies = &deps
+	model.handleTaskMarkdownEdited(invalid)
+	if _, ok := model.appState.ActiveDialog().(*dialog.ErrorDialogModel); !ok {
+		t.Fatalf("expected an error dialog, got %T", model.appState.ActiveDialog())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the edited file to survive a validation error: %v", err)
+	}
+
+	// A failed save keeps it too; a successful one removes it
+	model.handleTaskEdited(TaskEditedMsg{TaskID: "2", Path: path, Err: errors.New("disk full")})
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the edited file to survive a failed save: %v", err)
+	}
+	model.handleTaskEdited(TaskEditedMsg{TaskID: "2", Path: path})
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("expected the edited file to be removed once saved")
+	}
+
+	broken := filepath.Join(t.TempDir(), "task.md")
+	os.WriteFile(broken, []byte("title: no front matter\n"), 0o600)
+	if msg := readEditedTask(broken, &original, nil); msg.Err == nil || msg.Path != broken {
+		t.Fatalf("expected parse error keeping the file, got %+v", msg)
+	}
+}
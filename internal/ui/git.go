@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/git"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// gitScanInterval is how often the git history is rescanned for commits
+// made outside the TUI.
+const gitScanInterval = time.Minute
+
+// maxDetailCommits is the number of commits listed in the details panel.
+const maxDetailCommits = 5
+
+// gitTickMsg triggers the periodic git scan.
+type gitTickMsg struct{}
+
+// gitScannedMsg carries the result of a git history scan.
+type gitScannedMsg struct {
+	Activity *git.Activity
+	Err      error
+}
+
+// gitBranchSwitchedMsg reports the outcome of switching to a task branch.
+type gitBranchSwitchedMsg struct {
+	TaskID  string
+	Branch  string
+	Created bool
+	Err     error
+}
+
+// gitStatusSyncedMsg reports the status changes made from git activity.
+type gitStatusSyncedMsg struct {
+	Changes []taskmaster.GitStatusChange
+	Err     error
+}
+
+func gitTickCmd() tea.Cmd {
+	return tea.Tick(gitScanInterval, func(time.Time) tea.Msg {
+		return gitTickMsg{}
+	})
+}
+
+func scanGitCmd(svc TaskService) tea.Cmd {
+	if svc == nil || !svc.IsAvailable() {
+		return nil
+	}
+	return func() tea.Msg {
+		activity, err := svc.GitActivity(context.Background())
+		return gitScannedMsg{Activity: activity, Err: err}
+	}
+}
+
+func switchTaskBranchCmd(svc TaskService, taskID string) tea.Cmd {
+	return func() tea.Msg {
+		branch, created, err := svc.SwitchTaskBranch(context.Background(), taskID)
+		return gitBranchSwitchedMsg{TaskID: taskID, Branch: branch, Created: created, Err: err}
+	}
+}
+
+func applyGitActivityCmd(svc TaskService, activity *git.Activity) tea.Cmd {
+	return func() tea.Msg {
+		changes, err := svc.ApplyGitActivity(context.Background(), activity)
+		if len(changes) == 0 && err == nil {
+			return nil
+		}
+		return gitStatusSyncedMsg{Changes: changes, Err: err}
+	}
+}
+
+// switchTaskBranch checks out the branch of the selected task, creating it
+// when the task has none.
+func (m *Model) switchTaskBranch() tea.Cmd {
+	if m.taskService == nil || !m.taskService.IsAvailable() {
+		m.showErrorDialog("Switch Branch", "Task service is not available.")
+		return nil
+	}
+	if m.selectedTask == nil {
+		m.addLogLine("No task selected")
+		return nil
+	}
+	return switchTaskBranchCmd(m.taskService, m.selectedTask.ID)
+}
+
+// handleGitScanned keeps the scanned activity for the details panel and,
+// when enabled, updates task statuses from it. A project outside git is
+// not an error; other failures are logged once until a scan succeeds.
+func (m *Model) handleGitScanned(msg gitScannedMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.gitActivity = nil
+		if !errors.Is(msg.Err, git.ErrNotRepository) && !m.gitScanFailed {
+			m.addLogLine(fmt.Sprintf("Failed to read git history: %v", msg.Err))
+		}
+		m.gitScanFailed = true
+		m.updateDetailsViewport()
+		return nil
+	}
+	m.gitActivity = msg.Activity
+	m.gitScanFailed = false
+	m.updateDetailsViewport()
+	if m.config != nil && m.config.Git.AutoStatus {
+		return applyGitActivityCmd(m.taskService, msg.Activity)
+	}
+	return nil
+}
+
+// handleGitBranchSwitched logs the checked out branch and rescans, so the
+// details panel shows it.
+func (m *Model) handleGitBranchSwitched(msg gitBranchSwitchedMsg) tea.Cmd {
+	if msg.Err != nil {
+		var appErr *AppError
+		if errors.Is(msg.Err, git.ErrNotRepository) || errors.Is(msg.Err, git.ErrGitNotFound) {
+			appErr = NewDependencyError("Switch Branch", "Task branches need the project to be in a git repository.", msg.Err).
+				WithRecoveryHints(
+					"Run git init in the project directory",
+					"Check that git is installed and on the PATH",
+				)
+		} else {
+			appErr = NewOperationError("Switch Branch", fmt.Sprintf("Failed to switch to the branch of task %s", msg.TaskID), msg.Err).
+				WithRecoveryHints(
+					"Commit or stash uncommitted changes first",
+					"Check the git branchPrefix in .taskmaster/config.json",
+				)
+		}
+		m.showAppError(appErr)
+		return nil
+	}
+	if msg.Created {
+		m.addLogLine(fmt.Sprintf("Created and switched to branch %s for task %s", msg.Branch, msg.TaskID))
+	} else {
+		m.addLogLine(fmt.Sprintf("Switched to branch %s for task %s", msg.Branch, msg.TaskID))
+	}
+	return scanGitCmd(m.taskService)
+}
+
+// handleGitStatusSynced logs the status changes made from git activity and
+// reloads the tasks.
+func (m *Model) handleGitStatusSynced(msg gitStatusSyncedMsg) tea.Cmd {
+	if msg.Err != nil {
+		if !m.showMergeConflict(msg.Err) {
+			m.addLogLine(fmt.Sprintf("Failed to update task status from git: %v", msg.Err))
+		}
+		return nil
+	}
+	for _, change := range msg.Changes {
+		reason := "first commit"
+		if change.Status == taskmaster.StatusDone {
+			reason = "merged in"
+		}
+		m.addLogLine(fmt.Sprintf("Task %s → %s (%s %s)", change.TaskID, change.Status, reason, change.Commit.ShortHash()))
+	}
+	return LoadTasksCmd(m.taskService)
+}
+
+// renderGitDetails renders the branch and commits of a task for the
+// details panel, or "" when git knows nothing about it.
+func (m Model) renderGitDetails(task *taskmaster.Task, width int) string {
+	branch := m.gitActivity.Branch(task.ID)
+	commits := m.gitActivity.TaskCommits(task.ID)
+	if branch == "" && len(commits) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	if branch != "" {
+		b.WriteString(m.styles.Subtitle.Render("Branch: "))
+		b.WriteString(branch)
+		if branch == m.gitActivity.CurrentBranch {
+			b.WriteString(" (checked out)")
+		}
+		b.WriteString("\n\n")
+	}
+	if merge, ok := m.gitActivity.MergedCommit(task.ID); ok {
+		b.WriteString(m.styles.Subtitle.Render("Merged: "))
+		b.WriteString(fmt.Sprintf("into %s in %s", m.gitActivity.MainBranch, merge.ShortHash()))
+		b.WriteString("\n\n")
+	}
+	if len(commits) > 0 {
+		b.WriteString(m.styles.Subtitle.Render(fmt.Sprintf("Commits (%d):", len(commits))))
+		b.WriteString("\n")
+		for i, commit := range commits {
+			if i == maxDetailCommits {
+				b.WriteString(fmt.Sprintf("… and %d more\n", len(commits)-maxDetailCommits))
+				break
+			}
+			line := fmt.Sprintf("%s %s · %s, %s", commit.ShortHash(), commit.Subject, commit.Author, commit.Time.Format("2006-01-02"))
+			b.WriteString(snippetText(line, width))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/git"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestGitScanShowsCommitsAndAppliesStatus(t *testing.T) {
+	model := newTestModel()
+	model.styles = NewStyles()
+	model.detailsViewport.Width = 84
+	model.config = &config.Config{Git: config.GitConfig{AutoStatus: true}}
+	svc := mockTaskService()
+	svc.available = true
+	svc.gitActivity = &git.Activity{
+		MainBranch:    "main",
+		CurrentBranch: "task/1-simple-task",
+		Branches:      map[string]string{"1": "task/1-simple-task"},
+		Commits: map[string][]git.Commit{"1": {
+			{Hash: "abcdef0123", Author: "Dana", Time: time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local), Subject: "Task 1: finish"},
+			{Hash: "0123abcdef", Author: "Dana", Time: time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), Subject: "Task 1: start"},
+		}},
+		Merged: map[string]git.Commit{},
+	}
+	model.taskService = svc
+	model.tasks = svc.tasks
+	model.buildTaskIndex()
+	model.selectedTask = model.taskIndex["1"]
+
+	scanned, ok := scanGitCmd(svc)().(gitScannedMsg)
+	if !ok || scanned.Err != nil {
+		t.Fatalf("expected a git scan, got %+v", scanned)
+	}
+	cmd := model.handleGitScanned(scanned)
+	if cmd == nil {
+		t.Fatalf("expected statuses to be updated from git when autoStatus is on")
+	}
+	cmd()
+	if len(svc.gitApplied) != 1 || svc.gitApplied[0] != svc.gitActivity {
+		t.Fatalf("expected the scanned activity applied, got %+v", svc.gitApplied)
+	}
+
+	details := ansi.Strip(model.renderTaskDetails())
+	for _, want := range []string{"Branch: task/1-simple-task (checked out)", "Commits (2):", "abcdef0 Task 1: finish · Dana, 2026-03-02"} {
+		if !strings.Contains(details, want) {
+			t.Fatalf("expected %q in details, got:\n%s", want, details)
+		}
+	}
+	if strings.Index(details, "finish") > strings.Index(details, "start") {
+		t.Fatalf("expected newest commit first, got:\n%s", details)
+	}
+
+	// Outside a repository the git section disappears without an error
+	svc.gitActivity = nil
+	model.handleGitScanned(scanGitCmd(svc)().(gitScannedMsg))
+	if details := ansi.Strip(model.renderTaskDetails()); strings.Contains(details, "Commits") {
+		t.Fatalf("expected no git details outside a repository, got:\n%s", details)
+	}
+	if len(model.logLines) != 0 {
+		t.Fatalf("expected no log lines for a project outside git, got %v", model.logLines)
+	}
+}
+
+func TestSwitchBranchCommandLogsBranch(t *testing.T) {
+	model := newTestModel()
+	svc := mockTaskService()
+	svc.available = true
+	model.taskService = svc
+	model.tasks = svc.tasks
+	model.buildTaskIndex()
+	model.selectedTask = model.taskIndex["2"]
+
+	switched, ok := findMsg[gitBranchSwitchedMsg](model.dispatchCommand(CommandSwitchBranch))
+	if !ok || switched.Err != nil || switched.Branch != "task/2" {
+		t.Fatalf("expected the task branch to be switched to, got %+v", switched)
+	}
+	model.handleGitBranchSwitched(switched)
+	if len(model.logLines) == 0 || !strings.Contains(model.logLines[len(model.logLines)-1], "Created and switched to branch task/2 for task 2") {
+		t.Fatalf("expected the new branch logged, got %v", model.logLines)
+	}
+}
@@ -0,0 +1,285 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// dependencyLayout places tasks into layers so every task sits below all of
+// its dependencies. Tasks caught in a cycle are placed below their acyclic
+// dependencies and flagged.
+type dependencyLayout struct {
+	graph  *taskmaster.DependencyGraph
+	layers [][]*taskmaster.Task
+	layer  map[string]int
+	cyclic map[string]bool
+}
+
+// buildDependencyLayout lays out the given tasks using the full task index for
+// edges, so hidden dependencies still push tasks to later layers.
+func (m Model) buildDependencyLayout(tasks []*taskmaster.Task) dependencyLayout {
+	graph := taskmaster.NewDependencyGraph(m.taskIndex)
+	layout := dependencyLayout{
+		graph:  graph,
+		layer:  make(map[string]int),
+		cyclic: make(map[string]bool),
+	}
+	for _, cycle := range graph.Cycles() {
+		for _, id := range cycle {
+			layout.cyclic[id] = true
+		}
+	}
+
+	// Assign layers along the topological order, then place whatever is left
+	// over (cycle members and tasks below them) using only placed dependencies.
+	order, _ := graph.TopologicalOrder()
+	placed := make(map[string]bool, len(order))
+	place := func(id string) {
+		layer := 0
+		for _, dep := range graph.Dependencies(id) {
+			if placed[dep] && layout.layer[dep]+1 > layer {
+				layer = layout.layer[dep] + 1
+			}
+		}
+		layout.layer[id] = layer
+		placed[id] = true
+	}
+	for _, id := range order {
+		place(id)
+	}
+	for _, id := range graph.IDs() {
+		if !placed[id] {
+			place(id)
+		}
+	}
+
+	for _, task := range tasks {
+		layer, ok := layout.layer[task.ID]
+		if !ok {
+			continue
+		}
+		for len(layout.layers) <= layer {
+			layout.layers = append(layout.layers, nil)
+		}
+		layout.layers[layer] = append(layout.layers[layer], task)
+	}
+	return layout
+}
+
+// nodes returns the visible graph nodes in display order.
+func (l dependencyLayout) nodes() []*taskmaster.Task {
+	var result []*taskmaster.Task
+	for _, layer := range l.layers {
+		result = append(result, layer...)
+	}
+	return result
+}
+
+// graphMoveVertical moves the selection to the previous or next node in
+// display order.
+func (m *Model) graphMoveVertical(delta int) {
+	nodes := m.buildDependencyLayout(m.filteredFlatTasks()).nodes()
+	if len(nodes) == 0 {
+		return
+	}
+	pos := 0
+	if m.selectedTask != nil {
+		for i, task := range nodes {
+			if task.ID == m.selectedTask.ID {
+				pos = i + delta
+				break
+			}
+		}
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= len(nodes) {
+		pos = len(nodes) - 1
+	}
+	m.graphPrevID = ""
+	m.selectGraphNode(nodes[pos].ID)
+}
+
+// graphFollowEdge moves the selection along a dependency edge: upstream to a
+// dependency when upstream is true, otherwise downstream to a dependent. When
+// stepping back the way we came, the previous node is preferred.
+func (m *Model) graphFollowEdge(upstream bool) {
+	if m.selectedTask == nil {
+		return
+	}
+	visible := make(map[string]bool)
+	for _, task := range m.filteredFlatTasks() {
+		visible[task.ID] = true
+	}
+
+	graph := taskmaster.NewDependencyGraph(m.taskIndex)
+	var candidates []string
+	if upstream {
+		candidates = graph.Dependencies(m.selectedTask.ID)
+	} else {
+		candidates = graph.Dependents(m.selectedTask.ID)
+	}
+
+	target := ""
+	for _, id := range candidates {
+		if !visible[id] {
+			continue
+		}
+		if id == m.graphPrevID {
+			target = id
+			break
+		}
+		if target == "" {
+			target = id
+		}
+	}
+	if target == "" {
+		if upstream {
+			m.addLogLine(fmt.Sprintf("Task %s has no visible dependencies", m.selectedTask.ID))
+		} else {
+			m.addLogLine(fmt.Sprintf("No visible tasks depend on %s", m.selectedTask.ID))
+		}
+		return
+	}
+
+	m.graphPrevID = m.selectedTask.ID
+	m.selectGraphNode(target)
+}
+
+func (m *Model) selectGraphNode(id string) {
+	if task, ok := m.taskIndex[id]; ok {
+		m.selectedTask = task
+	}
+	m.ensureTaskSelected(id)
+}
+
+// graphErrorStyle returns the style used for cycle members, taken from the
+// configured theme error colour.
+func (m Model) graphErrorStyle() lipgloss.Style {
+	color := ColorBlocked
+	if m.config != nil && m.config.Theme.ErrorColor != "" {
+		color = m.config.Theme.ErrorColor
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true)
+}
+
+// renderDependencyGraph renders the dependency DAG as layers joined by
+// box-drawing rules. The selected task's upstream closure is marked ↑, its
+// downstream closure ↓, and tasks in a dependency cycle ⟳.
+func (m Model) renderDependencyGraph() string {
+	tasks := m.filteredFlatTasks()
+	if len(tasks) == 0 {
+		return m.styles.Info.Render("No tasks match current filters")
+	}
+	layout := m.buildDependencyLayout(tasks)
+
+	upstream := make(map[string]bool)
+	downstream := make(map[string]bool)
+	selectedID := ""
+	if m.selectedTask != nil {
+		selectedID = m.selectedTask.ID
+		for _, id := range layout.graph.Upstream(selectedID) {
+			upstream[id] = true
+		}
+		for _, id := range layout.graph.Unblocks(selectedID) {
+			downstream[id] = true
+		}
+	}
+
+	width := m.taskListViewport.Width
+	if width <= 0 {
+		width = 80
+	}
+	idWidth := 0
+	for _, task := range tasks {
+		if len(task.ID) > idWidth {
+			idWidth = len(task.ID)
+		}
+	}
+
+	upstreamStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorInProgress))
+	downstreamStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPending))
+	errorStyle := m.graphErrorStyle()
+
+	var b strings.Builder
+	legend := fmt.Sprintf("%s depends on  %s unblocks  %s cycle   ←/→ follow edges",
+		upstreamStyle.Render("↑"), downstreamStyle.Render("↓"), errorStyle.Render("⟳"))
+	b.WriteString(m.styles.Subtle.Render(legend))
+	b.WriteString("\n")
+
+	if len(layout.cyclic) > 0 {
+		var groups []string
+		for _, cycle := range layout.graph.Cycles() {
+			groups = append(groups, strings.Join(cycle, " → "))
+		}
+		b.WriteString(errorStyle.Render("⟳ Circular dependencies: " + strings.Join(groups, "; ")))
+		b.WriteString("\n")
+	}
+
+	last := len(layout.layers) - 1
+	for last > 0 && len(layout.layers[last]) == 0 {
+		last--
+	}
+	for i, layer := range layout.layers {
+		if len(layer) == 0 {
+			continue
+		}
+		header := fmt.Sprintf("┌─ Layer %d (%d) ", i, len(layer))
+		if pad := width - 2 - lipgloss.Width(header); pad > 0 {
+			header += strings.Repeat("─", pad)
+		}
+		b.WriteString(m.styles.Subtle.Render(header))
+		b.WriteString("\n")
+
+		for _, task := range layer {
+			marker := " "
+			lineStyle := m.styles.TaskUnselected
+			switch {
+			case task.ID == selectedID:
+				marker = "▶"
+				lineStyle = m.styles.TaskSelected
+			case layout.cyclic[task.ID]:
+				marker = "⟳"
+				lineStyle = errorStyle
+			case upstream[task.ID]:
+				marker = "↑"
+				lineStyle = upstreamStyle
+			case downstream[task.ID]:
+				marker = "↓"
+				lineStyle = downstreamStyle
+			}
+
+			node := fmt.Sprintf("%s %s %-*s %s", marker, GetStatusIcon(task.Status), idWidth, task.ID, task.Title)
+			if deps := layout.graph.Dependencies(task.ID); len(deps) > 0 {
+				edge := " ◀─ " + strings.Join(deps, ", ")
+				node = ansi.Truncate(node, width-4-lipgloss.Width(edge), "…") + edge
+			} else {
+				node = ansi.Truncate(node, width-4, "…")
+			}
+			if layout.cyclic[task.ID] && task.ID == selectedID {
+				node += " " + errorStyle.Render("⟳")
+			}
+
+			b.WriteString(m.styles.Subtle.Render("│ "))
+			b.WriteString(lineStyle.Render(node))
+			b.WriteString("\n")
+		}
+
+		if i < last {
+			b.WriteString(m.styles.Subtle.Render("└─┬" + strings.Repeat("─", max(width-6, 0))))
+			b.WriteString("\n")
+			b.WriteString(m.styles.Subtle.Render("  ▼"))
+			b.WriteString("\n")
+		} else {
+			b.WriteString(m.styles.Subtle.Render("└" + strings.Repeat("─", max(width-3, 0))))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+)
+
+func createGraphTestModel(tasks []taskmaster.Task) Model {
+	m := createTestModel()
+	m.tasks = tasks
+	m.viewMode = ViewModeGraph
+	m.buildTaskIndex()
+	m.rebuildVisibleTasks()
+	m.ensureTaskSelected(tasks[0].ID)
+	m.taskListViewport.Width = 100
+	return m
+}
+
+func graphViewFixture() []taskmaster.Task {
+	return []taskmaster.Task{
+		{ID: "1", Title: "Schema", Status: "done"},
+		{ID: "2", Title: "API", Status: "pending", Dependencies: []string{"1"}},
+		{ID: "3", Title: "Docs", Status: "pending", Dependencies: []string{"1"}},
+		{ID: "4", Title: "Release", Status: "pending", Dependencies: []string{"2", "3"}},
+	}
+}
+
+func TestDependencyLayoutLayers(t *testing.T) {
+	m := createGraphTestModel(graphViewFixture())
+
+	layout := m.buildDependencyLayout(m.filteredFlatTasks())
+	if len(layout.layers) != 3 {
+		t.Fatalf("expected 3 layers, got %d", len(layout.layers))
+	}
+	if got := taskIDs(layout.layers[1]); len(got) != 2 || got[0] != "2" || got[1] != "3" {
+		t.Errorf("unexpected middle layer: %v", got)
+	}
+	if got := taskIDs(layout.layers[2]); len(got) != 1 || got[0] != "4" {
+		t.Errorf("unexpected last layer: %v", got)
+	}
+}
+
+func TestGraphFollowEdgeReturnsAlongPath(t *testing.T) {
+	m := createGraphTestModel(graphViewFixture())
+	m.ensureTaskSelected("4")
+
+	m.graphFollowEdge(true)
+	if m.selectedTask.ID != "2" {
+		t.Fatalf("expected first dependency 2, got %s", m.selectedTask.ID)
+	}
+	m.graphFollowEdge(true)
+	if m.selectedTask.ID != "1" {
+		t.Fatalf("expected dependency 1, got %s", m.selectedTask.ID)
+	}
+
+	// Stepping back downstream prefers the node we came from
+	m.graphFollowEdge(false)
+	if m.selectedTask.ID != "2" {
+		t.Errorf("expected to return to 2, got %s", m.selectedTask.ID)
+	}
+
+	m.graphMoveVertical(1)
+	if m.selectedTask.ID != "3" {
+		t.Errorf("expected next node 3, got %s", m.selectedTask.ID)
+	}
+}
+
+func TestRenderDependencyGraphHighlightsClosure(t *testing.T) {
+	m := createGraphTestModel(graphViewFixture())
+	m.ensureTaskSelected("2")
+
+	view := m.renderDependencyGraph()
+	for _, want := range []string{"Layer 0", "Layer 2", "▶ ○ 2", "↑ ✓ 1", "↓ ○ 4", "◀─ 2, 3"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected graph to contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "↑ ○ 3") || strings.Contains(view, "↓ ○ 3") {
+		t.Errorf("sibling task 3 should not be highlighted:\n%s", view)
+	}
+}
+
+func TestRenderDependencyGraphFlagsCycles(t *testing.T) {
+	m := createGraphTestModel([]taskmaster.Task{
+		{ID: "1", Title: "Root", Status: "pending"},
+		{ID: "2", Title: "Ping", Status: "pending", Dependencies: []string{"1", "3"}},
+		{ID: "3", Title: "Pong", Status: "pending", Dependencies: []string{"2"}},
+	})
+	m.config = &config.Config{Theme: config.ThemeConfig{ErrorColor: "#ff0000"}}
+
+	view := m.renderDependencyGraph()
+	if !strings.Contains(view, "Circular dependencies: 2 → 3") {
+		t.Errorf("expected cycle summary:\n%s", view)
+	}
+	if !strings.Contains(view, "⟳ ○ 2") || !strings.Contains(view, "⟳ ○ 3") {
+		t.Errorf("expected cycle members to be flagged:\n%s", view)
+	}
+}
+
+func TestGraphViewModeRoundTrip(t *testing.T) {
+	m := createGraphTestModel(graphViewFixture())
+
+	state := m.extractUIState()
+	if state.ViewMode != "graph" {
+		t.Fatalf("expected graph view mode, got %q", state.ViewMode)
+	}
+	restored := createTestModel()
+	restored.restoreUIState(state)
+	if restored.viewMode != ViewModeGraph {
+		t.Errorf("expected graph view mode after restore")
+	}
+}
@@ -43,6 +43,13 @@ func (m Model) renderCompactHelp() string {
 	b.WriteString(formatCompactKey("n", "Get next available task", helpWidth))
 	b.WriteString(formatCompactKey("r", "Refresh tasks from disk", helpWidth))
 	b.WriteString(formatCompactKey(":", "Jump to task by ID", helpWidth))
+	b.WriteString(formatCompactKey("E", "Edit fields of the selected task", helpWidth))
+	b.WriteString(formatCompactKey("ctrl+e", "Edit the selected task as Markdown in $EDITOR", helpWidth))
+	b.WriteString(formatCompactKey("B", "Bulk actions on the selected tasks", helpWidth))
+	b.WriteString(formatCompactKey("s", "Start/stop timer on selected task", helpWidth))
+	b.WriteString(formatCompactKey("ctrl+z", "Undo last change (ctrl+y redo, H history)", helpWidth))
+	b.WriteString(formatCompactKey("o", "Cycle sort order (priority, status, ID, ...)", helpWidth))
+	b.WriteString(formatCompactKey("V", "Switch to a saved view (save from palette)", helpWidth))
 	b.WriteString(formatCompactKey("alt+c", "Analyze task complexity workflow", helpWidth))
 	b.WriteString(formatCompactKey("ctrl+p", "Open Command Palette (pick any action)", helpWidth))
 	b.WriteString(formatCompactKey("ctrl+shift+a", "Add a new tag context", helpWidth))
@@ -59,6 +66,7 @@ func (m Model) renderCompactHelp() string {
 	b.WriteString(formatTableRow("1", "Focus task list panel", "2", "Focus details panel", helpWidth))
 	b.WriteString(formatTableRow("3", "Focus log panel", "tab", "Cycle through panels", helpWidth))
 	b.WriteString(formatTableRow("d", "Toggle details panel", "L", "Toggle log panel", helpWidth))
+	b.WriteString(formatTableRow("M", "Markdown/raw details", "g", "Follow link in details", helpWidth))
 	b.WriteString(formatTableRow("t", "Switch to tree view", "T", "Switch to list view", helpWidth))
 	b.WriteString(formatCompactKey("v", "Cycle view modes", helpWidth))
 	b.WriteString("\n")
@@ -0,0 +1,340 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// kanbanStatuses lists the board columns in workflow order.
+var kanbanStatuses = []string{
+	taskmaster.StatusPending,
+	taskmaster.StatusInProgress,
+	taskmaster.StatusBlocked,
+	taskmaster.StatusDeferred,
+	taskmaster.StatusDone,
+	taskmaster.StatusCancelled,
+}
+
+// kanbanDefaultCollapsed lists the columns that start collapsed. These hold
+// finished work and tend to dominate the board in long-running projects.
+var kanbanDefaultCollapsed = map[string]bool{
+	taskmaster.StatusDone:      true,
+	taskmaster.StatusCancelled: true,
+}
+
+const (
+	kanbanCollapsedWidth = 7
+	kanbanMinColumnWidth = 16
+)
+
+// isKanbanColumnCollapsed reports whether the column for status is collapsed.
+func (m Model) isKanbanColumnCollapsed(status string) bool {
+	if collapsed, ok := m.kanbanCollapsed[status]; ok {
+		return collapsed
+	}
+	return kanbanDefaultCollapsed[status]
+}
+
+// setKanbanColumnCollapsed records the collapsed state of a column.
+func (m *Model) setKanbanColumnCollapsed(status string, collapsed bool) {
+	if m.kanbanCollapsed == nil {
+		m.kanbanCollapsed = make(map[string]bool)
+	}
+	m.kanbanCollapsed[status] = collapsed
+}
+
+// toggleKanbanFinishedColumns collapses or expands the done and cancelled
+// columns together. If either is collapsed both are expanded.
+func (m *Model) toggleKanbanFinishedColumns() {
+	collapse := true
+	for status := range kanbanDefaultCollapsed {
+		if m.isKanbanColumnCollapsed(status) {
+			collapse = false
+			break
+		}
+	}
+	for status := range kanbanDefaultCollapsed {
+		m.setKanbanColumnCollapsed(status, collapse)
+	}
+
+	if collapse {
+		m.addLogLine("Collapsed done/cancelled columns")
+	} else {
+		m.addLogLine("Expanded done/cancelled columns")
+	}
+
+	// Keep focus on an open column
+	if m.isKanbanColumnCollapsed(kanbanStatuses[m.kanbanColumn]) {
+		m.kanbanFocusColumn(m.nearestOpenKanbanColumn(m.kanbanColumn))
+	}
+}
+
+// kanbanColumns groups the tasks shown on the board by status column. It uses
+// the same source as the list view so search and status filters apply.
+func (m Model) kanbanColumns() [][]*taskmaster.Task {
+	columns := make([][]*taskmaster.Task, len(kanbanStatuses))
+	for _, task := range m.filteredFlatTasks() {
+		if col := kanbanColumnIndex(task.Status); col >= 0 {
+			columns[col] = append(columns[col], task)
+		}
+	}
+	return columns
+}
+
+// kanbanColumnIndex returns the column index for a status, or -1.
+func kanbanColumnIndex(status string) int {
+	for i, s := range kanbanStatuses {
+		if s == status {
+			return i
+		}
+	}
+	return -1
+}
+
+// kanbanActiveColumn returns the column that currently has focus. The stored
+// column wins unless it has cards and the selected task lives elsewhere, which
+// happens when the selection changes outside board navigation.
+func (m Model) kanbanActiveColumn(columns [][]*taskmaster.Task) int {
+	active := m.kanbanColumn
+	if active < 0 || active >= len(kanbanStatuses) {
+		active = 0
+	}
+	if m.selectedTask == nil || len(columns[active]) == 0 {
+		return active
+	}
+	for _, task := range columns[active] {
+		if task.ID == m.selectedTask.ID {
+			return active
+		}
+	}
+	if col := kanbanColumnIndex(m.selectedTask.Status); col >= 0 && !m.isKanbanColumnCollapsed(kanbanStatuses[col]) {
+		return col
+	}
+	return active
+}
+
+// syncKanbanColumn moves board focus to the column of the selected task.
+func (m *Model) syncKanbanColumn() {
+	if m.selectedTask == nil {
+		return
+	}
+	if col := kanbanColumnIndex(m.selectedTask.Status); col >= 0 && !m.isKanbanColumnCollapsed(kanbanStatuses[col]) {
+		m.kanbanColumn = col
+	}
+}
+
+// nearestOpenKanbanColumn returns the closest non-collapsed column to col.
+func (m Model) nearestOpenKanbanColumn(col int) int {
+	for offset := 0; offset < len(kanbanStatuses); offset++ {
+		for _, candidate := range []int{col - offset, col + offset} {
+			if candidate >= 0 && candidate < len(kanbanStatuses) && !m.isKanbanColumnCollapsed(kanbanStatuses[candidate]) {
+				return candidate
+			}
+		}
+	}
+	return col
+}
+
+// kanbanFocusColumn focuses a column and selects the card at the same row as
+// the current selection, clamped to the column length.
+func (m *Model) kanbanFocusColumn(col int) {
+	columns := m.kanbanColumns()
+	row := m.kanbanSelectedRow(columns)
+	m.kanbanColumn = col
+	cards := columns[col]
+	if len(cards) == 0 {
+		return
+	}
+	if row < 0 {
+		row = 0
+	}
+	if row >= len(cards) {
+		row = len(cards) - 1
+	}
+	m.selectKanbanCard(cards[row])
+}
+
+// kanbanSelectedRow returns the row of the selected card in the active column.
+func (m Model) kanbanSelectedRow(columns [][]*taskmaster.Task) int {
+	if m.selectedTask == nil {
+		return 0
+	}
+	for i, task := range columns[m.kanbanActiveColumn(columns)] {
+		if task.ID == m.selectedTask.ID {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m *Model) selectKanbanCard(task *taskmaster.Task) {
+	if stable, ok := m.taskIndex[task.ID]; ok {
+		m.selectedTask = stable
+	} else {
+		m.selectedTask = task
+	}
+	m.ensureTaskSelected(task.ID)
+}
+
+// kanbanMoveHorizontal moves focus to the next open column in direction delta.
+func (m *Model) kanbanMoveHorizontal(delta int) {
+	columns := m.kanbanColumns()
+	col := m.kanbanActiveColumn(columns)
+	for next := col + delta; next >= 0 && next < len(kanbanStatuses); next += delta {
+		if !m.isKanbanColumnCollapsed(kanbanStatuses[next]) {
+			m.kanbanColumn = col
+			m.kanbanFocusColumn(next)
+			return
+		}
+	}
+}
+
+// kanbanMoveVertical moves the selection up or down within the active column.
+func (m *Model) kanbanMoveVertical(delta int) {
+	columns := m.kanbanColumns()
+	col := m.kanbanActiveColumn(columns)
+	cards := columns[col]
+	if len(cards) == 0 {
+		return
+	}
+	row := m.kanbanSelectedRow(columns) + delta
+	if row < 0 {
+		row = 0
+	}
+	if row >= len(cards) {
+		row = len(cards) - 1
+	}
+	m.kanbanColumn = col
+	m.selectKanbanCard(cards[row])
+}
+
+// moveKanbanCard moves the selected card(s) to the adjacent column in
+// direction delta and persists the new status. Collapsed target columns are
+// expanded so the moved card stays visible.
+func (m *Model) moveKanbanCard(delta int) tea.Cmd {
+	if m.selectedTask == nil {
+		m.addLogLine("No task selected")
+		return nil
+	}
+	from := kanbanColumnIndex(m.selectedTask.Status)
+	if from < 0 {
+		m.addLogLine(fmt.Sprintf("Task %s has unknown status %q", m.selectedTask.ID, m.selectedTask.Status))
+		return nil
+	}
+	target := from + delta
+	if target < 0 || target >= len(kanbanStatuses) {
+		return nil
+	}
+	status := kanbanStatuses[target]
+	if m.isKanbanColumnCollapsed(status) {
+		m.setKanbanColumnCollapsed(status, false)
+	}
+	m.kanbanColumn = target
+	return m.setTaskStatus(status)
+}
+
+// renderKanbanBoard renders one column per status with WIP counts in the
+// column headers.
+func (m Model) renderKanbanBoard() string {
+	columns := m.kanbanColumns()
+	active := m.kanbanActiveColumn(columns)
+
+	totalWidth := m.taskListViewport.Width
+	if totalWidth <= 0 {
+		totalWidth = 80
+	}
+
+	openColumns := 0
+	for _, status := range kanbanStatuses {
+		if !m.isKanbanColumnCollapsed(status) {
+			openColumns++
+		}
+	}
+	collapsedColumns := len(kanbanStatuses) - openColumns
+
+	columnWidth := kanbanMinColumnWidth
+	if openColumns > 0 {
+		available := totalWidth - collapsedColumns*(kanbanCollapsedWidth+1) - (openColumns - 1)
+		if w := available / openColumns; w > columnWidth {
+			columnWidth = w
+		}
+	}
+
+	rendered := make([]string, 0, len(kanbanStatuses)*2)
+	for i, status := range kanbanStatuses {
+		if i > 0 {
+			rendered = append(rendered, " ")
+		}
+		if m.isKanbanColumnCollapsed(status) {
+			rendered = append(rendered, m.renderKanbanCollapsedColumn(status, len(columns[i])))
+			continue
+		}
+		rendered = append(rendered, m.renderKanbanColumn(status, columns[i], columnWidth, i == active))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+func (m Model) renderKanbanColumn(status string, cards []*taskmaster.Task, width int, focused bool) string {
+	statusStyle := m.styles.GetStatusStyle(status)
+	header := fmt.Sprintf("%s %s (%d)", GetStatusIcon(status), GetStatusLabel(status), len(cards))
+	header = ansi.Truncate(header, width, "…")
+	if focused {
+		header = m.styles.TaskSelected.Render(header)
+	} else {
+		header = statusStyle.Bold(true).Render(header)
+	}
+
+	lines := []string{header, strings.Repeat("─", width)}
+	if len(cards) == 0 {
+		lines = append(lines, m.styles.Subtle.Render("(empty)"))
+	}
+	for _, task := range cards {
+		lines = append(lines, m.renderKanbanCard(task, width, focused)...)
+	}
+
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) renderKanbanCollapsedColumn(status string, count int) string {
+	lines := []string{
+		m.styles.GetStatusStyle(status).Bold(true).Render(GetStatusIcon(status)),
+		m.styles.Subtle.Render(fmt.Sprintf("%d", count)),
+		m.styles.Subtle.Render("▸"),
+	}
+	return lipgloss.NewStyle().Width(kanbanCollapsedWidth).Render(strings.Join(lines, "\n"))
+}
+
+// renderKanbanCard renders a card as an ID/priority/complexity line followed
+// by the task title.
+func (m Model) renderKanbanCard(task *taskmaster.Task, width int, focusedColumn bool) []string {
+	isSelected := focusedColumn && m.selectedTask != nil && m.selectedTask.ID == task.ID
+
+	prefix := "  "
+	if isSelected {
+		prefix = "> "
+	}
+	if m.isTaskSelected(task.ID) {
+		prefix += "[✓] "
+	}
+
+	meta := []string{task.ID}
+	if task.Priority != "" {
+		meta = append(meta, task.Priority)
+	}
+	if task.Complexity > 0 {
+		meta = append(meta, GetComplexityIndicator(task.Complexity))
+	}
+	header := ansi.Truncate(prefix+strings.Join(meta, " · "), width, "…")
+	title := ansi.Truncate("  "+task.Title, width, "…")
+
+	if isSelected {
+		return []string{m.styles.TaskSelected.Render(header), m.styles.TaskSelected.Render(title), ""}
+	}
+	return []string{m.styles.TaskUnselected.Render(header), m.styles.Subtle.Render(title), ""}
+}
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func createKanbanTestModel() Model {
+	m := createTestModel()
+	m.taskService = mockTaskService()
+	m.viewMode = ViewModeKanban
+	m.rebuildVisibleTasks()
+	m.ensureTaskSelected("1")
+	m.syncKanbanColumn()
+	return m
+}
+
+func TestKanbanColumnsGroupByStatus(t *testing.T) {
+	m := createKanbanTestModel()
+
+	columns := m.kanbanColumns()
+	if len(columns) != len(kanbanStatuses) {
+		t.Fatalf("expected %d columns, got %d", len(kanbanStatuses), len(columns))
+	}
+
+	pending := columns[kanbanColumnIndex(taskmaster.StatusPending)]
+	if len(pending) != 2 || pending[0].ID != "1" || pending[1].ID != "1.1" {
+		t.Errorf("unexpected pending column: %v", taskIDs(pending))
+	}
+	if got := columns[kanbanColumnIndex(taskmaster.StatusInProgress)]; len(got) != 1 || got[0].ID != "2" {
+		t.Errorf("unexpected in-progress column: %v", taskIDs(got))
+	}
+	if got := columns[kanbanColumnIndex(taskmaster.StatusDone)]; len(got) != 1 || got[0].ID != "1.2" {
+		t.Errorf("unexpected done column: %v", taskIDs(got))
+	}
+}
+
+func TestKanbanNavigationSkipsCollapsedColumns(t *testing.T) {
+	m := createKanbanTestModel()
+
+	m.kanbanMoveVertical(1)
+	if m.selectedTask == nil || m.selectedTask.ID != "1.1" {
+		t.Fatalf("expected 1.1 selected after moving down, got %v", m.selectedTask)
+	}
+
+	m.kanbanMoveHorizontal(1)
+	if m.selectedTask.ID != "2" {
+		t.Fatalf("expected task 2 selected in in-progress column, got %s", m.selectedTask.ID)
+	}
+
+	// Blocked and deferred are empty but open; done and cancelled are collapsed
+	for i := 0; i < 5; i++ {
+		m.kanbanMoveHorizontal(1)
+	}
+	if status := kanbanStatuses[m.kanbanColumn]; status != taskmaster.StatusDeferred {
+		t.Errorf("expected focus to stop at deferred column, got %s", status)
+	}
+
+	m.toggleKanbanFinishedColumns()
+	if m.isKanbanColumnCollapsed(taskmaster.StatusDone) {
+		t.Fatal("expected done column to expand")
+	}
+	m.kanbanMoveHorizontal(1)
+	if m.selectedTask.ID != "1.2" {
+		t.Errorf("expected done card 1.2 selected, got %s", m.selectedTask.ID)
+	}
+}
+
+func TestKanbanMoveCardPersistsStatus(t *testing.T) {
+	m := createKanbanTestModel()
+	svc := m.taskService.(*mockService)
+
+	cmd := m.moveKanbanCard(1)
+	if cmd == nil {
+		t.Fatal("expected a command to persist the status change")
+	}
+	msg, ok := cmd().(TaskStatusUpdatedMsg)
+	if !ok {
+		t.Fatalf("expected TaskStatusUpdatedMsg, got %T", cmd())
+	}
+	if msg.Err != nil || msg.Status != taskmaster.StatusInProgress {
+		t.Fatalf("unexpected result: %+v", msg)
+	}
+	if task, _ := svc.GetTaskByID("1"); task.Status != taskmaster.StatusInProgress {
+		t.Errorf("expected service status in-progress, got %s", task.Status)
+	}
+	if kanbanStatuses[m.kanbanColumn] != taskmaster.StatusInProgress {
+		t.Errorf("expected focus to follow the card")
+	}
+}
+
+func TestKanbanMoveCardIntoCollapsedColumnExpandsIt(t *testing.T) {
+	m := createKanbanTestModel()
+	m.ensureTaskSelected("2")
+	m.selectedTask.Status = taskmaster.StatusDeferred
+	m.syncKanbanColumn()
+
+	if cmd := m.moveKanbanCard(1); cmd == nil {
+		t.Fatal("expected a command")
+	}
+	if m.isKanbanColumnCollapsed(taskmaster.StatusDone) {
+		t.Error("expected done column to expand when a card moves into it")
+	}
+}
+
+func TestKanbanRenderShowsWIPCounts(t *testing.T) {
+	m := createKanbanTestModel()
+	m.taskListViewport.Width = 120
+
+	board := m.renderKanbanBoard()
+	if !strings.Contains(board, "PENDING (2)") {
+		t.Errorf("expected pending WIP count in board:\n%s", board)
+	}
+	if !strings.Contains(board, "IN-PROGRESS (1)") {
+		t.Errorf("expected in-progress WIP count in board:\n%s", board)
+	}
+	if strings.Contains(board, "DONE (") {
+		t.Errorf("expected done column to be collapsed:\n%s", board)
+	}
+}
+
+func TestKanbanStateRoundTrip(t *testing.T) {
+	m := createKanbanTestModel()
+	m.toggleKanbanFinishedColumns()
+
+	state := m.extractUIState()
+	if state.ViewMode != "kanban" {
+		t.Fatalf("expected kanban view mode, got %q", state.ViewMode)
+	}
+
+	restored := createTestModel()
+	restored.restoreUIState(state)
+	if restored.viewMode != ViewModeKanban {
+		t.Errorf("expected kanban view mode after restore")
+	}
+	if restored.isKanbanColumnCollapsed(taskmaster.StatusDone) {
+		t.Errorf("expected done column expansion to round-trip")
+	}
+}
+
+func TestCycleViewIncludesKanban(t *testing.T) {
+	m := createTestModel()
+	m.viewMode = ViewModeList
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if updated.(Model).viewMode != ViewModeKanban {
+		t.Errorf("expected cycle from list to kanban")
+	}
+}
+
+func taskIDs(tasks []*taskmaster.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
@@ -59,9 +59,16 @@ type KeyMap struct {
 	ProjectSearch      key.Binding
 
 	// View modes
-	ViewTree  key.Binding
-	ViewList  key.Binding
-	CycleView key.Binding
+	ViewTree   key.Binding
+	ViewList   key.Binding
+	ViewKanban key.Binding
+	ViewGraph  key.Binding
+	CycleView  key.Binding
+
+	// Kanban board
+	MoveCardLeft          key.Binding
+	MoveCardRight         key.Binding
+	ToggleFinishedColumns key.Binding
 
 	// Help and quit
 	Help   key.Binding
@@ -73,6 +80,29 @@ type KeyMap struct {
 
 	// Analysis
 	AnalyzeComplexity key.Binding
+
+	// Time tracking
+	ToggleTimer key.Binding
+
+	// Undo history
+	Undo        key.Binding
+	Redo        key.Binding
+	UndoHistory key.Binding
+
+	// Saved views
+	SavedViews key.Binding
+
+	// Sorting
+	CycleSort key.Binding
+
+	// Editing
+	EditTask     key.Binding
+	EditInEditor key.Binding
+	BulkActions  key.Binding
+
+	// Details panel
+	ToggleMarkdown key.Binding
+	FollowLink     key.Binding
 }
 
 // DefaultKeyMap returns the default keybindings
@@ -135,7 +165,7 @@ func DefaultKeyMap() KeyMap {
 		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
-			key.WithHelp("/", "search tasks"),
+			key.WithHelp("/", "search or query tasks"),
 		),
 		Filter: key.NewBinding(
 			key.WithKeys("F"),
@@ -145,6 +175,50 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("alt+c"),
 			key.WithHelp("Alt+C", "analyze complexity"),
 		),
+		ToggleTimer: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "start/stop timer"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("ctrl+z"),
+			key.WithHelp("ctrl+z", "undo"),
+		),
+		Redo: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("ctrl+y", "redo"),
+		),
+		UndoHistory: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "undo history"),
+		),
+		SavedViews: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "saved views"),
+		),
+		CycleSort: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "cycle sort order"),
+		),
+		EditTask: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "edit task"),
+		),
+		EditInEditor: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("ctrl+e", "edit in $EDITOR"),
+		),
+		BulkActions: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "bulk actions"),
+		),
+		ToggleMarkdown: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "toggle Markdown/raw details"),
+		),
+		FollowLink: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "follow link in details"),
+		),
 
 		// Status changes
 		SetInProgress: key.NewBinding(
@@ -259,11 +333,33 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("T"),
 			key.WithHelp("T", "list view"),
 		),
+		ViewKanban: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "kanban view"),
+		),
+		ViewGraph: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("G", "dependency graph view"),
+		),
 		CycleView: key.NewBinding(
 			key.WithKeys("v"),
 			key.WithHelp("v", "cycle view"),
 		),
 
+		// Kanban board
+		MoveCardLeft: key.NewBinding(
+			key.WithKeys("<", "shift+left"),
+			key.WithHelp("<", "move card left"),
+		),
+		MoveCardRight: key.NewBinding(
+			key.WithKeys(">", "shift+right"),
+			key.WithHelp(">", "move card right"),
+		),
+		ToggleFinishedColumns: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "collapse done/cancelled"),
+		),
+
 		// Help and quit
 		Help: key.NewBinding(
 			key.WithKeys("?"),
@@ -375,6 +471,83 @@ func NewKeyMap(cfg *config.Config) KeyMap {
 		)
 	}
 
+	if timerKey := getKey("toggleTimer", "s"); timerKey != "" {
+		km.ToggleTimer = key.NewBinding(
+			key.WithKeys(timerKey),
+			key.WithHelp(timerKey, "start/stop timer"),
+		)
+	}
+
+	if undoKey := getKey("undo", "ctrl+z"); undoKey != "" {
+		km.Undo = key.NewBinding(
+			key.WithKeys(undoKey),
+			key.WithHelp(undoKey, "undo"),
+		)
+	}
+
+	if redoKey := getKey("redo", "ctrl+y"); redoKey != "" {
+		km.Redo = key.NewBinding(
+			key.WithKeys(redoKey),
+			key.WithHelp(redoKey, "redo"),
+		)
+	}
+
+	if historyKey := getKey("undoHistory", "H"); historyKey != "" {
+		km.UndoHistory = key.NewBinding(
+			key.WithKeys(historyKey),
+			key.WithHelp(historyKey, "undo history"),
+		)
+	}
+
+	if viewsKey := getKey("savedViews", "V"); viewsKey != "" {
+		km.SavedViews = key.NewBinding(
+			key.WithKeys(viewsKey),
+			key.WithHelp(viewsKey, "saved views"),
+		)
+	}
+
+	if sortKey := getKey("cycleSort", "o"); sortKey != "" {
+		km.CycleSort = key.NewBinding(
+			key.WithKeys(sortKey),
+			key.WithHelp(sortKey, "cycle sort order"),
+		)
+	}
+
+	if editKey := getKey("editTask", "E"); editKey != "" {
+		km.EditTask = key.NewBinding(
+			key.WithKeys(editKey),
+			key.WithHelp(editKey, "edit task"),
+		)
+	}
+
+	if externalKey := getKey("editInEditor", "ctrl+e"); externalKey != "" {
+		km.EditInEditor = key.NewBinding(
+			key.WithKeys(externalKey),
+			key.WithHelp(externalKey, "edit in $EDITOR"),
+		)
+	}
+
+	if bulkKey := getKey("bulkActions", "B"); bulkKey != "" {
+		km.BulkActions = key.NewBinding(
+			key.WithKeys(bulkKey),
+			key.WithHelp(bulkKey, "bulk actions"),
+		)
+	}
+
+	if markdownKey := getKey("toggleMarkdown", "M"); markdownKey != "" {
+		km.ToggleMarkdown = key.NewBinding(
+			key.WithKeys(markdownKey),
+			key.WithHelp(markdownKey, "toggle Markdown/raw details"),
+		)
+	}
+
+	if linkKey := getKey("followLink", "g"); linkKey != "" {
+		km.FollowLink = key.NewBinding(
+			key.WithKeys(linkKey),
+			key.WithHelp(linkKey, "follow link in details"),
+		)
+	}
+
 	if paletteKey := getKey("commandPalette", "ctrl+p"); paletteKey != "" {
 		km.CommandPalette = key.NewBinding(
 			key.WithKeys(paletteKey),
@@ -465,14 +638,17 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
 		{k.PageUp, k.PageDown, k.ToggleExpand, k.Select},
-		{k.NextTask, k.Refresh, k.JumpToID},
+		{k.NextTask, k.Refresh, k.JumpToID, k.CycleSort},
 		{k.SetInProgress, k.SetDone, k.SetBlocked, k.SetCancelled},
 		{k.SetDeferred, k.SetPending},
 		{k.FocusTaskList, k.FocusDetails, k.FocusLog, k.CyclePanel},
-		{k.ToggleDetails, k.ToggleLog},
+		{k.ToggleDetails, k.ToggleLog, k.ToggleMarkdown, k.FollowLink},
+		{k.ViewTree, k.ViewList, k.ViewKanban, k.ViewGraph, k.CycleView, k.SavedViews},
+		{k.MoveCardLeft, k.MoveCardRight, k.ToggleFinishedColumns},
 		{k.Help, k.Quit, k.Cancel, k.ClearState},
-		{k.AnalyzeComplexity},
-		{k.CommandPalette, k.ParsePRD, k.ExpandTask, k.DeleteTask, k.RunTask},
+		{k.AnalyzeComplexity, k.ToggleTimer},
+		{k.Undo, k.Redo, k.UndoHistory},
+		{k.CommandPalette, k.EditTask, k.EditInEditor, k.BulkActions, k.ParsePRD, k.ExpandTask, k.DeleteTask, k.RunTask},
 		{k.ManageTags, k.TagManagement, k.UseTag},
 		{k.ProjectTags, k.ProjectQuickSwitch, k.ProjectSearch},
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/agreen757/tm-tui/internal/taskmaster"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 const (
@@ -182,7 +183,7 @@ func (m Model) renderHeader() string {
 			BorderStyle(lipgloss.RoundedBorder()).
 			BorderForeground(lipgloss.Color("#00FFFF")). // ColorHighlight
 			Padding(0, 1).
-			Width(40)
+			Width(60)
 
 		searchBoxView := m.searchInput.View()
 		styledSearchBox := searchBoxStyle.Render(searchBoxView)
@@ -190,6 +191,18 @@ func (m Model) renderHeader() string {
 		searchHelp := m.styles.Subtle.Render(" (Enter to search, Esc to cancel)")
 		searchLine := searchLabel + styledSearchBox + searchHelp
 
+		// The error row is always reserved so the list below does not move
+		// as the query becomes valid or invalid
+		errLine := ""
+		if m.searchErr != nil {
+			errLine = "✗ " + m.searchErr.Error()
+			if m.width > 0 {
+				errLine = ansi.Truncate(errLine, m.width, "…")
+			}
+			errLine = m.styles.Error.Render(errLine)
+		}
+		searchLine += "\n" + errLine
+
 		return titleLine + "\n" + countsLine + "\n" + searchLine + "\n"
 	}
 
@@ -276,9 +289,21 @@ func (m Model) renderStatusBar() string {
 
 	// Show search mode status if in search mode
 	if m.searchMode {
-		searchStatus := fmt.Sprintf("🔍 SEARCH MODE: Type search query and press Enter, or Esc to cancel")
+		searchStatus := "🔍 SEARCH MODE: Type text or a query (status:pending tag:api) and press Enter, or Esc to cancel"
+		if m.searchQuery != "" {
+			searchStatus = fmt.Sprintf("🔍 %s", m.searchQuery)
+		}
+		color := lipgloss.Color("#00FFFF") // ColorHighlight
+		if m.searchErr != nil {
+			searchStatus = fmt.Sprintf("%s  ✗ %s", searchStatus, m.searchErr)
+			color = lipgloss.Color("#DC143C") // ColorBlocked
+		}
+		// Truncate rather than wrap, so the status bar stays one row high
+		if m.width > 2 {
+			searchStatus = ansi.Truncate(searchStatus, m.width-2, "…")
+		}
 		return m.styles.StatusBar.
-			Foreground(lipgloss.Color("#00FFFF")). // ColorHighlight
+			Foreground(color).
 			Width(m.width).
 			Render(searchStatus)
 	}
@@ -288,6 +313,12 @@ func (m Model) renderStatusBar() string {
 	if active := m.activeProjectStatus(); active != "" {
 		helpText = fmt.Sprintf("%s | %s", helpText, active)
 	}
+	if timer := m.timerStatus(); timer != "" {
+		helpText = fmt.Sprintf("%s | %s", helpText, timer)
+	}
+	if sorting := m.sortStatus(); sorting != "" {
+		helpText = fmt.Sprintf("%s | %s", helpText, sorting)
+	}
 	return m.styles.StatusBar.Width(m.width).Render(helpText)
 }
 
@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxMarkdownCache bounds the number of rendered fields kept between redraws.
+const maxMarkdownCache = 128
+
+// markdownRenderer renders task text as Markdown for the details panel.
+// Rendering is cached because the panel is redrawn on every selection change.
+type markdownRenderer struct {
+	renderer *glamour.TermRenderer
+	cache    map[string]string
+}
+
+// newMarkdownRenderer builds a renderer matching the terminal background.
+// Glamour does not wrap; the output is wrapped with ansiWrapText instead so
+// it follows the same rules as the log panel.
+func newMarkdownRenderer() *markdownRenderer {
+	style := styles.DarkStyleConfig
+	if !lipgloss.HasDarkBackground() {
+		style = styles.LightStyleConfig
+	}
+	noMargin := uint(0)
+	style.Document.Margin = &noMargin
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+	style.CodeBlock.Margin = &noMargin
+
+	renderer, err := glamour.NewTermRenderer(glamour.WithStyles(style), glamour.WithWordWrap(0))
+	if err != nil {
+		return &markdownRenderer{}
+	}
+	return &markdownRenderer{renderer: renderer, cache: make(map[string]string)}
+}
+
+// render returns text as styled Markdown wrapped to width. Text that fails to
+// render, or any text when there is no renderer, is shown as it is.
+func (r *markdownRenderer) render(text string, width int) string {
+	if r == nil {
+		return ansiWrapText(strings.TrimSpace(text), width)
+	}
+	key := fmt.Sprintf("%d\x00%s", width, text)
+	if cached, ok := r.cache[key]; ok {
+		return cached
+	}
+
+	out := strings.ReplaceAll(text, "\t", "    ")
+	if r.renderer != nil {
+		if rendered, err := r.renderer.Render(out); err == nil {
+			out = strings.Trim(rendered, "\n")
+		}
+	}
+	out = ansiWrapText(out, width)
+
+	if r.cache != nil {
+		if len(r.cache) >= maxMarkdownCache {
+			clear(r.cache)
+		}
+		r.cache[key] = out
+	}
+	return out
+}
+
+// renderDetailText renders a long text field of a task: Markdown by default,
+// or the source text with its line breaks when raw details are toggled on.
+func (m Model) renderDetailText(text string, width int) string {
+	if m.rawDetails {
+		return ansiWrapText(strings.TrimSpace(text), width)
+	}
+	return m.markdown.render(text, width)
+}
+
+// toggleRawDetails switches the details panel between rendered Markdown and
+// the raw text and persists the choice.
+func (m *Model) toggleRawDetails() {
+	m.rawDetails = !m.rawDetails
+	m.updateDetailsViewport()
+	if m.rawDetails {
+		m.addLogLine("Details: showing raw text")
+	} else {
+		m.addLogLine("Details: rendering Markdown")
+	}
+	if err := m.SaveUIState(); err != nil {
+		m.addLogLine(fmt.Sprintf("Failed to save details mode: %v", err))
+	}
+}
+
+// detailLink is a URL or a reference to another task found in a task's text.
+type detailLink struct {
+	URL    string
+	TaskID string
+}
+
+var (
+	detailURLPattern     = regexp.MustCompile(`https?://[^\s<>()\[\]{}"'` + "`" + `]+`)
+	detailTaskRefPattern = regexp.MustCompile(`(?i)(?:\b(?:sub)?tasks?\s+#?|(?:^|[\s(])#)(\d+(?:\.\d+)*)\b`)
+)
+
+// detectDetailLinks finds URLs and references to other existing tasks in the
+// description, details and test strategy of task, in order of appearance.
+func detectDetailLinks(task *taskmaster.Task, index map[string]*taskmaster.Task) []detailLink {
+	var links []detailLink
+	seen := make(map[string]bool)
+	for _, text := range []string{task.Description, task.Details, task.TestStrategy} {
+		type match struct {
+			pos  int
+			link detailLink
+		}
+		var found []match
+		for _, loc := range detailURLPattern.FindAllStringIndex(text, -1) {
+			url := strings.TrimRight(text[loc[0]:loc[1]], ".,;:!?")
+			found = append(found, match{loc[0], detailLink{URL: url}})
+		}
+		for _, sub := range detailTaskRefPattern.FindAllStringSubmatchIndex(text, -1) {
+			id := text[sub[2]:sub[3]]
+			if _, ok := index[id]; ok && id != task.ID {
+				found = append(found, match{sub[2], detailLink{TaskID: id}})
+			}
+		}
+		// URLs can contain digits that look like task references.
+		for i := 0; i < len(found); i++ {
+			for j := i + 1; j < len(found); j++ {
+				if found[j].pos < found[i].pos {
+					found[i], found[j] = found[j], found[i]
+				}
+			}
+		}
+		urlEnd := -1
+		for _, f := range found {
+			if f.link.URL != "" {
+				urlEnd = f.pos + len(f.link.URL)
+			} else if f.pos < urlEnd {
+				continue
+			}
+			key := f.link.URL + "\x00" + f.link.TaskID
+			if !seen[key] {
+				seen[key] = true
+				links = append(links, f.link)
+			}
+		}
+	}
+	return links
+}
+
+// detailLinks returns the links of the task shown in the details panel.
+func (m Model) detailLinks() []detailLink {
+	if m.selectedTask == nil {
+		return nil
+	}
+	task := m.selectedTask
+	if fresh, ok := m.taskIndex[task.ID]; ok {
+		task = fresh
+	}
+	return detectDetailLinks(task, m.taskIndex)
+}
+
+// detailLinkLabel describes a link in the details panel and link picker.
+func (m Model) detailLinkLabel(link detailLink) string {
+	if link.URL != "" {
+		return link.URL
+	}
+	if task, ok := m.taskIndex[link.TaskID]; ok && task.Title != "" {
+		return fmt.Sprintf("Task %s · %s", link.TaskID, task.Title)
+	}
+	return "Task " + link.TaskID
+}
+
+// followDetailLink follows the only link of the selected task, or asks which
+// one to follow when there are several.
+func (m *Model) followDetailLink() tea.Cmd {
+	links := m.detailLinks()
+	switch len(links) {
+	case 0:
+		m.addLogLine("No links in the selected task")
+		return nil
+	case 1:
+		return m.openDetailLink(links[0])
+	}
+
+	dm := m.dialogManager()
+	if dm == nil {
+		return nil
+	}
+	items := make([]dialog.ListItem, len(links))
+	for i, link := range links {
+		items[i] = &detailLinkItem{link: link, label: m.detailLinkLabel(link)}
+	}
+	list := dialog.NewListDialog("Follow Link", 72, 16, items)
+	list.SetFooterHints(
+		dialog.ShortcutHint{Key: "↑/↓", Label: "Navigate"},
+		dialog.ShortcutHint{Key: "Enter", Label: "Follow"},
+		dialog.ShortcutHint{Key: "Esc", Label: "Close"},
+	)
+	if dm.Style != nil {
+		dialog.ApplyStyleToDialog(list, dm.Style)
+	}
+	m.appState.AddDialog(list, func(value interface{}, err error) tea.Cmd {
+		if err != nil {
+			return nil
+		}
+		msg, ok := value.(dialog.ListSelectionMsg)
+		if !ok || msg.SelectedItem == nil {
+			return nil
+		}
+		if item, ok := msg.SelectedItem.(*detailLinkItem); ok {
+			return m.openDetailLink(item.link)
+		}
+		return nil
+	})
+	return nil
+}
+
+// openDetailLink selects a referenced task or opens a URL in the browser.
+func (m *Model) openDetailLink(link detailLink) tea.Cmd {
+	if link.TaskID != "" {
+		if !m.selectTaskByID(link.TaskID) {
+			m.addLogLine(fmt.Sprintf("Task %s is hidden by the current filter", link.TaskID))
+			return nil
+		}
+		m.addLogLine(fmt.Sprintf("Jumped to task %s", link.TaskID))
+		m.updateTaskListViewport()
+		m.updateDetailsViewport()
+		return nil
+	}
+	m.addLogLine(fmt.Sprintf("Opening %s", link.URL))
+	url := link.URL
+	return func() tea.Msg {
+		cmd := browserCommand(url)
+		if err := cmd.Start(); err != nil {
+			return LinkOpenedMsg{URL: url, Err: err}
+		}
+		// Reap the handler once it exits so it does not linger as a zombie
+		go cmd.Wait()
+		return LinkOpenedMsg{URL: url}
+	}
+}
+
+// browserCommand builds the command that opens url with the system handler.
+func browserCommand(url string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
+
+// detailLinkItem adapts a detail link to a dialog.ListItem.
+type detailLinkItem struct {
+	link  detailLink
+	label string
+}
+
+func (i *detailLinkItem) Title() string       { return i.label }
+func (i *detailLinkItem) Description() string { return "" }
+func (i *detailLinkItem) FilterValue() string { return i.label }
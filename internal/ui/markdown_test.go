@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderTaskDetailsRendersMarkdown(t *testing.T) {
+	model := newTestModel()
+	model.styles = NewStyles()
+	model.markdown = newMarkdownRenderer()
+	model.detailsViewport.Width = 44
+	task := &taskmaster.Task{
+		ID:      "1",
+		Title:   "Markdown",
+		Status:  taskmaster.StatusPending,
+		Details: "## Steps\n\n- first step\n- second step\n\n```go\nfunc main() {}\n```",
+	}
+	model.tasks = []taskmaster.Task{*task}
+	model.buildTaskIndex()
+	model.selectedTask = model.taskIndex["1"]
+
+	rendered := ansi.Strip(model.renderTaskDetails())
+	if strings.Contains(rendered, "```") || strings.Contains(rendered, "- first") {
+		t.Fatalf("expected Markdown syntax to be rendered, got:\n%s", rendered)
+	}
+	for _, want := range []string{"Steps", "first step", "func main() {}"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("expected %q in rendered details, got:\n%s", want, rendered)
+		}
+	}
+	for _, line := range strings.Split(model.renderTaskDetails(), "\n") {
+		if width := ansi.StringWidth(line); width > 40 {
+			t.Fatalf("expected lines wrapped to 40 columns, got %d: %q", width, ansi.Strip(line))
+		}
+	}
+
+	model.toggleRawDetails()
+	raw := ansi.Strip(model.renderTaskDetails())
+	if !strings.Contains(raw, "```go") || !strings.Contains(raw, "- second step") {
+		t.Fatalf("expected raw details after toggling, got:\n%s", raw)
+	}
+
+	// Without a renderer the text is shown as it is
+	var none *markdownRenderer
+	if got := none.render("- first step\n", 40); got != "- first step" {
+		t.Fatalf("expected plain text without a renderer, got %q", got)
+	}
+}
+
+func TestDetectDetailLinks(t *testing.T) {
+	model := newTestModel()
+	model.styles = NewStyles()
+	model.tasks = []taskmaster.Task{
+		{ID: "1", Title: "Setup", Description: "See https://example.com/docs/12, and task 2.", Details: "Blocked by #3 and subtask 2.1; see task 1."},
+		{ID: "2", Title: "Build", Subtasks: []taskmaster.Task{{ID: "2.1", ParentID: "2", Title: "Compile"}}},
+		{ID: "3", Title: "Ship"},
+	}
+	model.buildTaskIndex()
+	model.selectedTask = model.taskIndex["1"]
+
+	links := model.detailLinks()
+	want := []detailLink{{URL: "https://example.com/docs/12"}, {TaskID: "2"}, {TaskID: "3"}, {TaskID: "2.1"}}
+	if len(links) != len(want) {
+		t.Fatalf("expected %d links, got %+v", len(want), links)
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Fatalf("expected link %d to be %+v, got %+v", i, want[i], links[i])
+		}
+	}
+	if !strings.Contains(ansi.Strip(model.renderTaskDetails()), "[3] Task 3 · Ship") {
+		t.Fatalf("expected numbered links in the details panel")
+	}
+
+	if cmd := model.openDetailLink(detailLink{TaskID: "3"}); cmd != nil {
+		t.Fatalf("expected following a task link to run no command")
+	}
+	if model.selectedTask == nil || model.selectedTask.ID != "3" {
+		t.Fatalf("expected task 3 selected, got %+v", model.selectedTask)
+	}
+
+	model.selectedTask = model.taskIndex["1"]
+	model.followDetailLink()
+	if dlg := model.appState.ActiveDialog(); dlg == nil || dlg.Title() != "Follow Link" {
+		t.Fatalf("expected link picker for several links, got %v", dlg)
+	}
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	mergeConflictDialogID = "merge_conflict_dialog"
+	// maxConflictLines caps how many conflicts the dialog lists.
+	maxConflictLines = 6
+)
+
+// showMergeConflict opens the merge conflict dialog when err reports that
+// tasks.json was edited externally with conflicting changes. It returns false
+// for any other error so callers can fall back to their usual error handling.
+func (m *Model) showMergeConflict(err error) bool {
+	var conflict *taskmaster.MergeConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+	if m.dialogManager() == nil {
+		m.addLogLine("Merge conflict: " + conflict.Error())
+		return true
+	}
+
+	content := dialog.NewSimpleModalContent(mergeConflictText(conflict))
+	button := func(kind dialog.ModalButtonKind, label string, resolution taskmaster.MergeResolution) dialog.ModalButton {
+		return dialog.ModalButton{
+			Kind:  kind,
+			Label: label,
+			OnClick: func() (dialog.DialogResult, tea.Cmd) {
+				return dialog.DialogResultConfirm, func() tea.Msg {
+					return dialog.DialogResultMsg{ID: mergeConflictDialogID, Button: string(resolution), Value: resolution}
+				}
+			},
+		}
+	}
+	buttons := []dialog.ModalButton{
+		button(dialog.ButtonYes, "Keep Mine", taskmaster.MergeKeepMine),
+		button(dialog.ButtonCustom, "Keep Theirs", taskmaster.MergeKeepTheirs),
+		button(dialog.ButtonNo, "Discard Mine", taskmaster.MergeDiscardMine),
+	}
+	lines := len(conflict.Conflicts)
+	if lines > maxConflictLines {
+		lines = maxConflictLines + 1
+	}
+	dlg := dialog.NewButtonModalDialog("Merge Conflict", 78, 10+lines, content, buttons)
+	dlg.ModalDialog.BaseDialog.ID = mergeConflictDialogID
+	m.appState.AddDialog(dlg, nil)
+	return true
+}
+
+// mergeConflictText describes each conflicting field with both values.
+func mergeConflictText(conflict *taskmaster.MergeConflictError) string {
+	var b strings.Builder
+	b.WriteString("tasks.json was changed outside tm-tui while you were editing.\n")
+	b.WriteString("These fields were changed on both sides:\n\n")
+	for i, c := range conflict.Conflicts {
+		if i == maxConflictLines {
+			fmt.Fprintf(&b, "…and %d more\n", len(conflict.Conflicts)-i)
+			break
+		}
+		fmt.Fprintf(&b, "• %s %s: mine %s, theirs %s\n", c.TaskID, c.Field, c.Mine, c.Theirs)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// resolveMergeConflictCmd settles the pending conflicting write.
+func resolveMergeConflictCmd(svc TaskService, resolution taskmaster.MergeResolution) tea.Cmd {
+	if svc == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		err := svc.ResolveMergeConflict(context.Background(), resolution)
+		return MergeResolvedMsg{Resolution: resolution, Err: err}
+	}
+}
+
+// handleMergeResolved logs the outcome of a conflict resolution and reloads
+// tasks.
+func (m *Model) handleMergeResolved(msg MergeResolvedMsg) tea.Cmd {
+	if msg.Err != nil {
+		appErr := NewOperationError("Merge", "Failed to resolve merge conflict", msg.Err).
+			WithRecoveryHints(
+				"Check that tasks.json is writable",
+				"Reload tasks and try again",
+			)
+		m.showAppError(appErr)
+		return nil
+	}
+	switch msg.Resolution {
+	case taskmaster.MergeKeepMine:
+		m.addLogLine("✓ Merged external changes, keeping your conflicting edits")
+	case taskmaster.MergeKeepTheirs:
+		m.addLogLine("✓ Merged external changes, keeping their conflicting edits")
+	default:
+		m.addLogLine("Discarded your change and reloaded tasks.json")
+	}
+	return LoadTasksCmd(m.taskService)
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+)
+
+func TestMergeConflictDialogResolvesPendingWrite(t *testing.T) {
+	model := newTestModel()
+	svc := mockTaskService()
+	svc.conflict = &taskmaster.MergeConflictError{Conflicts: []taskmaster.FieldConflict{
+		{TaskID: "2", Title: "Medium complexity task", Field: "status", Mine: `"done"`, Theirs: `"blocked"`},
+	}}
+	model.taskService = svc
+
+	err := svc.SetTaskStatus("2", taskmaster.StatusDone)
+	if !model.showMergeConflict(err) {
+		t.Fatalf("expected merge conflict to be shown for %v", err)
+	}
+	active, ok := model.appState.ActiveDialog().(*dialog.ButtonModalDialog)
+	if !ok || active.Title() != "Merge Conflict" {
+		t.Fatalf("expected merge conflict dialog, got %T", model.appState.ActiveDialog())
+	}
+
+	cmd := model.handleDialogResultMsg(dialog.DialogResultMsg{
+		ID:    mergeConflictDialogID,
+		Value: taskmaster.MergeKeepTheirs,
+	})
+	if cmd == nil {
+		t.Fatal("expected a command to resolve the conflict")
+	}
+	msg, ok := cmd().(MergeResolvedMsg)
+	if !ok || msg.Err != nil || svc.resolved != taskmaster.MergeKeepTheirs {
+		t.Fatalf("expected conflict resolved with theirs, got %+v", msg)
+	}
+	if model.showMergeConflict(taskmaster.ErrNoPendingMerge) {
+		t.Fatal("expected other errors to be left to the caller")
+	}
+}
+
+func TestMergeConflictTextListsFields(t *testing.T) {
+	text := mergeConflictText(&taskmaster.MergeConflictError{Conflicts: []taskmaster.FieldConflict{
+		{TaskID: "3", Field: "title", Mine: `"Ship"`, Theirs: `"Release"`},
+	}})
+	want := `• 3 title: mine "Ship", theirs "Release"`
+	if !strings.Contains(text, want) {
+		t.Fatalf("expected %q in %q", want, text)
+	}
+}
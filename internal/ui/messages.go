@@ -8,6 +8,7 @@ import (
 	"github.com/agreen757/tm-tui/internal/executor"
 	"github.com/agreen757/tm-tui/internal/projects"
 	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/timetrack"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -111,6 +112,77 @@ type tagListLoadedMsg struct {
 	Err  error
 }
 
+// TaskStatusUpdatedMsg reports the outcome of a native status change. TaskIDs
+// lists the tasks that were updated before any error occurred.
+type TaskStatusUpdatedMsg struct {
+	TaskIDs []string
+	Status  string
+	Err     error
+}
+
+// TaskEditedMsg reports the outcome of saving the task editor. Path is the
+// external editor's file, removed once the edit is saved.
+type TaskEditedMsg struct {
+	TaskID string
+	Path   string
+	Err    error
+}
+
+// TaskMarkdownEditedMsg carries the changes made to a task in an external
+// editor. Path is the edited file, kept until the changes are saved or
+// discarded.
+type TaskMarkdownEditedMsg struct {
+	TaskID  string
+	Update  taskmaster.TaskUpdate
+	Changes []taskmaster.FieldChange
+	Path    string
+	Err     error
+}
+
+// BulkAppliedMsg reports the outcome of a bulk action. Err with a nil Result
+// means nothing was changed; with a Result, the change was saved but the
+// tasks could not be reloaded.
+type BulkAppliedMsg struct {
+	Operation taskmaster.BulkOperation
+	Result    *taskmaster.BulkResult
+	Err       error
+}
+
+// LinkOpenedMsg reports whether a URL from the details panel was handed to
+// the system browser.
+type LinkOpenedMsg struct {
+	URL string
+	Err error
+}
+
+// TimerUpdatedMsg reports a timer start or stop. Stopped is the entry whose
+// time was recorded when a running timer ended, including idle stops.
+type TimerUpdatedMsg struct {
+	Started string
+	Stopped *timetrack.Entry
+	Err     error
+}
+
+// TimesheetExportedMsg is sent when a timesheet export finishes.
+type TimesheetExportedMsg struct {
+	FilePath string
+	Err      error
+}
+
+// HistoryMovedMsg reports the outcome of an undo, redo or history jump.
+// Description says what changed, e.g. "Undid: Set task 3 to done".
+type HistoryMovedMsg struct {
+	Description string
+	Err         error
+}
+
+// MergeResolvedMsg reports the outcome of resolving a merge conflict with
+// changes made to tasks.json outside the TUI.
+type MergeResolvedMsg struct {
+	Resolution taskmaster.MergeResolution
+	Err        error
+}
+
 // TagOperationMsg reports the outcome of a CLI-driven tag command.
 type TagOperationMsg struct {
 	Operation string
@@ -160,8 +232,8 @@ type ComplexityFilterAppliedMsg struct {
 
 // ComplexityExportRequestMsg is sent when export is requested
 type ComplexityExportRequestMsg struct {
-	Format string // "json", "csv"
-	Path   string // Output path
+	Format string // "json", "csv", "markdown"
+	Path   string // Output file, relative paths resolve against the project root
 }
 
 // ComplexityExportCompletedMsg is sent when export is complete
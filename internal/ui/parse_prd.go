@@ -22,8 +22,13 @@ const (
 	parsePrdModeReplace = taskmaster.ParsePrdModeReplace
 )
 
+// parsePrdParserOffline selects the local prd parser instead of the
+// AI-backed task-master parse-prd command.
+const parsePrdParserOffline = "offline"
+
 type parsePrdOptions struct {
-	Mode parsePrdMode
+	Mode    parsePrdMode
+	Offline bool
 }
 
 type parsePrdResultMsg struct {
@@ -36,6 +41,15 @@ type parsePrdResultMsg struct {
 
 type parsePrdStreamClosedMsg struct{}
 
+// prdOfflineParsedMsg carries the hierarchy read from a PRD by the local
+// parser, before anything is written.
+type prdOfflineParsedMsg struct {
+	Nodes []*prd.Node
+	Mode  parsePrdMode
+	Path  string
+	Err   error
+}
+
 type prdResultListItem struct {
 	title       string
 	description string
@@ -53,7 +67,7 @@ func (m *Model) openParsePrdWorkflow() tea.Cmd {
 	}
 
 	startDir := m.defaultPrdDirectory()
-	fileDialog := dialog.NewFileSelectionDialog("Select PRD File", startDir, 78, 20, []string{".md", ".txt"})
+	fileDialog := dialog.NewFileSelectionDialog("Select PRD File", startDir, 78, 20, prd.Extensions)
 	if dm.Style != nil {
 		dialog.ApplyStyleToDialog(fileDialog, dm.Style)
 	}
@@ -102,6 +116,16 @@ func (m *Model) showParsePrdOptions(path string) tea.Cmd {
 			},
 			Value: string(parsePrdModeAppend),
 		},
+		{
+			ID:    "parser",
+			Label: "Parser",
+			Type:  dialog.FormFieldTypeRadio,
+			Options: []dialog.FormOption{
+				{Value: "ai", Label: "Task Master AI", Description: "Run task-master parse-prd (needs an API key)"},
+				{Value: parsePrdParserOffline, Label: "Offline", Description: "Build tasks from headings and bullets, then preview and edit them"},
+			},
+			Value: "ai",
+		},
 	}
 
 	title := fmt.Sprintf("File: %s", filepath.Base(path))
@@ -119,7 +143,7 @@ func (m *Model) showParsePrdOptions(path string) tea.Cmd {
 			if mode != parsePrdModeReplace {
 				mode = parsePrdModeAppend
 			}
-			return parsePrdOptions{Mode: mode}, nil
+			return parsePrdOptions{Mode: mode, Offline: stringValue(values, "parser") == parsePrdParserOffline}, nil
 		},
 	)
 
@@ -139,6 +163,9 @@ func (m *Model) showParsePrdOptions(path string) tea.Cmd {
 		if !ok {
 			return nil
 		}
+		if opts.Offline {
+			return parsePrdOfflineCmd(path, opts.Mode)
+		}
 		return m.startParsePrdJob(path, opts.Mode)
 	})
 
@@ -230,7 +257,7 @@ func (m *Model) startParsePrdJob(path string, mode parsePrdMode) tea.Cmd {
 			send(parsePrdResultMsg{Err: readErr, Path: absPath})
 			return
 		}
-		nodes, parseErr := prd.Parse(string(data))
+		nodes, parseErr := prd.ParseFile(absPath, string(data))
 		if parseErr != nil {
 			send(parsePrdResultMsg{Err: parseErr, Path: absPath})
 			return
@@ -267,6 +294,9 @@ func (m *Model) handleParsePrdResult(msg parsePrdResultMsg) tea.Cmd {
 	m.dismissActiveProgressDialog()
 
 	if msg.Err != nil {
+		if m.showMergeConflict(msg.Err) {
+			return nil
+		}
 		appErr := NewParsingError("Parse PRD", fmt.Sprintf("Failed to parse %s", filepath.Base(msg.Path)), msg.Err).
 			WithDetails("The file may be malformed or unsupported.").
 			WithRecoveryHints(
@@ -302,15 +332,7 @@ func (m *Model) showParsePrdResults(msg parsePrdResultMsg) tea.Cmd {
 
 	items := make([]dialog.ListItem, 0, len(msg.Summaries))
 	for i, summary := range msg.Summaries {
-		descParts := []string{}
-		if summary.Description != "" {
-			descParts = append(descParts, summary.Description)
-		}
-		if summary.SubtaskCount > 0 {
-			descParts = append(descParts, fmt.Sprintf("%d subtasks", summary.SubtaskCount))
-		}
-		description := strings.Join(descParts, " · ")
-		items = append(items, &prdResultListItem{title: summary.Title, description: description, taskID: safeSliceValue(msg.TaskIDs, i)})
+		items = append(items, &prdResultListItem{title: summary.Title, description: prdSummaryDescription(summary), taskID: safeSliceValue(msg.TaskIDs, i)})
 	}
 
 	resultsDialog := dialog.NewListDialog("PRD Parse Results", 80, 20, items)
@@ -322,6 +344,167 @@ func (m *Model) showParsePrdResults(msg parsePrdResultMsg) tea.Cmd {
 	return nil
 }
 
+// parsePrdOfflineCmd reads and parses a PRD with the local parser.
+func parsePrdOfflineCmd(path string, mode parsePrdMode) tea.Cmd {
+	return func() tea.Msg {
+		absPath := path
+		if resolved, err := filepath.Abs(path); err == nil {
+			absPath = resolved
+		}
+		data, err := os.ReadFile(absPath)
+		if err != nil {
+			return prdOfflineParsedMsg{Path: absPath, Err: err}
+		}
+		nodes, err := prd.ParseFile(absPath, string(data))
+		// Anchor the tasks to the sections as parsed, before any edits
+		prd.MarkSections(nodes)
+		return prdOfflineParsedMsg{Nodes: nodes, Mode: mode, Path: absPath, Err: err}
+	}
+}
+
+// handlePrdOfflineParsed previews the tasks the local parser found; nothing
+// is written until they are confirmed in the editor that follows.
+func (m *Model) handlePrdOfflineParsed(msg prdOfflineParsedMsg) tea.Cmd {
+	if msg.Err != nil {
+		return m.handleParsePrdResult(parsePrdResultMsg{Path: msg.Path, Err: msg.Err})
+	}
+	dm := m.dialogManager()
+	if dm == nil {
+		return nil
+	}
+
+	startID := 1
+	if msg.Mode == parsePrdModeAppend {
+		startID = m.nextRootTaskID()
+	}
+	items := make([]dialog.ListItem, 0, len(msg.Nodes))
+	for i, summary := range prd.Summaries(msg.Nodes) {
+		items = append(items, &prdResultListItem{
+			title:       fmt.Sprintf("%d. %s", startID+i, summary.Title),
+			description: prdSummaryDescription(summary),
+			taskID:      strconv.Itoa(startID + i),
+		})
+	}
+
+	preview := dialog.NewListDialog(fmt.Sprintf("Offline PRD Preview: %s", filepath.Base(msg.Path)), 80, 20, items)
+	preview.SetShowDescription(true)
+	preview.SetFooterHints(
+		dialog.ShortcutHint{Key: "↑/↓", Label: "Navigate"},
+		dialog.ShortcutHint{Key: "Enter", Label: "Edit"},
+		dialog.ShortcutHint{Key: "Esc", Label: "Cancel"},
+	)
+	if dm.Style != nil {
+		dialog.ApplyStyleToDialog(preview, dm.Style)
+	}
+	m.appState.AddDialog(preview, func(value interface{}, err error) tea.Cmd {
+		if err != nil || value == nil {
+			return nil
+		}
+		return m.showOfflinePrdEditor(msg)
+	})
+	return nil
+}
+
+// showOfflinePrdEditor opens the expand edit dialog on the parsed tasks and
+// writes them once confirmed.
+func (m *Model) showOfflinePrdEditor(msg prdOfflineParsedMsg) tea.Cmd {
+	dm := m.dialogManager()
+	if dm == nil {
+		return nil
+	}
+
+	editor := dialog.NewSubtaskEditDialog(prdNodesToDrafts(msg.Nodes), dm.Style)
+	editor.SetTitle("Edit Parsed Tasks",
+		fmt.Sprintf("Edit the top-level tasks before they are written (%s). Keys: a=add, d=delete, e=edit, ↑/↓=navigate, Enter=write, Esc=cancel", msg.Mode))
+	m.appState.AddDialog(editor, func(value interface{}, err error) tea.Cmd {
+		if err != nil {
+			m.showErrorDialog("Parse PRD", err.Error())
+			return nil
+		}
+		drafts, ok := value.([]taskmaster.SubtaskDraft)
+		if !ok {
+			return nil
+		}
+		if len(drafts) == 0 {
+			m.addLogLine("Offline PRD import cancelled: no tasks left to write")
+			return nil
+		}
+		return m.importOfflinePrd(msg.Path, msg.Mode, draftsToPrdNodes(drafts))
+	})
+	return editor.Init()
+}
+
+// importOfflinePrd writes the edited tasks natively through the task service.
+func (m *Model) importOfflinePrd(path string, mode parsePrdMode, nodes []*prd.Node) tea.Cmd {
+	if m.taskService == nil {
+		m.showErrorDialog("Parse PRD", "Task service is not available.")
+		return nil
+	}
+	svc := m.taskService
+	return func() tea.Msg {
+		ids, err := svc.ImportPRD(context.Background(), path, nodes, mode)
+		if err != nil {
+			return parsePrdResultMsg{Path: path, Err: err}
+		}
+		return parsePrdResultMsg{Summaries: prd.Summaries(nodes), TaskIDs: ids, Mode: mode, Path: path}
+	}
+}
+
+// prdNodesToDrafts converts parsed PRD nodes to drafts for the edit dialog.
+func prdNodesToDrafts(nodes []*prd.Node) []taskmaster.SubtaskDraft {
+	drafts := make([]taskmaster.SubtaskDraft, len(nodes))
+	for i, node := range nodes {
+		drafts[i] = taskmaster.SubtaskDraft{
+			Title:        node.Title,
+			Description:  node.Description,
+			Status:       node.Status,
+			Priority:     node.Priority,
+			TestStrategy: node.TestStrategy,
+			DependsOn:    node.DependsOn,
+			Section:      node.Section,
+			Hash:         node.Hash,
+			Children:     prdNodesToDrafts(node.Children),
+		}
+	}
+	return drafts
+}
+
+// draftsToPrdNodes converts edited drafts back to PRD nodes for writing.
+func draftsToPrdNodes(drafts []taskmaster.SubtaskDraft) []*prd.Node {
+	nodes := make([]*prd.Node, len(drafts))
+	for i, draft := range drafts {
+		nodes[i] = &prd.Node{
+			Title:        draft.Title,
+			Description:  draft.Description,
+			Status:       draft.Status,
+			Priority:     draft.Priority,
+			TestStrategy: draft.TestStrategy,
+			DependsOn:    draft.DependsOn,
+			Section:      draft.Section,
+			Hash:         draft.Hash,
+			Children:     draftsToPrdNodes(draft.Children),
+		}
+	}
+	return nodes
+}
+
+func prdSummaryDescription(summary prd.Summary) string {
+	descParts := []string{}
+	if summary.Priority != "" {
+		descParts = append(descParts, summary.Priority)
+	}
+	if summary.Status != "" {
+		descParts = append(descParts, summary.Status)
+	}
+	if summary.Description != "" {
+		descParts = append(descParts, summary.Description)
+	}
+	if summary.SubtaskCount > 0 {
+		descParts = append(descParts, fmt.Sprintf("%d subtasks", summary.SubtaskCount))
+	}
+	return strings.Join(descParts, " · ")
+}
+
 func safeSliceValue(values []string, index int) string {
 	if index >= 0 && index < len(values) {
 		return values[index]
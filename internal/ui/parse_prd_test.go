@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestOfflinePrdPreviewEditAndImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prd.md")
+	content := "# Auth\nLogin flow\n- Sign in\n- Sign out\n\n# Billing\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write PRD: %v", err)
+	}
+
+	model := newTestModel()
+	svc := mockTaskService()
+	model.taskService = svc
+	model.tasks = svc.tasks
+	model.buildTaskIndex()
+
+	parsed, ok := parsePrdOfflineCmd(path, parsePrdModeAppend)().(prdOfflineParsedMsg)
+	if !ok || parsed.Err != nil || len(parsed.Nodes) != 2 {
+		t.Fatalf("expected 2 parsed tasks, got %+v", parsed)
+	}
+
+	model.handlePrdOfflineParsed(parsed)
+	preview, ok := model.appState.ActiveDialog().(*dialog.ListDialog)
+	if !ok || !strings.HasPrefix(preview.Title(), "Offline PRD Preview") {
+		t.Fatalf("expected offline preview, got %T", model.appState.ActiveDialog())
+	}
+
+	model.appState.ClearDialogs()
+	model.showOfflinePrdEditor(parsed)
+	editor, ok := model.appState.ActiveDialog().(*dialog.SubtaskEditDialog)
+	if !ok || len(editor.GetDrafts()) != 2 || len(editor.GetDrafts()[0].Children) != 2 {
+		t.Fatalf("expected the parsed hierarchy in the editor, got %T", model.appState.ActiveDialog())
+	}
+	if signOut := editor.GetDrafts()[0].Children[1]; signOut.Section != "Auth > Sign out" || signOut.Hash == "" {
+		t.Fatalf("expected drafts to carry their parsed section, got %+v", signOut)
+	}
+
+	// Drop Billing, then confirm.
+	model.appState.HandleDialogMsg(tea.KeyMsg{Type: tea.KeyDown})
+	model.appState.HandleDialogMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	cmd := model.appState.HandleDialogMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.appState.HasActiveDialog() || cmd == nil {
+		t.Fatalf("expected Enter to close the editor and import")
+	}
+
+	result, ok := findMsg[parsePrdResultMsg](cmd)
+	if !ok || result.Err != nil {
+		t.Fatalf("expected parsePrdResultMsg, got %+v", result)
+	}
+	if len(result.TaskIDs) != 1 || result.Summaries[0].Title != "Auth" || result.Summaries[0].SubtaskCount != 2 {
+		t.Fatalf("expected only Auth imported with its subtasks, got %+v", result)
+	}
+	if task, ok := svc.GetTaskByID(result.TaskIDs[0]); !ok || task.Title != "Auth" {
+		t.Fatalf("expected Auth written through the service, got %+v", task)
+	}
+}
+
+// findMsg runs cmd, descending into batches, and returns the first message of
+// type T.
+func findMsg[T tea.Msg](cmd tea.Cmd) (T, bool) {
+	var zero T
+	if cmd == nil {
+		return zero, false
+	}
+	switch msg := cmd().(type) {
+	case T:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if found, ok := findMsg[T](c); ok {
+				return found, true
+			}
+		}
+	}
+	return zero, false
+}
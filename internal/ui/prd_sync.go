@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/prd"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prdSyncPlannedMsg carries the changes a revised PRD proposes for the tasks
+// generated from it, before anything is written.
+type prdSyncPlannedMsg struct {
+	Plan *taskmaster.PRDSyncPlan
+	Path string
+	Err  error
+}
+
+// prdSyncAppliedMsg reports the outcome of writing the accepted changes.
+type prdSyncAppliedMsg struct {
+	Path    string
+	Changes []taskmaster.PRDSyncChange
+	Err     error
+}
+
+// prdSyncListItem adapts a proposed change to a dialog.ListItem.
+type prdSyncListItem struct {
+	change taskmaster.PRDSyncChange
+}
+
+func (i *prdSyncListItem) Title() string       { return i.change.Describe() }
+func (i *prdSyncListItem) Description() string { return prdSyncChangeDescription(i.change) }
+func (i *prdSyncListItem) FilterValue() string { return i.change.Title }
+
+// openPrdSyncWorkflow asks for a revised PRD and compares it with the tasks
+// generated from it.
+func (m *Model) openPrdSyncWorkflow() tea.Cmd {
+	if m.taskService == nil {
+		m.showErrorDialog("Re-sync PRD", "Task service is not available.")
+		return nil
+	}
+	dm := m.dialogManager()
+	if dm == nil {
+		return nil
+	}
+
+	fileDialog := dialog.NewFileSelectionDialog("Select Revised PRD", m.defaultPrdDirectory(), 78, 20, prd.Extensions)
+	if dm.Style != nil {
+		dialog.ApplyStyleToDialog(fileDialog, dm.Style)
+	}
+
+	initCmd := fileDialog.Init()
+	m.appState.AddDialog(fileDialog, func(value interface{}, err error) tea.Cmd {
+		if err != nil {
+			appErr := NewIOError("File Selection", "Failed to select file", err).
+				WithRecoveryHints(
+					"Check file permissions",
+					"Try selecting a different file",
+				)
+			m.showAppError(appErr)
+			return nil
+		}
+		path, _ := value.(string)
+		if path == "" {
+			return nil
+		}
+		m.lastPrdPath = filepath.Dir(path)
+		return planPrdSyncCmd(m.taskService, path)
+	})
+
+	return initCmd
+}
+
+// planPrdSyncCmd parses the PRD at path with the local parser and diffs it
+// against the tasks anchored to it.
+func planPrdSyncCmd(svc TaskService, path string) tea.Cmd {
+	return func() tea.Msg {
+		absPath := path
+		if resolved, err := filepath.Abs(path); err == nil {
+			absPath = resolved
+		}
+		data, err := os.ReadFile(absPath)
+		if err != nil {
+			return prdSyncPlannedMsg{Path: absPath, Err: err}
+		}
+		nodes, err := prd.ParseFile(absPath, string(data))
+		if err != nil {
+			return prdSyncPlannedMsg{Path: absPath, Err: err}
+		}
+		plan, err := svc.PlanPRDSync(absPath, nodes)
+		return prdSyncPlannedMsg{Plan: plan, Path: absPath, Err: err}
+	}
+}
+
+// handlePrdSyncPlanned shows the proposed changes for review. Adds and
+// updates start selected; orphans must be picked to be cancelled.
+func (m *Model) handlePrdSyncPlanned(msg prdSyncPlannedMsg) tea.Cmd {
+	if msg.Err != nil {
+		appErr := NewParsingError("Re-sync PRD", fmt.Sprintf("Failed to compare %s with its tasks", filepath.Base(msg.Path)), msg.Err).
+			WithRecoveryHints(
+				"Re-sync only PRDs parsed with the offline parser",
+				"Check the file format and encoding",
+			)
+		m.showAppError(appErr)
+		return nil
+	}
+	if len(msg.Plan.Changes) == 0 {
+		m.addLogLine(fmt.Sprintf("%s is in sync with its tasks", filepath.Base(msg.Path)))
+		return nil
+	}
+	dm := m.dialogManager()
+	if dm == nil {
+		return nil
+	}
+
+	items := make([]dialog.ListItem, len(msg.Plan.Changes))
+	for i, change := range msg.Plan.Changes {
+		items[i] = &prdSyncListItem{change: change}
+	}
+	review := dialog.NewListDialog(fmt.Sprintf("Re-sync PRD: %s", filepath.Base(msg.Path)), 90, 22, items)
+	review.SetShowDescription(true)
+	review.SetMultiSelect(true)
+	for i, change := range msg.Plan.Changes {
+		review.SetItemSelected(i, change.Kind != taskmaster.PRDSyncOrphan)
+	}
+	review.SetFooterHints(
+		dialog.ShortcutHint{Key: "↑/↓", Label: "Navigate"},
+		dialog.ShortcutHint{Key: "Space", Label: "Toggle"},
+		dialog.ShortcutHint{Key: "Enter", Label: "Apply"},
+		dialog.ShortcutHint{Key: "Esc", Label: "Cancel"},
+	)
+	if dm.Style != nil {
+		dialog.ApplyStyleToDialog(review, dm.Style)
+	}
+	m.appState.AddDialog(review, func(value interface{}, err error) tea.Cmd {
+		if err != nil {
+			return nil
+		}
+		selection, ok := value.(dialog.ListSelectionMsg)
+		if !ok {
+			return nil
+		}
+		indices := append([]int(nil), selection.SelectedItems...)
+		sort.Ints(indices)
+		var accepted []taskmaster.PRDSyncChange
+		for _, i := range indices {
+			if i >= 0 && i < len(msg.Plan.Changes) {
+				accepted = append(accepted, msg.Plan.Changes[i])
+			}
+		}
+		if len(accepted) == 0 {
+			m.addLogLine("PRD re-sync cancelled: no changes selected")
+			return nil
+		}
+		return m.applyPrdSync(msg.Path, msg.Plan, accepted)
+	})
+	return nil
+}
+
+// applyPrdSync writes the accepted changes through the task service.
+func (m *Model) applyPrdSync(path string, plan *taskmaster.PRDSyncPlan, changes []taskmaster.PRDSyncChange) tea.Cmd {
+	svc := m.taskService
+	return func() tea.Msg {
+		err := svc.ApplyPRDSync(context.Background(), plan, changes)
+		return prdSyncAppliedMsg{Path: path, Changes: changes, Err: err}
+	}
+}
+
+// handlePrdSyncApplied logs what the re-sync changed and reloads the tasks.
+func (m *Model) handlePrdSyncApplied(msg prdSyncAppliedMsg) tea.Cmd {
+	if msg.Err != nil {
+		if !m.showMergeConflict(msg.Err) {
+			appErr := NewOperationError("Re-sync PRD", fmt.Sprintf("Failed to re-sync %s", filepath.Base(msg.Path)), msg.Err).
+				WithRecoveryHints(
+					"Check that tasks.json is writable",
+					"Reload tasks and re-sync again",
+				)
+			m.showAppError(appErr)
+		}
+		return nil
+	}
+
+	counts := make(map[taskmaster.PRDSyncKind]int)
+	for _, change := range msg.Changes {
+		counts[change.Kind]++
+	}
+	m.addLogLine(fmt.Sprintf("Re-synced %s: %d added, %d updated, %d cancelled",
+		filepath.Base(msg.Path), counts[taskmaster.PRDSyncAdd], counts[taskmaster.PRDSyncUpdate], counts[taskmaster.PRDSyncOrphan]))
+	return LoadTasksCmd(m.taskService)
+}
+
+// prdSyncChangeDescription explains a proposed change in one line.
+func prdSyncChangeDescription(change taskmaster.PRDSyncChange) string {
+	switch change.Kind {
+	case taskmaster.PRDSyncAdd:
+		parts := []string{"new section " + change.Section}
+		if n := len(change.Node.Children); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d subtasks", n))
+		}
+		return strings.Join(parts, " · ")
+	case taskmaster.PRDSyncUpdate:
+		parts := make([]string, len(change.Fields))
+		for i, field := range change.Fields {
+			parts[i] = fmt.Sprintf("%s: %q → %q", field.Field, snippetText(field.Old, 30), snippetText(field.New, 30))
+		}
+		return strings.Join(parts, " · ")
+	case taskmaster.PRDSyncOrphan:
+		return fmt.Sprintf("section %s was removed; select to cancel the task", change.Section)
+	}
+	return ""
+}
+
+// snippetText shortens text to max runes on a single line.
+func snippetText(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > max {
+		return string(runes[:max-1]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/prd"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPrdSyncReviewAppliesSelectedChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prd.md")
+	if err := os.WriteFile(path, []byte("# Auth\nLogin and logout\n\n# Search\n"), 0644); err != nil {
+		t.Fatalf("failed to write PRD: %v", err)
+	}
+
+	model := newTestModel()
+	svc := mockTaskService()
+	svc.prdSync = &taskmaster.PRDSyncPlan{Source: "prd.md", Changes: []taskmaster.PRDSyncChange{
+		{Kind: taskmaster.PRDSyncAdd, Section: "Search", Title: "Search", Node: &prd.Node{Title: "Search"}},
+		{Kind: taskmaster.PRDSyncUpdate, TaskID: "1", Section: "Auth", Title: "Auth",
+			Fields: []taskmaster.FieldChange{{Field: "description", Old: "Login", New: "Login and logout"}}},
+		{Kind: taskmaster.PRDSyncOrphan, TaskID: "2", Section: "Reports", Title: "Reports"},
+	}}
+	model.taskService = svc
+
+	planned, ok := planPrdSyncCmd(svc, path)().(prdSyncPlannedMsg)
+	if !ok || planned.Err != nil || planned.Plan != svc.prdSync {
+		t.Fatalf("expected the service plan, got %+v", planned)
+	}
+
+	model.handlePrdSyncPlanned(planned)
+	review, ok := model.appState.ActiveDialog().(*dialog.ListDialog)
+	if !ok || !strings.HasPrefix(review.Title(), "Re-sync PRD") {
+		t.Fatalf("expected the re-sync review, got %T", model.appState.ActiveDialog())
+	}
+	if selected := review.SelectedItems(); len(selected) != 2 {
+		t.Fatalf("expected adds and updates preselected, got %d", len(selected))
+	}
+	if desc := (&prdSyncListItem{change: svc.prdSync.Changes[1]}).Description(); desc != `description: "Login" → "Login and logout"` {
+		t.Fatalf("unexpected update description %q", desc)
+	}
+
+	// Accept the orphan and skip the update.
+	model.appState.HandleDialogMsg(tea.KeyMsg{Type: tea.KeyDown})
+	model.appState.HandleDialogMsg(tea.KeyMsg{Type: tea.KeySpace})
+	model.appState.HandleDialogMsg(tea.KeyMsg{Type: tea.KeyDown})
+	model.appState.HandleDialogMsg(tea.KeyMsg{Type: tea.KeySpace})
+	cmd := model.appState.HandleDialogMsg(tea.KeyMsg{Type: tea.KeyEnter})
+
+	applied, ok := findMsg[prdSyncAppliedMsg](cmd)
+	if !ok || applied.Err != nil {
+		t.Fatalf("expected prdSyncAppliedMsg, got %+v", applied)
+	}
+	if len(svc.prdSynced) != 2 || svc.prdSynced[0].Kind != taskmaster.PRDSyncAdd || svc.prdSynced[1].Kind != taskmaster.PRDSyncOrphan {
+		t.Fatalf("expected the add and the orphan applied in order, got %+v", svc.prdSynced)
+	}
+}
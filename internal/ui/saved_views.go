@@ -0,0 +1,248 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// viewModeName returns the name a view mode is persisted under.
+func viewModeName(mode ViewMode) string {
+	switch mode {
+	case ViewModeList:
+		return "list"
+	case ViewModeKanban:
+		return "kanban"
+	case ViewModeGraph:
+		return "graph"
+	default:
+		return "tree"
+	}
+}
+
+// parseViewMode is the inverse of viewModeName, defaulting to the tree view.
+func parseViewMode(name string) ViewMode {
+	switch name {
+	case "list":
+		return ViewModeList
+	case "kanban":
+		return ViewModeKanban
+	case "graph":
+		return ViewModeGraph
+	default:
+		return ViewModeTree
+	}
+}
+
+// currentView captures the task list filters and layout as a saved view.
+func (m *Model) currentView(name string) config.SavedView {
+	var expanded []string
+	for id, open := range m.expandedNodes {
+		if open {
+			expanded = append(expanded, id)
+		}
+	}
+	sort.Strings(expanded)
+	return config.SavedView{
+		Name:         name,
+		Query:        m.searchQuery,
+		StatusFilter: m.statusFilter,
+		ViewMode:     viewModeName(m.viewMode),
+		SortMode:     string(m.sortMode),
+		ExpandedIDs:  expanded,
+	}
+}
+
+// applySavedView restores the filters and layout of a saved view, keeping the
+// selected task when it is still visible.
+func (m *Model) applySavedView(view config.SavedView) {
+	selectedID := ""
+	if m.selectedTask != nil {
+		selectedID = m.selectedTask.ID
+	}
+
+	m.setSearchQuery(view.Query)
+	m.searchInput.SetValue(view.Query)
+	m.statusFilter = view.StatusFilter
+	m.viewMode = parseViewMode(view.ViewMode)
+	m.sortMode, _ = taskmaster.ParseSortMode(view.SortMode)
+	m.graphPrevID = ""
+	m.expandedNodes = make(map[string]bool, len(view.ExpandedIDs))
+	for _, id := range view.ExpandedIDs {
+		m.expandedNodes[id] = true
+	}
+	m.activeView = view.Name
+
+	m.rebuildVisibleTasks()
+	if !m.searchFilter.IsEmpty() || m.statusFilter != "" {
+		m.visibleTasks = nil
+		m.updateFilteredTasks()
+	} else {
+		m.searchResults = nil
+	}
+	if selectedID != "" {
+		m.ensureTaskSelected(selectedID)
+	}
+	m.syncKanbanColumn()
+	m.updateTaskListViewport()
+	m.updateDetailsViewport()
+}
+
+// saveView stores the current layout under name and persists the state file.
+func (m *Model) saveView(request dialog.SaveViewRequest) {
+	m.savedViews.Put(m.currentView(request.Name))
+	if request.Default {
+		m.defaultView = request.Name
+	} else if strings.EqualFold(m.defaultView, request.Name) {
+		m.defaultView = ""
+	}
+	m.activeView = request.Name
+	if err := m.SaveUIState(); err != nil {
+		m.addLogLine(fmt.Sprintf("Failed to save view: %v", err))
+		return
+	}
+	m.addLogLine(fmt.Sprintf("Saved view %q", request.Name))
+}
+
+// deleteView removes a saved view and persists the state file.
+func (m *Model) deleteView(name string) {
+	if !m.savedViews.Delete(name) {
+		return
+	}
+	if strings.EqualFold(m.defaultView, name) {
+		m.defaultView = ""
+	}
+	if strings.EqualFold(m.activeView, name) {
+		m.activeView = ""
+	}
+	if err := m.SaveUIState(); err != nil {
+		m.addLogLine(fmt.Sprintf("Failed to delete view: %v", err))
+		return
+	}
+	m.addLogLine(fmt.Sprintf("Deleted view %q", name))
+}
+
+// showSaveViewDialog asks for a name to save the current layout under,
+// prefilled with the active view.
+func (m *Model) showSaveViewDialog() {
+	dm := m.dialogManager()
+	if dm == nil {
+		return
+	}
+
+	isDefault := m.activeView != "" && strings.EqualFold(m.activeView, m.defaultView)
+	saveDialog := dialog.NewSaveViewDialog(m.activeView, isDefault, dm.Style)
+	m.appState.AddDialog(saveDialog, func(value interface{}, err error) tea.Cmd {
+		request, ok := value.(dialog.SaveViewRequest)
+		if err != nil || !ok || request.Name == "" {
+			return nil
+		}
+		m.saveView(request)
+		return nil
+	})
+}
+
+// showSavedViewsDialog lists saved views; choosing one applies it. With no
+// saved views it opens the save dialog instead.
+func (m *Model) showSavedViewsDialog() {
+	if len(m.savedViews) == 0 {
+		m.addLogLine("No saved views yet - save the current layout first")
+		m.showSaveViewDialog()
+		return
+	}
+	m.showViewListDialog("Saved Views", "Apply", func(view config.SavedView) {
+		m.applySavedView(view)
+		m.addLogLine(fmt.Sprintf("Switched to view %q", view.Name))
+	})
+}
+
+// showDeleteViewDialog lists saved views; choosing one deletes it.
+func (m *Model) showDeleteViewDialog() {
+	if len(m.savedViews) == 0 {
+		m.addLogLine("No saved views to delete")
+		return
+	}
+	m.showViewListDialog("Delete View", "Delete", func(view config.SavedView) {
+		m.deleteView(view.Name)
+	})
+}
+
+func (m *Model) showViewListDialog(title, action string, onSelect func(view config.SavedView)) {
+	dm := m.dialogManager()
+	if dm == nil {
+		return
+	}
+
+	items := make([]dialog.ListItem, 0, len(m.savedViews))
+	for _, view := range m.savedViews {
+		items = append(items, &savedViewItem{
+			view:      view,
+			isDefault: strings.EqualFold(view.Name, m.defaultView),
+			isActive:  strings.EqualFold(view.Name, m.activeView),
+		})
+	}
+
+	list := dialog.NewListDialog(title, 70, 16, items)
+	list.SetShowDescription(true)
+	list.SetFooterHints(
+		dialog.ShortcutHint{Key: "↑/↓", Label: "Navigate"},
+		dialog.ShortcutHint{Key: "Enter", Label: action},
+		dialog.ShortcutHint{Key: "Esc", Label: "Close"},
+	)
+	if dm.Style != nil {
+		dialog.ApplyStyleToDialog(list, dm.Style)
+	}
+
+	m.appState.AddDialog(list, func(value interface{}, err error) tea.Cmd {
+		if err != nil {
+			return nil
+		}
+		msg, ok := value.(dialog.ListSelectionMsg)
+		if !ok || msg.SelectedItem == nil {
+			return nil
+		}
+		if item, ok := msg.SelectedItem.(*savedViewItem); ok {
+			onSelect(item.view)
+		}
+		return nil
+	})
+}
+
+// savedViewItem adapts a saved view to a dialog.ListItem.
+type savedViewItem struct {
+	view      config.SavedView
+	isDefault bool
+	isActive  bool
+}
+
+func (i *savedViewItem) Title() string {
+	title := i.view.Name
+	if i.isActive {
+		title = "● " + title
+	}
+	if i.isDefault {
+		title += " (startup)"
+	}
+	return title
+}
+
+func (i *savedViewItem) Description() string {
+	parts := []string{i.view.ViewMode + " view"}
+	if mode, err := taskmaster.ParseSortMode(i.view.SortMode); err == nil && mode != taskmaster.SortFile {
+		parts = append(parts, "by "+mode.Label())
+	}
+	if i.view.StatusFilter != "" {
+		parts = append(parts, "status "+i.view.StatusFilter)
+	}
+	if i.view.Query != "" {
+		parts = append(parts, i.view.Query)
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (i *savedViewItem) FilterValue() string { return i.view.Name }
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+)
+
+func TestSavedViewPersistsAndAppliesAtStartup(t *testing.T) {
+	m := createTestModel()
+	m.config.StatePath = filepath.Join(t.TempDir(), "tui-state.json")
+
+	m.applyTaskQuery("status:pending")
+	m.viewMode = ViewModeList
+	m.expandedNodes["1"] = true
+	m.saveView(dialog.SaveViewRequest{Name: "Pending", Default: true})
+
+	m.applySavedView(config.SavedView{Name: "All", ViewMode: "tree"})
+	if m.searchQuery != "" || m.viewMode != ViewModeTree || m.expandedNodes["1"] {
+		t.Fatalf("expected plain tree view, got query %q mode %v", m.searchQuery, m.viewMode)
+	}
+
+	state, err := config.LoadState(m.config.StatePath)
+	if err != nil {
+		t.Fatalf("LoadState returned error: %v", err)
+	}
+	if state.DefaultView != "Pending" || len(state.SavedViews) != 1 {
+		t.Fatalf("expected Pending saved as default, got %+v", state)
+	}
+
+	restarted := createTestModel()
+	restarted.restoreUIState(state)
+	if restarted.searchQuery != "status:pending" || restarted.viewMode != ViewModeList || !restarted.expandedNodes["1"] {
+		t.Fatalf("expected default view applied at startup, got query %q mode %v", restarted.searchQuery, restarted.viewMode)
+	}
+	for _, task := range restarted.visibleTasks {
+		if task.Status != "pending" {
+			t.Fatalf("expected only pending tasks visible, got %s (%s)", task.ID, task.Status)
+		}
+	}
+
+	restarted.config.StatePath = m.config.StatePath
+	restarted.deleteView("pending")
+	if restarted.defaultView != "" || len(restarted.savedViews) != 0 {
+		t.Fatalf("expected view and default removed, got %q %+v", restarted.defaultView, restarted.savedViews)
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// setSearchQuery parses query for the task list filter. A syntax error is
+// kept in searchErr for inline display and leaves the last valid filter in
+// place, so the list does not flicker while a query is being typed.
+func (m *Model) setSearchQuery(query string) {
+	m.searchQuery = query
+	parsed, err := taskmaster.ParseQuery(query)
+	if err != nil {
+		m.searchErr = err
+		return
+	}
+	m.searchErr = nil
+	m.searchFilter = parsed
+}
+
+// showTaskQueryDialog edits the search query from the command palette.
+func (m *Model) showTaskQueryDialog() {
+	dm := m.dialogManager()
+	if dm == nil {
+		return
+	}
+
+	queryDialog := dialog.NewTaskQueryDialog(m.searchQuery, dm.Style)
+	m.appState.AddDialog(queryDialog, func(value interface{}, err error) tea.Cmd {
+		query, ok := value.(string)
+		if err != nil || !ok {
+			return nil
+		}
+		m.applyTaskQuery(query)
+		return nil
+	})
+}
+
+// applyTaskQuery filters the task list by a validated query and logs the
+// result.
+func (m *Model) applyTaskQuery(query string) {
+	m.setSearchQuery(query)
+	m.searchInput.SetValue(query)
+	m.visibleTasks = nil
+	m.updateFilteredTasks()
+	if query == "" {
+		m.addLogLine("Search cleared")
+		return
+	}
+	m.addLogLine(fmt.Sprintf("Found %d tasks matching '%s'", len(m.searchResults), query))
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSearchQueryFiltersAndReportsSyntaxErrors(t *testing.T) {
+	m := createTestModel()
+	m.searchMode = true
+
+	m.searchInput.SetValue("status:pending -id:=1")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.searchErr != nil || m.searchMode {
+		t.Fatalf("expected valid query to close search, got err %v", m.searchErr)
+	}
+	if len(m.visibleTasks) != 1 || m.visibleTasks[0].ID != "1.1" {
+		t.Fatalf("expected only subtask 1.1, got %d tasks", len(m.visibleTasks))
+	}
+	if tree := m.filterTaskTree(m.tasks); len(tree) != 1 || len(tree[0].Subtasks) != 1 {
+		t.Fatalf("expected parent kept with one matching subtask, got %+v", tree)
+	}
+
+	m.searchMode = true
+	m.searchInput.SetValue("status:finished")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.searchErr == nil || !m.searchMode {
+		t.Fatal("expected invalid query to stay in search mode with an error")
+	}
+	if len(m.visibleTasks) != 1 {
+		t.Fatalf("expected last valid filter kept, got %d tasks", len(m.visibleTasks))
+	}
+
+	m.applyTaskQuery("")
+	if m.searchErr != nil || !m.searchFilter.IsEmpty() {
+		t.Fatal("expected applying an empty query to clear the filter")
+	}
+}
+
+func TestSearchErrorKeepsHeaderAndStatusHeight(t *testing.T) {
+	m := createTestModel()
+	m.width = 60
+	m.searchMode = true
+
+	m.setSearchQuery("status:pending")
+	header, status := lipgloss.Height(m.renderHeader()), lipgloss.Height(m.renderStatusBar())
+
+	m.setSearchQuery("status:pending priority:urgent " + strings.Repeat("x", 80))
+	if m.searchErr == nil {
+		t.Fatal("expected an invalid query")
+	}
+	if got := lipgloss.Height(m.renderHeader()); got != header {
+		t.Errorf("expected header height %d with an error, got %d", header, got)
+	}
+	if got := lipgloss.Height(m.renderStatusBar()); got != status || got != 1 {
+		t.Errorf("expected a one-row status bar with an error, got %d rows", got)
+	}
+}
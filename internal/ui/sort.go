@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sortedTasks returns the task tree in display order. Sorting applies within
+// each level, so subtasks stay under their parents.
+func (m Model) sortedTasks() []taskmaster.Task {
+	return taskmaster.SortTaskTree(m.tasks, m.sortMode)
+}
+
+// sortStatus describes a non-default sort order for the status bar.
+func (m Model) sortStatus() string {
+	if m.sortMode == taskmaster.SortFile {
+		return ""
+	}
+	return "⇅ " + m.sortMode.Label()
+}
+
+// cycleSortMode switches to the next sort order.
+func (m *Model) cycleSortMode() {
+	next := taskmaster.SortModes[0]
+	for i, mode := range taskmaster.SortModes {
+		if mode == m.sortMode {
+			next = taskmaster.SortModes[(i+1)%len(taskmaster.SortModes)]
+			break
+		}
+	}
+	m.setSortMode(next)
+}
+
+// setSortMode reorders the task views, keeps the selected task selected and
+// persists the choice.
+func (m *Model) setSortMode(mode taskmaster.SortMode) {
+	selectedID := ""
+	if m.selectedTask != nil {
+		selectedID = m.selectedTask.ID
+	}
+
+	m.sortMode = mode
+	m.rebuildVisibleTasks()
+	if !m.searchFilter.IsEmpty() || m.statusFilter != "" {
+		m.visibleTasks = nil
+		m.updateFilteredTasks()
+	}
+	if selectedID != "" {
+		m.ensureTaskSelected(selectedID)
+	}
+	m.updateTaskListViewport()
+	m.addLogLine(fmt.Sprintf("Sorting by %s", mode.Label()))
+
+	if err := m.SaveUIState(); err != nil {
+		m.addLogLine(fmt.Sprintf("Failed to save sort order: %v", err))
+	}
+}
+
+// showSortDialog lists the sort orders; choosing one applies it.
+func (m *Model) showSortDialog() {
+	dm := m.dialogManager()
+	if dm == nil {
+		return
+	}
+
+	items := make([]dialog.ListItem, 0, len(taskmaster.SortModes))
+	for _, mode := range taskmaster.SortModes {
+		items = append(items, &sortModeItem{mode: mode, active: mode == m.sortMode})
+	}
+
+	list := dialog.NewListDialog("Sort Tasks", 60, 14, items)
+	list.SetShowDescription(true)
+	list.SetFooterHints(
+		dialog.ShortcutHint{Key: "↑/↓", Label: "Navigate"},
+		dialog.ShortcutHint{Key: "Enter", Label: "Sort"},
+		dialog.ShortcutHint{Key: "Esc", Label: "Close"},
+	)
+	if dm.Style != nil {
+		dialog.ApplyStyleToDialog(list, dm.Style)
+	}
+
+	m.appState.AddDialog(list, func(value interface{}, err error) tea.Cmd {
+		if err != nil {
+			return nil
+		}
+		msg, ok := value.(dialog.ListSelectionMsg)
+		if !ok || msg.SelectedItem == nil {
+			return nil
+		}
+		if item, ok := msg.SelectedItem.(*sortModeItem); ok {
+			m.setSortMode(item.mode)
+		}
+		return nil
+	})
+}
+
+// sortModeItem adapts a sort mode to a dialog.ListItem.
+type sortModeItem struct {
+	mode   taskmaster.SortMode
+	active bool
+}
+
+func (i *sortModeItem) Title() string {
+	title := i.mode.Label()
+	if i.active {
+		title = "● " + title
+	}
+	return title
+}
+
+func (i *sortModeItem) Description() string {
+	switch i.mode {
+	case taskmaster.SortPriority:
+		return "Critical first"
+	case taskmaster.SortStatus:
+		return "In progress, pending, blocked, deferred, done, cancelled"
+	case taskmaster.SortComplexity:
+		return "Most complex first"
+	case taskmaster.SortID:
+		return "Numeric, so 2 comes before 10"
+	case taskmaster.SortUpdated:
+		return "Most recently changed first"
+	case taskmaster.SortEstimate:
+		return "Largest estimate first"
+	default:
+		return "As stored in tasks.json"
+	}
+}
+
+func (i *sortModeItem) FilterValue() string { return string(i.mode) }
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func visibleIDs(m Model) []string {
+	ids := make([]string, len(m.visibleTasks))
+	for i, task := range m.visibleTasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func TestSortModeOrdersEachLevelAndPersists(t *testing.T) {
+	m := createTestModel()
+	m.config.StatePath = filepath.Join(t.TempDir(), "tui-state.json")
+	m.tasks[0].Subtasks[1].Status = taskmaster.StatusInProgress
+	m.buildTaskIndex()
+	m.expandedNodes["1"] = true
+	m.rebuildVisibleTasks()
+	m.ensureTaskSelected("1.1")
+
+	m.setSortMode(taskmaster.SortStatus)
+	want := []string{"2", "1", "1.2", "1.1"}
+	got := visibleIDs(m)
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if m.selectedTask == nil || m.selectedTask.ID != "1.1" {
+		t.Fatalf("expected selection kept on 1.1, got %+v", m.selectedTask)
+	}
+	if m.tasks[0].ID != "1" || m.tasks[0].Subtasks[0].ID != "1.1" {
+		t.Fatal("expected sorting to leave the loaded tasks in file order")
+	}
+
+	state, err := config.LoadState(m.config.StatePath)
+	if err != nil {
+		t.Fatalf("LoadState returned error: %v", err)
+	}
+	if state.SortMode != "status" {
+		t.Fatalf("expected sort mode persisted, got %q", state.SortMode)
+	}
+	restarted := createTestModel()
+	restarted.restoreUIState(state)
+	if got := visibleIDs(restarted); len(got) == 0 || got[0] != "2" {
+		t.Fatalf("expected restored status order, got %v", got)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m = updated.(Model)
+	if m.sortMode != taskmaster.SortComplexity {
+		t.Fatalf("expected o to cycle to complexity, got %q", m.sortMode)
+	}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// showTaskEditor opens the field editor for the selected task.
+func (m *Model) showTaskEditor() {
+	if m.selectedTask == nil {
+		appErr := NewValidationError("Edit Task", "No task selected.", nil).
+			WithRecoveryHints("Use arrow keys to select a task, then press E again")
+		m.showAppError(appErr)
+		return
+	}
+	dm := m.dialogManager()
+	if dm == nil || m.taskService == nil {
+		return
+	}
+
+	ids := make([]string, 0, len(m.taskIndex))
+	for id := range m.taskIndex {
+		ids = append(ids, id)
+	}
+	taskmaster.SortTaskIDs(ids)
+
+	taskID := m.selectedTask.ID
+	validate := func(update taskmaster.TaskUpdate) error {
+		return taskmaster.ValidateTaskUpdate(m.taskIndex, taskID, update)
+	}
+	editor := dialog.NewTaskEditorDialog(m.selectedTask, ids, validate, dm.Style)
+	m.appState.AddDialog(editor, func(value interface{}, err error) tea.Cmd {
+		request, ok := value.(dialog.TaskEditRequest)
+		if err != nil || !ok {
+			return nil
+		}
+		if request.Update.IsEmpty() {
+			m.addLogLine(fmt.Sprintf("No changes to task %s", request.TaskID))
+			return nil
+		}
+		return updateTaskFieldsCmd(m.taskService, request.TaskID, request.Update)
+	})
+}
+
+// updateTaskFieldsCmd saves a field update through the native write path.
+func updateTaskFieldsCmd(svc TaskService, taskID string, update taskmaster.TaskUpdate) tea.Cmd {
+	return func() tea.Msg {
+		_, err := svc.UpdateTaskFields(context.Background(), taskID, update)
+		return TaskEditedMsg{TaskID: taskID, Err: err}
+	}
+}
+
+// handleTaskEdited reports a saved edit and reloads the tasks.
+func (m *Model) handleTaskEdited(msg TaskEditedMsg) tea.Cmd {
+	if msg.Err != nil {
+		if msg.Path != "" {
+			m.addLogLine(fmt.Sprintf("Edits to task %s are kept in %s", msg.TaskID, msg.Path))
+		}
+		if !m.showMergeConflict(msg.Err) {
+			hints := []string{"Check that tasks.json is writable", "Reload tasks and try again"}
+			if msg.Path != "" {
+				hints = append(hints, fmt.Sprintf("Your edits are kept in %s", msg.Path))
+			}
+			appErr := NewOperationError("Edit Task", fmt.Sprintf("Failed to save task %s", msg.TaskID), msg.Err).
+				WithRecoveryHints(hints...)
+			m.showAppError(appErr)
+		}
+		return nil
+	}
+	if msg.Path != "" {
+		os.Remove(msg.Path)
+	}
+	m.addLogLine(fmt.Sprintf("✓ Saved changes to task %s", msg.TaskID))
+	return LoadTasksCmd(m.taskService)
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+)
+
+func TestEditTaskCommandSavesChangedFields(t *testing.T) {
+	model := newTestModel()
+	svc := mockTaskService()
+	model.taskService = svc
+	model.tasks = svc.tasks
+	model.buildTaskIndex()
+	model.selectedTask = model.taskIndex["2"]
+
+	model.dispatchCommand(CommandEditTask)
+	editor, ok := model.appState.ActiveDialog().(*dialog.FormDialog)
+	if !ok || editor.Title() != "Edit Task 2" {
+		t.Fatalf("expected task editor, got %T", model.appState.ActiveDialog())
+	}
+
+	title := "Renamed"
+	cmd := updateTaskFieldsCmd(svc, "2", taskmaster.TaskUpdate{Title: &title})
+	msg, ok := cmd().(TaskEditedMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("expected successful edit, got %+v", msg)
+	}
+	if task, _ := svc.GetTaskByID("2"); task.Title != title {
+		t.Fatalf("expected title saved, got %q", task.Title)
+	}
+	if model.handleTaskEdited(msg) == nil {
+		t.Fatal("expected tasks to be reloaded after saving")
+	}
+}
@@ -2,9 +2,14 @@ package ui
 
 import (
 	"context"
+	"time"
 
+	"github.com/agreen757/tm-tui/internal/config"
+	"github.com/agreen757/tm-tui/internal/git"
+	"github.com/agreen757/tm-tui/internal/prd"
 	"github.com/agreen757/tm-tui/internal/projects"
 	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/timetrack"
 )
 
 // TaskService defines the subset of taskmaster.Service behavior required by the UI.
@@ -12,19 +17,47 @@ type TaskService interface {
 	GetTasks() ([]taskmaster.Task, []string)
 	GetTaskFromCLI(taskID string) (*taskmaster.Task, error)
 	LoadTasks(ctx context.Context) error
+	SetTaskStatus(taskID, status string) error
+	UpdateTaskFields(ctx context.Context, taskID string, update taskmaster.TaskUpdate) (*taskmaster.Task, error)
+	ApplyBulk(ctx context.Context, taskIDs []string, op taskmaster.BulkOperation) (*taskmaster.BulkResult, error)
 	ReloadEvents() <-chan struct{}
 	AnalyzeComplexity(ctx context.Context, scope string, taskID string, tags []string) (*taskmaster.ComplexityReport, error)
 	AnalyzeComplexityWithProgress(ctx context.Context, scope string, taskID string, tags []string, onProgress func(taskmaster.ComplexityProgressState)) (*taskmaster.ComplexityReport, error)
 	ParsePRDWithProgress(ctx context.Context, inputPath string, mode taskmaster.ParsePrdMode, onProgress func(taskmaster.ParsePrdProgressState)) error
+	ImportPRD(ctx context.Context, source string, nodes []*prd.Node, mode taskmaster.ParsePrdMode) ([]string, error)
+	PlanPRDSync(source string, nodes []*prd.Node) (*taskmaster.PRDSyncPlan, error)
+	ApplyPRDSync(ctx context.Context, plan *taskmaster.PRDSyncPlan, changes []taskmaster.PRDSyncChange) error
 	ExpandTaskWithProgress(ctx context.Context, taskID string, opts taskmaster.ExpandTaskOptions, prompt string, onProgress func(taskmaster.ExpandProgressState)) error
+	ApplySubtaskDrafts(ctx context.Context, parentID string, drafts []taskmaster.SubtaskDraft) ([]string, error)
 	ExecuteExpandWithProgress(ctx context.Context, scope string, taskID string, fromID string, toID string, tags []string, opts taskmaster.ExpandTaskOptions, onProgress func(taskmaster.ExpandProgressState)) error
 	GetLatestComplexityReport() *taskmaster.ComplexityReport
 	ExportComplexityReport(ctx context.Context, format string, outputPath string) (string, error)
+	ComplexityTrend() (*taskmaster.ComplexityTrend, error)
+	ScoringProfile() taskmaster.ScoringProfile
+	SetComplexityConfig(cfg config.ComplexityConfig)
+	CalibrateComplexity() (*taskmaster.CalibrationResult, error)
+	SaveScoringWeights(weights taskmaster.ScoringWeights, forActiveTag bool) error
+	StartTimer(ctx context.Context, taskID string) (*timetrack.Entry, error)
+	StopTimer(ctx context.Context) (*timetrack.Entry, error)
+	CheckIdleTimer(ctx context.Context) (*timetrack.Entry, error)
+	TouchTimer()
+	ActiveTimer() *timetrack.Timer
+	ExportTimesheet(ctx context.Context, from, to time.Time, outputPath string) (string, error)
+	SwitchTaskBranch(ctx context.Context, taskID string) (string, bool, error)
+	GitActivity(ctx context.Context) (*git.Activity, error)
+	ApplyGitActivity(ctx context.Context, activity *git.Activity) ([]taskmaster.GitStatusChange, error)
 	IsAvailable() bool
 	AnalyzeDeleteImpact(taskIDs []string, opts taskmaster.DeleteOptions) (*taskmaster.DeleteImpact, error)
 	DeleteTasks(ctx context.Context, taskIDs []string, opts taskmaster.DeleteOptions) (*taskmaster.DeleteResult, error)
 	UndoAction(ctx context.Context, actionID string) error
+	Undo(ctx context.Context) (*taskmaster.UndoAction, error)
+	Redo(ctx context.Context) (*taskmaster.UndoAction, error)
+	UndoHistory() taskmaster.UndoHistory
+	RestoreHistory(ctx context.Context, actionID string) error
+	PendingMergeConflict() *taskmaster.MergeConflictError
+	ResolveMergeConflict(ctx context.Context, resolution taskmaster.MergeResolution) error
 	ListTagContexts(ctx context.Context, includeMetadata bool) (*taskmaster.TagList, error)
+	TagNames() []string
 	AddTagContext(ctx context.Context, opts taskmaster.TagAddOptions) (*taskmaster.TagOperationResult, error)
 	DeleteTagContext(ctx context.Context, name string, skipConfirmation bool) (*taskmaster.TagOperationResult, error)
 	UseTagContext(ctx context.Context, name string) (*taskmaster.TagOperationResult, error)
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/timetrack"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// timerCheckInterval is how often the running timer is checked for
+// idleness and its latest activity saved.
+const timerCheckInterval = 30 * time.Second
+
+// timerTickMsg triggers the periodic idle check.
+type timerTickMsg struct{}
+
+func timerTickCmd() tea.Cmd {
+	return tea.Tick(timerCheckInterval, func(time.Time) tea.Msg {
+		return timerTickMsg{}
+	})
+}
+
+// checkIdleTimerCmd stops the timer if it has gone idle. It produces no
+// message when nothing changed.
+func checkIdleTimerCmd(svc TaskService) tea.Cmd {
+	return func() tea.Msg {
+		stopped, err := svc.CheckIdleTimer(context.Background())
+		if stopped == nil && err == nil {
+			return nil
+		}
+		return TimerUpdatedMsg{Stopped: stopped, Err: err}
+	}
+}
+
+func startTimerCmd(svc TaskService, taskID string) tea.Cmd {
+	return func() tea.Msg {
+		stopped, err := svc.StartTimer(context.Background(), taskID)
+		msg := TimerUpdatedMsg{Stopped: stopped, Err: err}
+		if active := svc.ActiveTimer(); active != nil && active.TaskID == taskID {
+			msg.Started = taskID
+		}
+		return msg
+	}
+}
+
+func stopTimerCmd(svc TaskService) tea.Cmd {
+	return func() tea.Msg {
+		stopped, err := svc.StopTimer(context.Background())
+		return TimerUpdatedMsg{Stopped: stopped, Err: err}
+	}
+}
+
+// toggleTimer starts the timer on the selected task, or stops it when it is
+// already running on that task.
+func (m *Model) toggleTimer() tea.Cmd {
+	if m.taskService == nil {
+		return nil
+	}
+	active := m.taskService.ActiveTimer()
+	if m.selectedTask == nil {
+		if active == nil {
+			m.addLogLine("No task selected")
+			return nil
+		}
+		return stopTimerCmd(m.taskService)
+	}
+	if active != nil && active.TaskID == m.selectedTask.ID {
+		return stopTimerCmd(m.taskService)
+	}
+	return startTimerCmd(m.taskService, m.selectedTask.ID)
+}
+
+// timerForStatusChange starts the timer when a single task moves to
+// in-progress and stops it when the timed task moves to any other status.
+func (m *Model) timerForStatusChange(taskIDs []string, status string) tea.Cmd {
+	if m.taskService == nil {
+		return nil
+	}
+	active := m.taskService.ActiveTimer()
+	if status == taskmaster.StatusInProgress {
+		if len(taskIDs) != 1 || (active != nil && active.TaskID == taskIDs[0]) {
+			return nil
+		}
+		return startTimerCmd(m.taskService, taskIDs[0])
+	}
+	if active == nil {
+		return nil
+	}
+	for _, taskID := range taskIDs {
+		if taskID == active.TaskID {
+			return stopTimerCmd(m.taskService)
+		}
+	}
+	return nil
+}
+
+// handleTimerUpdated logs timer changes and reloads tasks when time was
+// recorded.
+func (m *Model) handleTimerUpdated(msg TimerUpdatedMsg) tea.Cmd {
+	if msg.Stopped != nil {
+		line := fmt.Sprintf("⏱ Stopped timer on task %s: %s recorded", msg.Stopped.TaskID, formatTrackedDuration(msg.Stopped.Duration()))
+		if msg.Stopped.Idle {
+			line += " (idle time discarded)"
+		}
+		m.addLogLine(line)
+	}
+	if msg.Started != "" {
+		m.addLogLine(fmt.Sprintf("⏱ Started timer on task %s", msg.Started))
+	}
+	if msg.Err != nil {
+		if errors.Is(msg.Err, timetrack.ErrNoActiveTimer) {
+			m.addLogLine("No timer running")
+		} else {
+			appErr := NewOperationError("Time Tracking", "Failed to update the task timer", msg.Err).
+				WithRecoveryHints(
+					"Check that the .taskmaster directory is writable",
+					"Try again",
+				)
+			m.showAppError(appErr)
+		}
+	}
+
+	m.updateDetailsViewport()
+	if msg.Stopped != nil {
+		return LoadTasksCmd(m.taskService)
+	}
+	return nil
+}
+
+// showTimesheetExportDialog asks for a date range and exports the timesheet.
+func (m *Model) showTimesheetExportDialog() {
+	dm := m.dialogManager()
+	if dm == nil || m.taskService == nil {
+		return
+	}
+
+	exportDialog := dialog.NewTimesheetExportDialog(time.Now(), dm.Style)
+	m.appState.AddDialog(exportDialog, func(value interface{}, err error) tea.Cmd {
+		request, ok := value.(dialog.TimesheetExportRequest)
+		if err != nil || !ok {
+			return nil
+		}
+		svc := m.taskService
+		return func() tea.Msg {
+			path, err := svc.ExportTimesheet(context.Background(), request.From, request.To, request.FilePath)
+			return TimesheetExportedMsg{FilePath: path, Err: err}
+		}
+	})
+}
+
+// trackedTime summarizes logged hours and the running timer for the details
+// panel. Returns "" when there is nothing to show.
+func (m Model) trackedTime(task *taskmaster.Task) string {
+	var parts []string
+	if task.ActualHours > 0 {
+		parts = append(parts, fmt.Sprintf("%.2fh logged", task.ActualHours))
+	}
+	if task.EstimatedHours > 0 {
+		parts = append(parts, fmt.Sprintf("%.1fh estimated", task.EstimatedHours))
+	}
+	if active := m.activeTimer(); active != nil && active.TaskID == task.ID {
+		parts = append(parts, fmt.Sprintf("⏱ running %s", formatTrackedDuration(active.Elapsed(time.Now()))))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// timerStatus renders the running timer for the status bar.
+func (m Model) timerStatus() string {
+	active := m.activeTimer()
+	if active == nil {
+		return ""
+	}
+	return fmt.Sprintf("⏱ %s %s", active.TaskID, formatTrackedDuration(active.Elapsed(time.Now())))
+}
+
+// activeTimer returns the running timer, or nil when there is none or no
+// task service.
+func (m Model) activeTimer() *timetrack.Timer {
+	if m.taskService == nil {
+		return nil
+	}
+	return m.taskService.ActiveTimer()
+}
+
+// formatTrackedDuration renders a duration to the minute, e.g. "42m" or "1h05m".
+func formatTrackedDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func createTimerTestModel() Model {
+	m := createTestModel()
+	m.taskService = mockTaskService()
+	m.ensureTaskSelected("1")
+	return m
+}
+
+// runTimerCmd runs cmd and feeds the resulting TimerUpdatedMsg back into m.
+func runTimerCmd(t *testing.T, m *Model, cmd tea.Cmd) TimerUpdatedMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatalf("expected a timer command")
+	}
+	msg, ok := cmd().(TimerUpdatedMsg)
+	if !ok {
+		t.Fatalf("expected TimerUpdatedMsg")
+	}
+	m.handleTimerUpdated(msg)
+	return msg
+}
+
+func TestToggleTimerKeyStartsAndStopsTimer(t *testing.T) {
+	m := createTimerTestModel()
+	m.registerDefaultCommandShortcuts()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if msg := runTimerCmd(t, &m, cmd); msg.Started != "1" || msg.Err != nil {
+		t.Fatalf("expected timer started on task 1, got %+v", msg)
+	}
+	if status := m.timerStatus(); !strings.Contains(status, "⏱ 1") {
+		t.Errorf("expected status bar timer for task 1, got %q", status)
+	}
+	if details := m.renderTaskDetails(); !strings.Contains(details, "running") {
+		t.Errorf("expected details panel to show the running timer")
+	}
+
+	msg := runTimerCmd(t, &m, m.toggleTimer())
+	if msg.Stopped == nil || msg.Stopped.TaskID != "1" {
+		t.Fatalf("expected timer on task 1 to stop, got %+v", msg)
+	}
+	if m.timerStatus() != "" {
+		t.Errorf("expected no timer in status bar after stop")
+	}
+}
+
+func TestTimerFollowsStatusChanges(t *testing.T) {
+	m := createTimerTestModel()
+
+	msg := runTimerCmd(t, &m, m.timerForStatusChange([]string{"2"}, taskmaster.StatusInProgress))
+	if msg.Started != "2" {
+		t.Fatalf("expected moving to in-progress to start the timer, got %+v", msg)
+	}
+	if cmd := m.timerForStatusChange([]string{"2", "3"}, taskmaster.StatusInProgress); cmd != nil {
+		t.Errorf("expected bulk in-progress changes not to switch the timer")
+	}
+	if cmd := m.timerForStatusChange([]string{"3"}, taskmaster.StatusDone); cmd != nil {
+		t.Errorf("expected status changes on other tasks to leave the timer running")
+	}
+
+	msg = runTimerCmd(t, &m, m.timerForStatusChange([]string{"2"}, taskmaster.StatusDone))
+	if msg.Stopped == nil || msg.Stopped.TaskID != "2" {
+		t.Fatalf("expected completing the timed task to stop the timer, got %+v", msg)
+	}
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	"github.com/agreen757/tm-tui/internal/ui/dialog"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoLastChange reverts the most recent task change.
+func (m *Model) undoLastChange() tea.Cmd {
+	if m.taskService == nil {
+		return nil
+	}
+	svc := m.taskService
+	return func() tea.Msg {
+		action, err := svc.Undo(context.Background())
+		if err != nil {
+			return HistoryMovedMsg{Err: err}
+		}
+		return HistoryMovedMsg{Description: "Undid: " + action.Summary}
+	}
+}
+
+// redoLastChange reapplies the most recently undone change.
+func (m *Model) redoLastChange() tea.Cmd {
+	if m.taskService == nil {
+		return nil
+	}
+	svc := m.taskService
+	return func() tea.Msg {
+		action, err := svc.Redo(context.Background())
+		if err != nil {
+			return HistoryMovedMsg{Err: err}
+		}
+		return HistoryMovedMsg{Description: "Redid: " + action.Summary}
+	}
+}
+
+// restoreHistoryCmd jumps to the point in the history around item.
+func restoreHistoryCmd(svc TaskService, item *undoHistoryItem) tea.Cmd {
+	return func() tea.Msg {
+		if err := svc.RestoreHistory(context.Background(), item.action.ID); err != nil {
+			return HistoryMovedMsg{Err: err}
+		}
+		if item.applied {
+			return HistoryMovedMsg{Description: "Restored state before: " + item.action.Summary}
+		}
+		return HistoryMovedMsg{Description: "Restored state after: " + item.action.Summary}
+	}
+}
+
+// handleHistoryMoved logs the outcome of an undo or redo and reloads tasks.
+func (m *Model) handleHistoryMoved(msg HistoryMovedMsg) tea.Cmd {
+	if msg.Err != nil {
+		switch {
+		case errors.Is(msg.Err, taskmaster.ErrNothingToUndo):
+			m.addLogLine("Nothing to undo")
+		case errors.Is(msg.Err, taskmaster.ErrNothingToRedo):
+			m.addLogLine("Nothing to redo")
+		default:
+			appErr := NewOperationError("Undo", "Failed to restore task history", msg.Err).
+				WithRecoveryHints(
+					"Check that tasks.json and .taskmaster/history are writable",
+					"Reload tasks and try again",
+				)
+			m.showAppError(appErr)
+		}
+		return nil
+	}
+	m.addLogLine("↶ " + msg.Description)
+	return LoadTasksCmd(m.taskService)
+}
+
+// showUndoHistoryDialog lists past changes, newest first. Choosing an applied
+// change undoes it and everything after it; choosing an undone change redoes
+// up to it.
+func (m *Model) showUndoHistoryDialog() {
+	dm := m.dialogManager()
+	if dm == nil || m.taskService == nil {
+		return
+	}
+
+	history := m.taskService.UndoHistory()
+	if len(history.Actions) == 0 {
+		m.addLogLine("No changes recorded yet")
+		return
+	}
+	items := make([]dialog.ListItem, 0, len(history.Actions))
+	for i := len(history.Actions) - 1; i >= 0; i-- {
+		items = append(items, newUndoHistoryItem(history.Actions[i], i < history.Position))
+	}
+
+	width := 80
+	height := 20
+	if m.width > 0 {
+		width = m.width - 20
+		if width < 60 {
+			width = 60
+		}
+	}
+
+	list := dialog.NewListDialog("Undo History", width, height, items)
+	list.SetShowDescription(true)
+	list.SetFooterHints(
+		dialog.ShortcutHint{Key: "↑/↓", Label: "Navigate"},
+		dialog.ShortcutHint{Key: "Enter", Label: "Jump here"},
+		dialog.ShortcutHint{Key: "Esc", Label: "Close"},
+	)
+	if dm.Style != nil {
+		dialog.ApplyStyleToDialog(list, dm.Style)
+	}
+
+	svc := m.taskService
+	m.appState.AddDialog(list, func(value interface{}, err error) tea.Cmd {
+		if err != nil {
+			return nil
+		}
+		msg, ok := value.(dialog.ListSelectionMsg)
+		if !ok || msg.SelectedItem == nil {
+			return nil
+		}
+		if item, ok := msg.SelectedItem.(*undoHistoryItem); ok {
+			return restoreHistoryCmd(svc, item)
+		}
+		return nil
+	})
+}
+
+// undoHistoryItem adapts an undo action to a dialog.ListItem.
+type undoHistoryItem struct {
+	action  taskmaster.UndoAction
+	applied bool
+}
+
+func newUndoHistoryItem(action taskmaster.UndoAction, applied bool) *undoHistoryItem {
+	return &undoHistoryItem{action: action, applied: applied}
+}
+
+func (i *undoHistoryItem) Title() string {
+	if i.applied {
+		return "● " + i.action.Summary
+	}
+	return "○ " + i.action.Summary + " (undone)"
+}
+
+func (i *undoHistoryItem) Description() string {
+	when := i.action.CreatedAt.Local().Format("Jan 2 15:04:05")
+	if i.applied {
+		return fmt.Sprintf("%s · Enter undoes this and later changes", when)
+	}
+	return fmt.Sprintf("%s · Enter redoes up to this change", when)
+}
+
+func (i *undoHistoryItem) FilterValue() string { return i.action.Summary }
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agreen757/tm-tui/internal/taskmaster"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestUndoRedoKeysMoveThroughHistory(t *testing.T) {
+	m := createTestModel()
+	svc := mockTaskService()
+	svc.undo = taskmaster.UndoHistory{
+		Actions: []taskmaster.UndoAction{
+			{ID: "undo-1", Summary: "Set task 1 to done", CreatedAt: time.Now()},
+			{ID: "undo-2", Summary: "Edited task 2", CreatedAt: time.Now()},
+		},
+		Position: 2,
+	}
+	m.taskService = svc
+	m.registerDefaultCommandShortcuts()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if cmd == nil {
+		t.Fatalf("expected ctrl+z to produce an undo command")
+	}
+	msg, ok := cmd().(HistoryMovedMsg)
+	if !ok || msg.Err != nil || msg.Description != "Undid: Edited task 2" {
+		t.Fatalf("expected edit undone, got %+v", msg)
+	}
+	m.handleHistoryMoved(msg)
+
+	msg = m.redoLastChange()().(HistoryMovedMsg)
+	if msg.Description != "Redid: Edited task 2" || svc.undo.Position != 2 {
+		t.Fatalf("expected edit redone, got %+v at position %d", msg, svc.undo.Position)
+	}
+	if msg := m.redoLastChange()().(HistoryMovedMsg); msg.Err == nil {
+		t.Fatalf("expected redo with nothing undone to fail")
+	}
+
+	item := newUndoHistoryItem(svc.undo.Actions[0], true)
+	msg = restoreHistoryCmd(svc, item)().(HistoryMovedMsg)
+	if msg.Err != nil || svc.undo.Position != 0 {
+		t.Fatalf("expected jump to before the first change, got %+v at position %d", msg, svc.undo.Position)
+	}
+}